@@ -2,8 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/mail"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
 )
 
 // Config holds the application configuration.
@@ -13,32 +19,140 @@ type Config struct {
 	AppEnv     string
 	Port       string
 
+	// Client IPs behind a load balancer: the IP is read from ProxyHeader,
+	// e.g. X-Real-IP, but only on requests from TrustedProxies (IPs or
+	// CIDRs). Pick a header the proxy overwrites rather than appends to.
+	ProxyHeader    string
+	TrustedProxies []string
+
 	// MongoDB — host/db จาก ConfigMap, credentials จาก Secret
 	MongoHost     string
 	MongoDBName   string
 	MongoUser     string
 	MongoPassword string
 
-	// JWT
-	JWTSecret string
+	// JWT — asymmetric signing when JWTSigningKeyFile is set, otherwise HS256 with JWTSecret
+	JWTSecret         string
+	JWTSigningKeyFile string   // PEM private key (RSA or Ed25519)
+	JWTVerifyKeyFiles []string // extra PEM keys still accepted, e.g. the previous signing key
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
+
+	// Login brute-force protection
+	LoginAttemptStore     string // "mongo" (shared across replicas) or "memory"
+	LoginMaxFailures      int    // per username within LoginFailureWindow
+	LoginMaxFailuresPerIP int    // per client IP within LoginFailureWindow
+	LoginFailureWindow    time.Duration
+	LoginLockoutBase      time.Duration // first lockout; doubles on each repeat
+	LoginLockoutMax       time.Duration
+
+	// OpenID Connect single sign-on; enabled when OIDCIssuer is set
+	OIDCIssuer        string
+	OIDCClientID      string
+	OIDCClientSecret  string // empty for a public client (PKCE only)
+	OIDCRedirectURL   string // our callback, e.g. https://api.example.com/api/v1/auth/oidc/callback
+	OIDCScopes        []string
+	OIDCUsernameClaim string
+	OIDCRoleClaim     string            // ID token claim holding provider roles or groups
+	OIDCRoleMap       map[string]string // provider role -> local role, from "staff=admin,student=student"
+	OIDCDefaultRole   string            // role when nothing maps; empty rejects the login
+
+	// Password policy, applied on registration, password change and reset
+	PasswordMinLength     int
+	PasswordRequireUpper  bool
+	PasswordRequireLower  bool
+	PasswordRequireDigit  bool
+	PasswordRequireSymbol bool
+	PasswordBlocklistFile string // extra rejected passwords, one per line
+
+	// Admin-initiated password reset
+	PasswordResetTTL      time.Duration
+	PasswordResetURL      string // page that accepts ?token=..., used in reset notifications
+	PasswordResetNotifier string // "log", "smtp" or "webhook"; uses the watch notification settings below
 
 	// Superadmin seed
 	SuperAdminUser string
 	SuperAdminPass string
 
-	// External Course gRPC
-	CourseGRPCAddr string
+	// External course API
+	CourseSources            []string // "grpc", "http" and/or "file", highest priority first
+	CourseGRPCAddr           string
+	CourseGRPCTLS            bool          // dial with TLS instead of plaintext
+	CourseGRPCCAFile         string        // PEM CA bundle; empty uses the system roots
+	CourseGRPCCertFile       string        // client certificate for mTLS
+	CourseGRPCKeyFile        string        // client key for mTLS
+	CourseGRPCServerName     string        // overrides the name verified against the server certificate
+	CourseGRPCToken          string        // bearer token sent with every RPC
+	CourseGRPCAPIKey         string        // API key sent with every RPC
+	CourseGRPCAPIKeyHeader   string        // metadata key for CourseGRPCAPIKey
+	CourseGRPCKeepalive      time.Duration // ping an idle connection with open streams after this long
+	CourseGRPCKeepaliveWait  time.Duration // how long to wait for the ping ack
+	CourseGRPCMaxAttempts    int           // attempts per RPC on UNAVAILABLE, including the first
+	CourseHTTPURL            string        // base URL; courses are fetched from <url>/<code>
+	CourseHTTPTimeout        time.Duration // HTTP client timeout
+	CourseFilePath           string        // static JSON course file for the "file" source
+	CourseAPICallTimeout     time.Duration // per-call deadline
+	CourseAPIMaxConcurrent   int           // concurrent upstream calls
+	CourseAPIBreakerFailures int           // consecutive failures that open the circuit breaker
+	CourseAPIBreakerOpenTime time.Duration // how long the breaker stays open before a trial call
+	CourseAPIBreakerTrials   int           // trial calls while half-open
+
+	// Watch notifications
+	Notifier              string // "log", "smtp" or "webhook"
+	SMTPHost              string
+	SMTPPort              int
+	SMTPUsername          string // empty sends without authentication
+	SMTPPassword          string
+	SMTPFrom              string
+	SMTPRecipientDomain   string // appended to usernames that are not e-mail addresses
+	SMTPTimeout           time.Duration
+	NotifyWebhookURL      string
+	NotifyWebhookSecret   string // signs notification bodies with HMAC-SHA256
+	NotifyWebhookTimeout  time.Duration
+	NotifyMaxAttempts     int
+	NotifyRetryBackoff    time.Duration // wait after the first failed attempt, doubled after each one
+	NotifyRetryBackoffMax time.Duration
+	NotifyPollInterval    time.Duration // how often due notifications are sent
+
+	// Outbound webhooks
+	WebhookTimeout         time.Duration
+	WebhookMaxAttempts     int
+	WebhookRetryBackoff    time.Duration // wait after the first failed attempt, doubled after each one
+	WebhookRetryBackoffMax time.Duration
+	WebhookPollInterval    time.Duration // how often due deliveries are sent
+
+	// Domain event outbox
+	OutboxSinks           []string      // where events are relayed: webhook, log
+	OutboxPollInterval    time.Duration // how often pending events are relayed
+	OutboxRetryBackoff    time.Duration // wait after the first failed relay, doubled after each one
+	OutboxRetryBackoffMax time.Duration
 }
 
-// requiredEnvVars lists every environment variable that must be set in production.
+// requiredEnvVars lists every environment variable that must be set in
+// production. JWT_SECRET is also required unless JWT_SIGNING_KEY_FILE is set.
 var requiredEnvVars = []string{
 	"APP_NAME", "APP_VERSION", "APP_ENV", "PORT",
 	"MONGO_HOST", "MONGO_DB_NAME",
 	"MONGO_INITDB_ROOT_USERNAME", "MONGO_INITDB_ROOT_PASSWORD",
-	"JWT_SECRET",
 	"SUPER_ADMIN_USER", "SUPER_ADMIN_PASS",
 }
 
+// Production secret requirements.
+const (
+	minJWTSecretBytes     = 32 // HS256 key size
+	minSuperAdminPassword = 12 // in characters
+)
+
+// knownDefaultSecrets are values shipped in examples and docs, or otherwise
+// guessed first; production refuses them for any secret. Compared
+// case-insensitively.
+var knownDefaultSecrets = map[string]bool{
+	"change-me": true, "changeme": true, "change-this": true, "changethis": true,
+	"secret": true, "jwt-secret": true, "jwtsecret": true, "my-secret": true, "your-secret": true,
+	"password": true, "password123": true, "admin": true, "admin123": true, "root": true,
+	"superadmin": true, "superadmin123": true, "test": true, "example": true,
+}
+
 // Load reads configuration from environment variables.
 // In production all variables must be explicitly set; missing ones cause an error.
 func Load() (*Config, error) {
@@ -50,30 +164,409 @@ func Load() (*Config, error) {
 		}
 	}
 
+	proxyHeader := getEnv("PROXY_HEADER", "")
+	trustedProxies := getList("TRUSTED_PROXIES")
+	if proxyHeader != "" && len(trustedProxies) == 0 {
+		return nil, fmt.Errorf("TRUSTED_PROXIES is required when PROXY_HEADER is set")
+	}
+	for _, proxy := range trustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: must be an IP address or CIDR", proxy)
+			}
+		}
+	}
+
+	accessTTL, err := getDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	refreshTTL, err := getDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	loginStore := getEnv("LOGIN_ATTEMPT_STORE", "mongo")
+	if loginStore != "mongo" && loginStore != "memory" {
+		return nil, fmt.Errorf("invalid LOGIN_ATTEMPT_STORE %q: must be mongo or memory", loginStore)
+	}
+	loginMaxFailures, err := getInt("LOGIN_MAX_FAILURES", 5)
+	if err != nil {
+		return nil, err
+	}
+	loginMaxPerIP, err := getInt("LOGIN_MAX_FAILURES_PER_IP", 20)
+	if err != nil {
+		return nil, err
+	}
+	loginWindow, err := getDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	lockoutBase, err := getDuration("LOGIN_LOCKOUT_BASE", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	lockoutMax, err := getDuration("LOGIN_LOCKOUT_MAX", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if lockoutMax < lockoutBase {
+		return nil, fmt.Errorf("LOGIN_LOCKOUT_MAX (%s) must not be shorter than LOGIN_LOCKOUT_BASE (%s)", lockoutMax, lockoutBase)
+	}
+
+	oidcIssuer := getEnv("OIDC_ISSUER", "")
+	oidcClientID := getEnv("OIDC_CLIENT_ID", "")
+	oidcRedirectURL := getEnv("OIDC_REDIRECT_URL", "")
+	if oidcIssuer != "" && (oidcClientID == "" || oidcRedirectURL == "") {
+		return nil, fmt.Errorf("OIDC_ISSUER is set: OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required")
+	}
+	oidcRoleMap, err := getRoleMap("OIDC_ROLE_MAP")
+	if err != nil {
+		return nil, err
+	}
+	oidcDefaultRole := getEnv("OIDC_DEFAULT_ROLE", constants.RoleStudent)
+	if oidcDefaultRole != "" && !assignableRole(oidcDefaultRole) {
+		return nil, fmt.Errorf("invalid OIDC_DEFAULT_ROLE %q: must be admin, student or empty", oidcDefaultRole)
+	}
+	oidcScopes := getList("OIDC_SCOPES")
+	if oidcScopes == nil {
+		oidcScopes = []string{"openid", "profile", "email"}
+	}
+
+	passwordMinLength, err := getInt("PASSWORD_MIN_LENGTH", 8)
+	if err != nil {
+		return nil, err
+	}
+	passwordUpper, err := getBool("PASSWORD_REQUIRE_UPPER", true)
+	if err != nil {
+		return nil, err
+	}
+	passwordLower, err := getBool("PASSWORD_REQUIRE_LOWER", true)
+	if err != nil {
+		return nil, err
+	}
+	passwordDigit, err := getBool("PASSWORD_REQUIRE_DIGIT", true)
+	if err != nil {
+		return nil, err
+	}
+	passwordSymbol, err := getBool("PASSWORD_REQUIRE_SYMBOL", false)
+	if err != nil {
+		return nil, err
+	}
+	passwordResetTTL, err := getDuration("PASSWORD_RESET_TTL", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	courseSources := getList("COURSE_API")
+	if len(courseSources) == 0 {
+		courseSources = []string{"grpc"}
+	}
+	seenSources := make(map[string]bool, len(courseSources))
+	for _, src := range courseSources {
+		if src != "grpc" && src != "http" && src != "file" {
+			return nil, fmt.Errorf("invalid COURSE_API %q: sources must be grpc, http or file", src)
+		}
+		if seenSources[src] {
+			return nil, fmt.Errorf("invalid COURSE_API %q: listed twice", src)
+		}
+		seenSources[src] = true
+	}
+	courseFilePath := getEnv("COURSE_FILE_PATH", "")
+	if seenSources["file"] && courseFilePath == "" {
+		return nil, fmt.Errorf("COURSE_FILE_PATH is required when COURSE_API includes file")
+	}
+	grpcTLS, err := getBool("COURSE_GRPC_TLS", false)
+	if err != nil {
+		return nil, err
+	}
+	grpcCAFile, grpcCertFile, grpcKeyFile := getEnv("COURSE_GRPC_CA_FILE", ""), getEnv("COURSE_GRPC_CERT_FILE", ""), getEnv("COURSE_GRPC_KEY_FILE", "")
+	grpcServerName := getEnv("COURSE_GRPC_SERVER_NAME", "")
+	if !grpcTLS && (grpcCAFile != "" || grpcCertFile != "" || grpcKeyFile != "" || grpcServerName != "") {
+		return nil, fmt.Errorf("COURSE_GRPC_CA_FILE, COURSE_GRPC_CERT_FILE, COURSE_GRPC_KEY_FILE and COURSE_GRPC_SERVER_NAME require COURSE_GRPC_TLS=true")
+	}
+	if (grpcCertFile == "") != (grpcKeyFile == "") {
+		return nil, fmt.Errorf("COURSE_GRPC_CERT_FILE and COURSE_GRPC_KEY_FILE must be set together")
+	}
+	grpcToken, grpcAPIKey := getEnv("COURSE_GRPC_TOKEN", ""), getEnv("COURSE_GRPC_API_KEY", "")
+	if grpcToken != "" && grpcAPIKey != "" {
+		return nil, fmt.Errorf("set only one of COURSE_GRPC_TOKEN and COURSE_GRPC_API_KEY")
+	}
+	if appEnv == "production" && seenSources["grpc"] && !grpcTLS && (grpcToken != "" || grpcAPIKey != "") {
+		return nil, fmt.Errorf("production mode: COURSE_GRPC_TOKEN and COURSE_GRPC_API_KEY require COURSE_GRPC_TLS=true")
+	}
+	grpcKeepalive, err := getDuration("COURSE_GRPC_KEEPALIVE_TIME", 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	grpcKeepaliveWait, err := getDuration("COURSE_GRPC_KEEPALIVE_TIMEOUT", 20*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	grpcMaxAttempts, err := getInt("COURSE_GRPC_MAX_ATTEMPTS", 3)
+	if err != nil {
+		return nil, err
+	}
+	if grpcMaxAttempts < 1 || grpcMaxAttempts > 5 {
+		return nil, fmt.Errorf("invalid COURSE_GRPC_MAX_ATTEMPTS %d: must be between 1 and 5", grpcMaxAttempts)
+	}
+	courseHTTPTimeout, err := getDuration("COURSE_HTTP_TIMEOUT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	courseCallTimeout, err := getDuration("COURSE_API_CALL_TIMEOUT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	courseMaxConcurrent, err := getInt("COURSE_API_MAX_CONCURRENT", 4)
+	if err != nil {
+		return nil, err
+	}
+	breakerFailures, err := getInt("COURSE_API_BREAKER_FAILURES", 5)
+	if err != nil {
+		return nil, err
+	}
+	breakerOpenTime, err := getDuration("COURSE_API_BREAKER_OPEN_TIMEOUT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	breakerTrials, err := getInt("COURSE_API_BREAKER_HALF_OPEN_CALLS", 1)
+	if err != nil {
+		return nil, err
+	}
+	if courseMaxConcurrent < 1 || breakerFailures < 1 || breakerTrials < 1 {
+		return nil, fmt.Errorf("COURSE_API_MAX_CONCURRENT, COURSE_API_BREAKER_FAILURES and COURSE_API_BREAKER_HALF_OPEN_CALLS must be at least 1")
+	}
+
+	notifierKind := getEnv("NOTIFIER", "log")
+	resetNotifierKind := getEnv("PASSWORD_RESET_NOTIFIER", "log")
+	smtpHost, smtpFrom := getEnv("SMTP_HOST", ""), getEnv("SMTP_FROM", "")
+	notifyWebhookURL := getEnv("NOTIFY_WEBHOOK_URL", "")
+	for _, n := range []struct{ key, kind string }{{"NOTIFIER", notifierKind}, {"PASSWORD_RESET_NOTIFIER", resetNotifierKind}} {
+		switch n.kind {
+		case "log":
+		case "smtp":
+			if smtpHost == "" || smtpFrom == "" {
+				return nil, fmt.Errorf("SMTP_HOST and SMTP_FROM are required when %s is smtp", n.key)
+			}
+			if _, err := mail.ParseAddress(smtpFrom); err != nil {
+				return nil, fmt.Errorf("invalid SMTP_FROM %q: %w", smtpFrom, err)
+			}
+		case "webhook":
+			if notifyWebhookURL == "" {
+				return nil, fmt.Errorf("NOTIFY_WEBHOOK_URL is required when %s is webhook", n.key)
+			}
+		default:
+			return nil, fmt.Errorf("invalid %s %q: must be log, smtp or webhook", n.key, n.kind)
+		}
+	}
+	smtpPort, err := getInt("SMTP_PORT", 587)
+	if err != nil {
+		return nil, err
+	}
+	if smtpPort < 1 || smtpPort > 65535 {
+		return nil, fmt.Errorf("invalid SMTP_PORT %d: must be between 1 and 65535", smtpPort)
+	}
+	smtpTimeout, err := getDuration("SMTP_TIMEOUT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	notifyWebhookTimeout, err := getDuration("NOTIFY_WEBHOOK_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	notifyMaxAttempts, err := getInt("NOTIFY_MAX_ATTEMPTS", 5)
+	if err != nil {
+		return nil, err
+	}
+	if notifyMaxAttempts < 1 {
+		return nil, fmt.Errorf("NOTIFY_MAX_ATTEMPTS must be at least 1")
+	}
+	notifyRetryBackoff, err := getDuration("NOTIFY_RETRY_BACKOFF", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	notifyRetryBackoffMax, err := getDuration("NOTIFY_RETRY_BACKOFF_MAX", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if notifyRetryBackoffMax < notifyRetryBackoff {
+		return nil, fmt.Errorf("NOTIFY_RETRY_BACKOFF_MAX must not be less than NOTIFY_RETRY_BACKOFF")
+	}
+	notifyPollInterval, err := getDuration("NOTIFY_POLL_INTERVAL", 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookTimeout, err := getDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	webhookMaxAttempts, err := getInt("WEBHOOK_MAX_ATTEMPTS", 8)
+	if err != nil {
+		return nil, err
+	}
+	if webhookMaxAttempts < 1 {
+		return nil, fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+	webhookRetryBackoff, err := getDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	webhookRetryBackoffMax, err := getDuration("WEBHOOK_RETRY_BACKOFF_MAX", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if webhookRetryBackoffMax < webhookRetryBackoff {
+		return nil, fmt.Errorf("WEBHOOK_RETRY_BACKOFF_MAX must not be less than WEBHOOK_RETRY_BACKOFF")
+	}
+	webhookPollInterval, err := getDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxSinks := getList("OUTBOX_SINKS")
+	if len(outboxSinks) == 0 {
+		outboxSinks = []string{"webhook"}
+	}
+	seenSinks := make(map[string]bool, len(outboxSinks))
+	for _, sink := range outboxSinks {
+		if sink != "webhook" && sink != "log" {
+			return nil, fmt.Errorf("invalid OUTBOX_SINKS %q: sinks must be webhook or log", sink)
+		}
+		if seenSinks[sink] {
+			return nil, fmt.Errorf("invalid OUTBOX_SINKS %q: listed twice", sink)
+		}
+		seenSinks[sink] = true
+	}
+	outboxPollInterval, err := getDuration("OUTBOX_POLL_INTERVAL", time.Second)
+	if err != nil {
+		return nil, err
+	}
+	outboxRetryBackoff, err := getDuration("OUTBOX_RETRY_BACKOFF", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	outboxRetryBackoffMax, err := getDuration("OUTBOX_RETRY_BACKOFF_MAX", 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if outboxRetryBackoffMax < outboxRetryBackoff {
+		return nil, fmt.Errorf("OUTBOX_RETRY_BACKOFF_MAX must not be less than OUTBOX_RETRY_BACKOFF")
+	}
+
 	return &Config{
 		AppName:    getEnv("APP_NAME", "calendar-reg-main-api"),
 		AppVersion: getEnv("APP_VERSION", "0.1.0"),
 		AppEnv:     appEnv,
 		Port:       getEnv("PORT", "8080"),
 
+		ProxyHeader:    proxyHeader,
+		TrustedProxies: trustedProxies,
+
 		MongoHost:     getEnv("MONGO_HOST", "localhost:27017"),
 		MongoDBName:   getEnv("MONGO_DB_NAME", "calendar-reg"),
 		MongoUser:     getEnv("MONGO_INITDB_ROOT_USERNAME", ""),
 		MongoPassword: getEnv("MONGO_INITDB_ROOT_PASSWORD", ""),
 
-		JWTSecret: getEnv("JWT_SECRET", "change-me"),
+		JWTSecret:         getEnv("JWT_SECRET", "change-me"),
+		JWTSigningKeyFile: getEnv("JWT_SIGNING_KEY_FILE", ""),
+		JWTVerifyKeyFiles: getList("JWT_VERIFY_KEY_FILES"),
+		AccessTokenTTL:    accessTTL,
+		RefreshTokenTTL:   refreshTTL,
+
+		LoginAttemptStore:     loginStore,
+		LoginMaxFailures:      loginMaxFailures,
+		LoginMaxFailuresPerIP: loginMaxPerIP,
+		LoginFailureWindow:    loginWindow,
+		LoginLockoutBase:      lockoutBase,
+		LoginLockoutMax:       lockoutMax,
+
+		OIDCIssuer:        oidcIssuer,
+		OIDCClientID:      oidcClientID,
+		OIDCClientSecret:  getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:   oidcRedirectURL,
+		OIDCScopes:        oidcScopes,
+		OIDCUsernameClaim: getEnv("OIDC_USERNAME_CLAIM", "preferred_username"),
+		OIDCRoleClaim:     getEnv("OIDC_ROLE_CLAIM", ""),
+		OIDCRoleMap:       oidcRoleMap,
+		OIDCDefaultRole:   oidcDefaultRole,
+
+		PasswordMinLength:     passwordMinLength,
+		PasswordRequireUpper:  passwordUpper,
+		PasswordRequireLower:  passwordLower,
+		PasswordRequireDigit:  passwordDigit,
+		PasswordRequireSymbol: passwordSymbol,
+		PasswordBlocklistFile: getEnv("PASSWORD_BLOCKLIST_FILE", ""),
+		PasswordResetTTL:      passwordResetTTL,
+		PasswordResetURL:      getEnv("PASSWORD_RESET_URL", ""),
+		PasswordResetNotifier: resetNotifierKind,
 
 		SuperAdminUser: getEnv("SUPER_ADMIN_USER", "superadmin"),
 		SuperAdminPass: getEnv("SUPER_ADMIN_PASS", "superadmin123"),
 
-		CourseGRPCAddr: getEnv("COURSE_GRPC_ADDR", "localhost:50051"),
+		CourseSources:            courseSources,
+		CourseGRPCAddr:           getEnv("COURSE_GRPC_ADDR", "localhost:50051"),
+		CourseGRPCTLS:            grpcTLS,
+		CourseGRPCCAFile:         grpcCAFile,
+		CourseGRPCCertFile:       grpcCertFile,
+		CourseGRPCKeyFile:        grpcKeyFile,
+		CourseGRPCServerName:     grpcServerName,
+		CourseGRPCToken:          grpcToken,
+		CourseGRPCAPIKey:         grpcAPIKey,
+		CourseGRPCAPIKeyHeader:   getEnv("COURSE_GRPC_API_KEY_HEADER", "x-api-key"),
+		CourseGRPCKeepalive:      grpcKeepalive,
+		CourseGRPCKeepaliveWait:  grpcKeepaliveWait,
+		CourseGRPCMaxAttempts:    grpcMaxAttempts,
+		CourseHTTPURL:            getEnv("COURSE_HTTP_URL", "http://localhost:8888"),
+		CourseHTTPTimeout:        courseHTTPTimeout,
+		CourseFilePath:           courseFilePath,
+		CourseAPICallTimeout:     courseCallTimeout,
+		CourseAPIMaxConcurrent:   courseMaxConcurrent,
+		CourseAPIBreakerFailures: breakerFailures,
+		CourseAPIBreakerOpenTime: breakerOpenTime,
+		CourseAPIBreakerTrials:   breakerTrials,
+
+		Notifier:              notifierKind,
+		SMTPHost:              smtpHost,
+		SMTPPort:              smtpPort,
+		SMTPUsername:          getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:          getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:              smtpFrom,
+		SMTPRecipientDomain:   getEnv("SMTP_RECIPIENT_DOMAIN", ""),
+		SMTPTimeout:           smtpTimeout,
+		NotifyWebhookURL:      notifyWebhookURL,
+		NotifyWebhookSecret:   getEnv("NOTIFY_WEBHOOK_SECRET", ""),
+		NotifyWebhookTimeout:  notifyWebhookTimeout,
+		NotifyMaxAttempts:     notifyMaxAttempts,
+		NotifyRetryBackoff:    notifyRetryBackoff,
+		NotifyRetryBackoffMax: notifyRetryBackoffMax,
+		NotifyPollInterval:    notifyPollInterval,
+
+		WebhookTimeout:         webhookTimeout,
+		WebhookMaxAttempts:     webhookMaxAttempts,
+		WebhookRetryBackoff:    webhookRetryBackoff,
+		WebhookRetryBackoffMax: webhookRetryBackoffMax,
+		WebhookPollInterval:    webhookPollInterval,
+
+		OutboxSinks:           outboxSinks,
+		OutboxPollInterval:    outboxPollInterval,
+		OutboxRetryBackoff:    outboxRetryBackoff,
+		OutboxRetryBackoffMax: outboxRetryBackoffMax,
 	}, nil
 }
 
-// validateProduction checks that every required environment variable is set.
+// validateProduction checks that every required environment variable is set
+// and that no secret is a known default or too weak. Secret values are never
+// included in the error.
 func validateProduction() error {
+	required := requiredEnvVars
+	useJWTSecret := getEnv("JWT_SIGNING_KEY_FILE", "") == ""
+	if useJWTSecret {
+		required = append(required[:len(required):len(required)], "JWT_SECRET")
+	}
 	var missing []string
-	for _, key := range requiredEnvVars {
+	for _, key := range required {
 		if _, ok := os.LookupEnv(key); !ok {
 			missing = append(missing, key)
 		}
@@ -81,12 +574,116 @@ func validateProduction() error {
 	if len(missing) > 0 {
 		return fmt.Errorf("production mode: missing required environment variables: %s", strings.Join(missing, ", "))
 	}
+
+	var weak []string
+	if useJWTSecret {
+		secret := os.Getenv("JWT_SECRET")
+		if isKnownDefault(secret) {
+			weak = append(weak, "JWT_SECRET is a known default")
+		} else if len(secret) < minJWTSecretBytes {
+			weak = append(weak, fmt.Sprintf("JWT_SECRET must be at least %d bytes", minJWTSecretBytes))
+		}
+	}
+	adminUser, adminPass := os.Getenv("SUPER_ADMIN_USER"), os.Getenv("SUPER_ADMIN_PASS")
+	switch {
+	case isKnownDefault(adminPass):
+		weak = append(weak, "SUPER_ADMIN_PASS is a known default")
+	case len([]rune(adminPass)) < minSuperAdminPassword:
+		weak = append(weak, fmt.Sprintf("SUPER_ADMIN_PASS must be at least %d characters", minSuperAdminPassword))
+	case adminUser != "" && strings.Contains(strings.ToLower(adminPass), strings.ToLower(adminUser)):
+		weak = append(weak, "SUPER_ADMIN_PASS must not contain SUPER_ADMIN_USER")
+	}
+	if isKnownDefault(os.Getenv("MONGO_INITDB_ROOT_PASSWORD")) {
+		weak = append(weak, "MONGO_INITDB_ROOT_PASSWORD is a known default")
+	}
+	if len(weak) > 0 {
+		return fmt.Errorf("production mode: insecure secrets: %s", strings.Join(weak, "; "))
+	}
+	// The log notifier writes live reset tokens to the server log.
+	if getEnv("PASSWORD_RESET_NOTIFIER", "log") == "log" {
+		return fmt.Errorf("production mode: PASSWORD_RESET_NOTIFIER must be smtp or webhook")
+	}
 	return nil
 }
 
+// isKnownDefault reports whether a secret is empty or a known default.
+func isKnownDefault(secret string) bool {
+	return strings.TrimSpace(secret) == "" || knownDefaultSecrets[strings.ToLower(secret)]
+}
+
 func getEnv(key, fallback string) string {
 	if val, ok := os.LookupEnv(key); ok {
 		return val
 	}
 	return fallback
 }
+
+// getList splits a comma-separated variable, dropping empty entries.
+func getList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(getEnv(key, ""), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// getRoleMap parses "provider=local,..." pairs mapping identity provider
+// roles onto local roles. Superadmin can never be mapped.
+func getRoleMap(key string) (map[string]string, error) {
+	m := map[string]string{}
+	for _, pair := range getList(key) {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || !assignableRole(to) {
+			return nil, fmt.Errorf("invalid %s entry %q: expected provider-role=admin|student", key, pair)
+		}
+		m[from] = to
+	}
+	return m, nil
+}
+
+// assignableRole reports whether role may be granted by configuration.
+func assignableRole(role string) bool {
+	return constants.ValidRoles[role] && role != constants.RoleSuperAdmin
+}
+
+// getInt parses a non-negative integer from key.
+func getInt(key string, fallback int) (int, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative integer", key, val)
+	}
+	return n, nil
+}
+
+// getBool parses a boolean ("true", "false", "1", "0", ...) from key.
+func getBool(key string, fallback bool) (bool, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", key, val)
+	}
+	return b, nil
+}
+
+// getDuration parses a Go duration (e.g. "15m", "168h") from key.
+func getDuration(key string, fallback time.Duration) (time.Duration, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive duration such as 15m", key, val)
+	}
+	return d, nil
+}
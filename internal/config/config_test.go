@@ -2,15 +2,25 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
+	"time"
 )
 
-// setAllEnvVars sets every required env var to a dummy value and returns a cleanup function.
+// setAllEnvVars sets every required env var to a dummy value, using values
+// that pass the production secret checks for the secrets.
 func setAllEnvVars(t *testing.T) {
 	t.Helper()
 	for _, key := range requiredEnvVars {
 		t.Setenv(key, "test-value")
 	}
+	t.Setenv("JWT_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("JWT_SIGNING_KEY_FILE", "")
+	t.Setenv("MONGO_INITDB_ROOT_PASSWORD", "mongo-root-Pw-7f3a")
+	t.Setenv("SUPER_ADMIN_USER", "root-admin")
+	t.Setenv("SUPER_ADMIN_PASS", "Kx9-long-seed-pass")
+	t.Setenv("PASSWORD_RESET_NOTIFIER", "webhook")
+	t.Setenv("NOTIFY_WEBHOOK_URL", "https://notify.example.com/hook")
 }
 
 func TestLoad_Production_AllSet(t *testing.T) {
@@ -28,7 +38,7 @@ func TestLoad_Production_AllSet(t *testing.T) {
 
 func TestLoad_Production_MissingVars(t *testing.T) {
 	// Clear all env vars to ensure missing keys
-	for _, key := range requiredEnvVars {
+	for _, key := range append(requiredEnvVars, "JWT_SECRET", "JWT_SIGNING_KEY_FILE") {
 		os.Unsetenv(key)
 	}
 	t.Setenv("APP_ENV", "production")
@@ -46,6 +56,60 @@ func TestLoad_Production_MissingVars(t *testing.T) {
 	}
 }
 
+func TestLoad_Production_SigningKeyFileMakesJWTSecretOptional(t *testing.T) {
+	setAllEnvVars(t)
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("JWT_SIGNING_KEY_FILE", "/etc/keys/jwt.pem")
+	os.Unsetenv("JWT_SECRET")
+
+	if _, err := Load(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestLoad_Production_RejectsLogPasswordResetNotifier(t *testing.T) {
+	setAllEnvVars(t)
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("PASSWORD_RESET_NOTIFIER", "log")
+
+	_, err := Load()
+	if err == nil || !contains(err.Error(), "PASSWORD_RESET_NOTIFIER must be smtp or webhook") {
+		t.Fatalf("expected the log reset notifier to be rejected, got: %v", err)
+	}
+}
+
+func TestLoad_Production_RejectsWeakSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"default jwt secret", "JWT_SECRET", "change-me", "JWT_SECRET is a known default"},
+		{"short jwt secret", "JWT_SECRET", "s3cr3t-but-short", "JWT_SECRET must be at least 32 bytes"},
+		{"default superadmin password", "SUPER_ADMIN_PASS", "superadmin123", "SUPER_ADMIN_PASS is a known default"},
+		{"empty superadmin password", "SUPER_ADMIN_PASS", "", "SUPER_ADMIN_PASS is a known default"},
+		{"short superadmin password", "SUPER_ADMIN_PASS", "Sh0rt-pass", "SUPER_ADMIN_PASS must be at least 12 characters"},
+		{"superadmin password contains username", "SUPER_ADMIN_PASS", "Root-Admin-2026!", "must not contain SUPER_ADMIN_USER"},
+		{"default mongo password", "MONGO_INITDB_ROOT_PASSWORD", "password", "MONGO_INITDB_ROOT_PASSWORD is a known default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setAllEnvVars(t)
+			t.Setenv("APP_ENV", "production")
+			t.Setenv(tt.key, tt.value)
+
+			_, err := Load()
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+			if tt.value != "" && contains(err.Error(), tt.value) {
+				t.Errorf("error must not reveal the secret: %v", err)
+			}
+		})
+	}
+}
+
 func TestLoad_Development_UsesDefaults(t *testing.T) {
 	// Clear all env vars
 	for _, key := range requiredEnvVars {
@@ -65,6 +129,45 @@ func TestLoad_Development_UsesDefaults(t *testing.T) {
 	}
 }
 
+func TestLoad_TokenTTLs(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("ACCESS_TOKEN_TTL", "5m")
+	t.Setenv("REFRESH_TOKEN_TTL", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AccessTokenTTL != 5*time.Minute {
+		t.Errorf("expected AccessTokenTTL=5m, got %s", cfg.AccessTokenTTL)
+	}
+	if cfg.RefreshTokenTTL != 7*24*time.Hour {
+		t.Errorf("expected default RefreshTokenTTL, got %s", cfg.RefreshTokenTTL)
+	}
+
+	t.Setenv("ACCESS_TOKEN_TTL", "soon")
+	if _, err := Load(); err == nil || !contains(err.Error(), "ACCESS_TOKEN_TTL") {
+		t.Errorf("expected ACCESS_TOKEN_TTL error, got %v", err)
+	}
+}
+
+func TestLoad_JWTKeyFiles(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("JWT_SIGNING_KEY_FILE", "/keys/current.pem")
+	t.Setenv("JWT_VERIFY_KEY_FILES", "/keys/previous.pem, ,/keys/older.pem")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.JWTSigningKeyFile != "/keys/current.pem" {
+		t.Errorf("unexpected signing key file %q", cfg.JWTSigningKeyFile)
+	}
+	if len(cfg.JWTVerifyKeyFiles) != 2 || cfg.JWTVerifyKeyFiles[1] != "/keys/older.pem" {
+		t.Errorf("unexpected verify key files %v", cfg.JWTVerifyKeyFiles)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && searchSubstring(s, substr)
 }
@@ -77,3 +180,407 @@ func searchSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLoad_LoginThrottle(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("LOGIN_MAX_FAILURES", "3")
+	t.Setenv("LOGIN_LOCKOUT_BASE", "30s")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LoginAttemptStore != "mongo" || cfg.LoginMaxFailures != 3 || cfg.LoginMaxFailuresPerIP != 20 {
+		t.Errorf("unexpected login limits: %+v", cfg)
+	}
+	if cfg.LoginLockoutBase != 30*time.Second || cfg.LoginLockoutMax != time.Hour {
+		t.Errorf("unexpected lockouts: base=%s max=%s", cfg.LoginLockoutBase, cfg.LoginLockoutMax)
+	}
+
+	for key, val := range map[string]string{
+		"LOGIN_ATTEMPT_STORE": "redis",
+		"LOGIN_MAX_FAILURES":  "-1",
+		"LOGIN_LOCKOUT_MAX":   "10s",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, val)
+			if _, err := Load(); err == nil || !contains(err.Error(), key) {
+				t.Errorf("expected %s error, got %v", key, err)
+			}
+		})
+	}
+}
+
+func TestLoad_OIDC(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("OIDC_ISSUER", "https://sso.example.ac.th")
+	t.Setenv("OIDC_CLIENT_ID", "calendar-reg")
+	t.Setenv("OIDC_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/callback")
+	t.Setenv("OIDC_ROLE_CLAIM", "groups")
+	t.Setenv("OIDC_ROLE_MAP", "staff=admin, students=student")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OIDCUsernameClaim != "preferred_username" || cfg.OIDCDefaultRole != "student" || len(cfg.OIDCScopes) != 3 {
+		t.Errorf("unexpected OIDC defaults: %+v", cfg)
+	}
+	if cfg.OIDCRoleMap["staff"] != "admin" || cfg.OIDCRoleMap["students"] != "student" {
+		t.Errorf("unexpected role map: %v", cfg.OIDCRoleMap)
+	}
+
+	for key, val := range map[string]string{
+		"OIDC_CLIENT_ID":    "",
+		"OIDC_ROLE_MAP":     "staff=superadmin",
+		"OIDC_DEFAULT_ROLE": "guest",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, val)
+			if _, err := Load(); err == nil || !contains(err.Error(), key) {
+				t.Errorf("expected %s error, got %v", key, err)
+			}
+		})
+	}
+}
+
+func TestLoad_PasswordPolicy(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PasswordMinLength != 8 || !cfg.PasswordRequireUpper || !cfg.PasswordRequireLower ||
+		!cfg.PasswordRequireDigit || cfg.PasswordRequireSymbol || cfg.PasswordResetTTL != time.Hour {
+		t.Errorf("unexpected password defaults: %+v", cfg)
+	}
+
+	t.Setenv("PASSWORD_MIN_LENGTH", "12")
+	t.Setenv("PASSWORD_REQUIRE_UPPER", "false")
+	t.Setenv("PASSWORD_REQUIRE_SYMBOL", "1")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PasswordMinLength != 12 || cfg.PasswordRequireUpper || !cfg.PasswordRequireSymbol {
+		t.Errorf("password policy not read from env: %+v", cfg)
+	}
+
+	for key, val := range map[string]string{
+		"PASSWORD_MIN_LENGTH":    "-1",
+		"PASSWORD_REQUIRE_DIGIT": "sometimes",
+		"PASSWORD_RESET_TTL":     "forever",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, val)
+			if _, err := Load(); err == nil || !contains(err.Error(), key) {
+				t.Errorf("expected %s error, got %v", key, err)
+			}
+		})
+	}
+}
+
+func TestLoad_CourseAPIResilience(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CourseAPICallTimeout != 30*time.Second || cfg.CourseAPIMaxConcurrent != 4 || cfg.CourseAPIBreakerFailures != 5 ||
+		cfg.CourseAPIBreakerOpenTime != 30*time.Second || cfg.CourseAPIBreakerTrials != 1 {
+		t.Errorf("unexpected course API defaults: %+v", cfg)
+	}
+
+	t.Setenv("COURSE_API_CALL_TIMEOUT", "5s")
+	t.Setenv("COURSE_API_BREAKER_FAILURES", "2")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CourseAPICallTimeout != 5*time.Second || cfg.CourseAPIBreakerFailures != 2 {
+		t.Errorf("course API settings not read from env: %+v", cfg)
+	}
+
+	for key, val := range map[string]string{
+		"COURSE_API_CALL_TIMEOUT":            "0s",
+		"COURSE_API_MAX_CONCURRENT":          "0",
+		"COURSE_API_BREAKER_HALF_OPEN_CALLS": "none",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, val)
+			if _, err := Load(); err == nil || !contains(err.Error(), key) {
+				t.Errorf("expected %s error, got %v", key, err)
+			}
+		})
+	}
+}
+
+func TestLoad_CourseAPISources(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.CourseSources, []string{"grpc"}) || cfg.CourseHTTPURL != "http://localhost:8888" || cfg.CourseHTTPTimeout != 30*time.Second {
+		t.Errorf("unexpected course source defaults: %+v", cfg)
+	}
+
+	t.Setenv("COURSE_API", "http, grpc,file")
+	t.Setenv("COURSE_HTTP_URL", "http://courses:8888")
+	t.Setenv("COURSE_HTTP_TIMEOUT", "10s")
+	t.Setenv("COURSE_FILE_PATH", "/etc/courses.json")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.CourseSources, []string{"http", "grpc", "file"}) || cfg.CourseHTTPURL != "http://courses:8888" ||
+		cfg.CourseHTTPTimeout != 10*time.Second || cfg.CourseFilePath != "/etc/courses.json" {
+		t.Errorf("course sources not read from env: %+v", cfg)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"unknown source":  {"COURSE_API": "grpc,soap"},
+		"duplicate":       {"COURSE_API": "grpc,grpc"},
+		"file needs path": {"COURSE_API": "file", "COURSE_FILE_PATH": ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(); err == nil || !contains(err.Error(), "COURSE_") {
+				t.Errorf("expected COURSE_API error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad_CourseGRPCConnection(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CourseGRPCTLS || cfg.CourseGRPCAPIKeyHeader != "x-api-key" || cfg.CourseGRPCKeepalive != 5*time.Minute ||
+		cfg.CourseGRPCKeepaliveWait != 20*time.Second || cfg.CourseGRPCMaxAttempts != 3 {
+		t.Errorf("unexpected gRPC defaults: %+v", cfg)
+	}
+
+	t.Setenv("COURSE_GRPC_TLS", "true")
+	t.Setenv("COURSE_GRPC_CA_FILE", "/etc/ca.pem")
+	t.Setenv("COURSE_GRPC_CERT_FILE", "/etc/client.pem")
+	t.Setenv("COURSE_GRPC_KEY_FILE", "/etc/client-key.pem")
+	t.Setenv("COURSE_GRPC_SERVER_NAME", "courses.internal")
+	t.Setenv("COURSE_GRPC_TOKEN", "s3cret")
+	t.Setenv("COURSE_GRPC_MAX_ATTEMPTS", "1")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.CourseGRPCTLS || cfg.CourseGRPCCAFile != "/etc/ca.pem" || cfg.CourseGRPCCertFile != "/etc/client.pem" ||
+		cfg.CourseGRPCKeyFile != "/etc/client-key.pem" || cfg.CourseGRPCServerName != "courses.internal" ||
+		cfg.CourseGRPCToken != "s3cret" || cfg.CourseGRPCMaxAttempts != 1 {
+		t.Errorf("gRPC settings not read from env: %+v", cfg)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"TLS files without TLS": {"COURSE_GRPC_TLS": "false"},
+		"cert without key":      {"COURSE_GRPC_KEY_FILE": ""},
+		"token and API key":     {"COURSE_GRPC_API_KEY": "k3y"},
+		"too many attempts":     {"COURSE_GRPC_MAX_ATTEMPTS": "6"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(); err == nil || !contains(err.Error(), "COURSE_GRPC_") {
+				t.Errorf("expected a COURSE_GRPC_ error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad_Production_GRPCCredentialsRequireTLS(t *testing.T) {
+	setAllEnvVars(t)
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("COURSE_GRPC_TOKEN", "s3cret")
+
+	if _, err := Load(); err == nil || !contains(err.Error(), "COURSE_GRPC_TLS") {
+		t.Errorf("expected a plaintext token to be rejected, got %v", err)
+	}
+
+	t.Setenv("COURSE_GRPC_TLS", "true")
+	if _, err := Load(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLoad_Notifier(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Notifier != "log" || cfg.PasswordResetNotifier != "log" || cfg.SMTPPort != 587 || cfg.NotifyWebhookTimeout != 10*time.Second ||
+		cfg.NotifyMaxAttempts != 5 || cfg.NotifyRetryBackoff != time.Minute || cfg.NotifyRetryBackoffMax != time.Hour || cfg.NotifyPollInterval != 5*time.Second {
+		t.Errorf("unexpected notifier defaults: %+v", cfg)
+	}
+
+	t.Setenv("NOTIFIER", "smtp")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "465")
+	t.Setenv("SMTP_FROM", "CPNext <noreply@example.com>")
+	t.Setenv("SMTP_RECIPIENT_DOMAIN", "kkumail.com")
+	t.Setenv("NOTIFY_MAX_ATTEMPTS", "3")
+	t.Setenv("PASSWORD_RESET_NOTIFIER", "smtp")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Notifier != "smtp" || cfg.PasswordResetNotifier != "smtp" || cfg.SMTPHost != "smtp.example.com" || cfg.SMTPPort != 465 || cfg.SMTPRecipientDomain != "kkumail.com" || cfg.NotifyMaxAttempts != 3 {
+		t.Errorf("SMTP settings not read from env: %+v", cfg)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"unknown notifier":          {"NOTIFIER": "sms"},
+		"smtp without host":         {"SMTP_HOST": ""},
+		"bad sender":                {"SMTP_FROM": "not an address"},
+		"port out of range":         {"SMTP_PORT": "70000"},
+		"webhook without URL":       {"NOTIFIER": "webhook"},
+		"unknown reset notifier":    {"PASSWORD_RESET_NOTIFIER": "sms"},
+		"reset webhook without URL": {"NOTIFIER": "log", "PASSWORD_RESET_NOTIFIER": "webhook"},
+		"reset smtp without host":   {"NOTIFIER": "log", "SMTP_HOST": ""},
+		"bad webhook timeout":       {"NOTIFY_WEBHOOK_TIMEOUT": "soon"},
+		"zero attempts":             {"NOTIFY_MAX_ATTEMPTS": "0"},
+		"max below backoff":         {"NOTIFY_RETRY_BACKOFF_MAX": "30s"},
+		"bad poll interval":         {"NOTIFY_POLL_INTERVAL": "0s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoad_Webhooks(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WebhookTimeout != 10*time.Second || cfg.WebhookMaxAttempts != 8 || cfg.WebhookRetryBackoff != 30*time.Second ||
+		cfg.WebhookRetryBackoffMax != time.Hour || cfg.WebhookPollInterval != 5*time.Second {
+		t.Errorf("unexpected webhook defaults: %+v", cfg)
+	}
+
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+	t.Setenv("WEBHOOK_RETRY_BACKOFF", "1m")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WebhookMaxAttempts != 3 || cfg.WebhookRetryBackoff != time.Minute {
+		t.Errorf("webhook settings not read from env: %+v", cfg)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"zero attempts":       {"WEBHOOK_MAX_ATTEMPTS": "0"},
+		"max below backoff":   {"WEBHOOK_RETRY_BACKOFF_MAX": "30s"},
+		"bad poll interval":   {"WEBHOOK_POLL_INTERVAL": "often"},
+		"bad webhook timeout": {"WEBHOOK_TIMEOUT": "-1s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoad_Outbox(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.OutboxSinks) != 1 || cfg.OutboxSinks[0] != "webhook" || cfg.OutboxPollInterval != time.Second ||
+		cfg.OutboxRetryBackoff != 10*time.Second || cfg.OutboxRetryBackoffMax != 10*time.Minute {
+		t.Errorf("unexpected outbox defaults: %+v", cfg)
+	}
+
+	t.Setenv("OUTBOX_SINKS", "log, webhook")
+	t.Setenv("OUTBOX_POLL_INTERVAL", "500ms")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.OutboxSinks) != 2 || cfg.OutboxSinks[0] != "log" || cfg.OutboxPollInterval != 500*time.Millisecond {
+		t.Errorf("outbox settings not read from env: %+v", cfg)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"unknown sink":      {"OUTBOX_SINKS": "kafka"},
+		"sink twice":        {"OUTBOX_SINKS": "log,log"},
+		"max below backoff": {"OUTBOX_RETRY_BACKOFF_MAX": "5s"},
+		"bad poll interval": {"OUTBOX_POLL_INTERVAL": "0s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoad_Proxy(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ProxyHeader != "" || len(cfg.TrustedProxies) != 0 {
+		t.Errorf("expected no proxy by default, got %q %v", cfg.ProxyHeader, cfg.TrustedProxies)
+	}
+
+	t.Setenv("PROXY_HEADER", "X-Real-IP")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.10")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ProxyHeader != "X-Real-IP" || len(cfg.TrustedProxies) != 2 || cfg.TrustedProxies[1] != "192.168.1.10" {
+		t.Errorf("proxy settings not read from env: %q %v", cfg.ProxyHeader, cfg.TrustedProxies)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"header without proxies": {"TRUSTED_PROXIES": ""},
+		"bad proxy":              {"TRUSTED_PROXIES": "10.0.0.0/33"},
+		"hostname":               {"TRUSTED_PROXIES": "lb.internal"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// --- API Key Request DTOs ---
+
+// CreateAPIKeyRequest is the body of POST /api-keys.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100" example:"timetable-sync"`
+	Scopes    []string   `json:"scopes" validate:"required,dive,permission" example:"course:write,queue:read"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2027-01-01T00:00:00Z"`
+}
+
+// --- API Key Response DTOs ---
+
+// APIKeyResponse describes a stored API key. The key itself is never returned.
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name" example:"timetable-sync"`
+	Prefix     string     `json:"prefix" example:"cpn_Ab12Cd34"`
+	Scopes     []string   `json:"scopes" example:"course:write"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is returned once, on creation, and includes the plaintext key.
+type CreateAPIKeyResponse struct {
+	*APIKeyResponse
+	Key string `json:"key" example:"cpn_Ab12Cd34..."`
+}
+
+// ToAPIKeyResponse converts an APIKey entity to a response DTO.
+func ToAPIKeyResponse(k *entity.APIKey) *APIKeyResponse {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+// ToAPIKeyResponses converts a slice of APIKey entities to response DTOs.
+func ToAPIKeyResponses(keys []*entity.APIKey) []*APIKeyResponse {
+	out := make([]*APIKeyResponse, len(keys))
+	for i, k := range keys {
+		out[i] = ToAPIKeyResponse(k)
+	}
+	return out
+}
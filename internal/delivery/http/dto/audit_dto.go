@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// --- Audit Response DTOs ---
+
+// AuditEntryResponse describes one recorded administrative action.
+type AuditEntryResponse struct {
+	ID         string                 `json:"id"`
+	ActorID    string                 `json:"actor_id"`
+	ActorName  string                 `json:"actor_name" example:"admin"`
+	Action     string                 `json:"action" example:"course.delete"`
+	TargetType string                 `json:"target_type" example:"course"`
+	TargetID   string                 `json:"target_id" example:"CP353004/2568/1"`
+	Before     map[string]interface{} `json:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty"`
+	IP         string                 `json:"ip" example:"10.0.0.12"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// ToAuditEntryResponse converts an AuditEntry entity to a response DTO.
+func ToAuditEntryResponse(e *entity.AuditEntry) *AuditEntryResponse {
+	return &AuditEntryResponse{
+		ID:         e.ID,
+		ActorID:    e.ActorID,
+		ActorName:  e.ActorName,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Before:     e.Before,
+		After:      e.After,
+		IP:         e.IP,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+// ToAuditEntryResponses converts a slice of AuditEntry entities to response DTOs.
+func ToAuditEntryResponses(entries []*entity.AuditEntry) []*AuditEntryResponse {
+	out := make([]*AuditEntryResponse, len(entries))
+	for i, e := range entries {
+		out[i] = ToAuditEntryResponse(e)
+	}
+	return out
+}
@@ -1,24 +1,60 @@
 package dto
 
-import "github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
 
 // ---------- Request DTOs ----------
 
 // RegisterRequest represents a user registration request.
 type RegisterRequest struct {
-	Username string `json:"username" example:"admin1"`
-	Password string `json:"password" example:"password123"`
-	Role     string `json:"role" example:"admin"`
+	Username string `json:"username" validate:"required" example:"admin1"`
+	Password string `json:"password" validate:"required" example:"Str0ng-Passw0rd"`
+	Role     string `json:"role" validate:"omitempty,oneof=superadmin admin student" enums:"superadmin,admin,student" example:"admin"`
 }
 
 // LoginRequest represents a login request.
 type LoginRequest struct {
-	Username string `json:"username" example:"admin1"`
-	Password string `json:"password" example:"password123"`
+	Username string `json:"username" validate:"required" example:"admin1"`
+	Password string `json:"password" validate:"required" example:"password123"`
+}
+
+// RefreshRequest carries a refresh token for POST /auth/refresh and /auth/logout.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// UpdateProfileRequest is the body of PATCH /auth/me.
+type UpdateProfileRequest struct {
+	Username string `json:"username" validate:"required" example:"admin1"`
+}
+
+// ChangePasswordRequest is the body of POST /auth/me/password.
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// ResetPasswordRequest is the body of POST /auth/password-reset.
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// ChangeRoleRequest is the body of PATCH /auth/users/{id}/role.
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=superadmin admin student" enums:"admin,student" example:"admin"`
 }
 
 // ---------- Response DTOs ----------
 
+// PasswordResetIssuedResponse is returned when a reset token has been sent.
+type PasswordResetIssuedResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // RegisterResponse represents the response after successful registration.
 type RegisterResponse struct {
 	ID       string `json:"id"`
@@ -26,9 +62,20 @@ type RegisterResponse struct {
 	Role     string `json:"role"`
 }
 
-// LoginResponse represents the response after successful login.
+// LoginResponse represents the token pair returned by login and refresh.
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"` // access token
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"` // access token lifetime in seconds
+}
+
+// PasswordChangeRequiredResponse is the data of the 403 login response for
+// users who must set a new password. The token is redeemed, with the new
+// password, at POST /auth/password-reset.
+type PasswordChangeRequiredResponse struct {
+	PasswordChangeToken string    `json:"password_change_token"`
+	ExpiresAt           time.Time `json:"expires_at"`
 }
 
 // ---------- Converters ----------
@@ -42,6 +89,16 @@ func ToRegisterResponse(user *entity.User) *RegisterResponse {
 	}
 }
 
+// ToLoginResponse converts an issued token pair to a LoginResponse.
+func ToLoginResponse(t *entity.AuthTokens) *LoginResponse {
+	return &LoginResponse{
+		Token:        t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(t.ExpiresIn.Seconds()),
+	}
+}
+
 // ---------- User list DTOs ----------
 
 // UserResponse represents a user in list responses (no password).
@@ -49,6 +106,7 @@ type UserResponse struct {
 	ID       string `json:"id" example:"60f7b3b3b3b3b3b3b3b3b3b3"`
 	Username string `json:"username" example:"admin1"`
 	Role     string `json:"role" example:"admin"`
+	Disabled bool   `json:"disabled"`
 }
 
 // ToUserResponse converts a User entity to a UserResponse.
@@ -57,6 +115,7 @@ func ToUserResponse(user *entity.User) *UserResponse {
 		ID:       user.ID,
 		Username: user.Username,
 		Role:     user.Role,
+		Disabled: user.Disabled,
 	}
 }
 
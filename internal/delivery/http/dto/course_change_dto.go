@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// --- Course Change Response DTOs ---
+
+// CourseChangeEventResponse describes the changes one refresh found in a course.
+type CourseChangeEventResponse struct {
+	ID         string                 `json:"id"`
+	CourseCode string                 `json:"course_code" example:"CP353004"`
+	Year       int                    `json:"year" example:"2568"`
+	Semester   int                    `json:"semester" example:"1"`
+	Source     string                 `json:"source,omitempty" example:"grpc"`
+	Changes    []CourseChangeResponse `json:"changes"`
+	DetectedAt time.Time              `json:"detected_at"`
+}
+
+// CourseChangeResponse is one difference between the old and new course.
+type CourseChangeResponse struct {
+	Kind      string `json:"kind" example:"ROOM_CHANGED" enums:"COURSE_INFO_CHANGED,SECTION_ADDED,SECTION_REMOVED,SCHEDULE_CHANGED,ROOM_CHANGED,INSTRUCTOR_CHANGED,SEATS_CHANGED,EXAM_CHANGED,MIDTERM_CHANGED,NOTE_CHANGED,SECTION_CLOSED"`
+	SectionID string `json:"section_id,omitempty"`
+	Section   string `json:"section,omitempty" example:"01"`
+	Detail    string `json:"detail,omitempty" example:"MON 13:00-15:00 (C)"`
+	Old       string `json:"old,omitempty" example:"CP9 CP9127"`
+	New       string `json:"new,omitempty" example:"SC2 SC2101"`
+}
+
+// ToCourseChangeEventResponse converts a CourseChangeEvent entity to a response DTO.
+func ToCourseChangeEventResponse(e *entity.CourseChangeEvent) *CourseChangeEventResponse {
+	changes := make([]CourseChangeResponse, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = CourseChangeResponse{
+			Kind:      string(c.Kind),
+			SectionID: c.SectionID,
+			Section:   c.Section,
+			Detail:    c.Detail,
+			Old:       c.Old,
+			New:       c.New,
+		}
+	}
+	return &CourseChangeEventResponse{
+		ID:         e.ID,
+		CourseCode: e.CourseCode,
+		Year:       e.Year,
+		Semester:   e.Semester,
+		Source:     e.Source,
+		Changes:    changes,
+		DetectedAt: e.DetectedAt,
+	}
+}
+
+// ToCourseChangeEventResponses converts a slice of CourseChangeEvent entities to response DTOs.
+func ToCourseChangeEventResponses(events []*entity.CourseChangeEvent) []*CourseChangeEventResponse {
+	out := make([]*CourseChangeEventResponse, len(events))
+	for i, e := range events {
+		out[i] = ToCourseChangeEventResponse(e)
+	}
+	return out
+}
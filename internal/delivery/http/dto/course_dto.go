@@ -2,38 +2,37 @@ package dto
 
 import (
 	"fmt"
-	"log"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
 )
 
 // --- Request DTOs ---
 
 // CreateCourseRequest represents the request body for creating a course.
 type CreateCourseRequest struct {
-	Code         string           `json:"code"`
-	NameEN       string           `json:"name_en"`
+	Code         string           `json:"code" validate:"required,course_code" example:"CP353004" pattern:"^[A-Za-z]{2}[0-9]{6}$"`
+	NameEN       string           `json:"name_en" validate:"required"`
 	NameTH       string           `json:"name_th"`
 	Faculty      string           `json:"faculty"`
 	Department   string           `json:"department,omitempty"`
-	Credits      string           `json:"credits"`
+	Credits      string           `json:"credits" validate:"required" example:"3 (2-2-5)"`
 	Prerequisite string           `json:"prerequisite,omitempty"`
-	Semester     int              `json:"semester"`
-	Year         int              `json:"year"`
-	Sections     []SectionRequest `json:"sections"`
+	Semester     int              `json:"semester" validate:"min=1,max=3" minimum:"1" maximum:"3"`
+	Year         int              `json:"year" validate:"required" example:"2568"`
+	Sections     []SectionRequest `json:"sections" validate:"required"`
 }
 
 // SectionRequest represents a section in a create/update request.
 type SectionRequest struct {
 	ID          string            `json:"id,omitempty"`
-	Number      string            `json:"number"`
+	Number      string            `json:"number" validate:"required" example:"01"`
 	Schedules   []ScheduleRequest `json:"schedules"`
-	Seats       int               `json:"seats"`
+	Seats       int               `json:"seats" validate:"min=0" minimum:"0"`
 	Instructor  []string          `json:"instructor"`
-	ExamDate    string            `json:"exam_date,omitempty"`
+	ExamDate    string            `json:"exam_date,omitempty" example:"31 มี.ค. 2569 เวลา 13:00 - 16:00"`
 	MidtermDate string            `json:"midterm_date,omitempty"`
 	Note        string            `json:"note,omitempty"`
 	ReservedFor []string          `json:"reserved_for,omitempty"`
@@ -43,54 +42,44 @@ type SectionRequest struct {
 
 // ScheduleRequest represents a schedule slot in a request.
 type ScheduleRequest struct {
-	Day  string `json:"day"`
-	Time string `json:"time"`
+	Day  string `json:"day" example:"จันทร์"`
+	Time string `json:"time" example:"13:00-15:00"`
 	Room string `json:"room"`
-	Type string `json:"type"`
+	Type string `json:"type" example:"C"`
 }
 
-// ToEntity converts a CreateCourseRequest to a domain entity.
-func (r *CreateCourseRequest) ToEntity() *entity.Course {
+// ToEntity converts a CreateCourseRequest to a domain entity. Unparseable
+// schedule times and exam dates are reported as validation.Errors.
+func (r *CreateCourseRequest) ToEntity() (*entity.Course, error) {
+	var errs validation.Errors
+
 	sections := make([]entity.Section, len(r.Sections))
 	for i, s := range r.Sections {
-		schedules := make([]entity.Schedule, len(s.Schedules))
+		var schedules []entity.Schedule
 		for j, sc := range s.Schedules {
-			var startTimeStr, endTimeStr string
-			parts := strings.Split(sc.Time, "-")
-			if len(parts) == 2 {
-				startTimeStr = strings.TrimSpace(parts[0])
-				endTimeStr = strings.TrimSpace(parts[1])
-			}
-
-			schedules[j] = entity.Schedule{
-				Day:       sc.Day,
-				StartTime: startTimeStr,
-				EndTime:   endTimeStr,
-				Room:      sc.Room,
-				Type:      sc.Type,
+			expanded, err := entity.NewSchedules(sc.Day, sc.Time, sc.Room, sc.Type)
+			if err != nil {
+				errs.Add(fmt.Sprintf("sections[%d].schedules[%d].time", i, j), err.Error())
 			}
+			schedules = append(schedules, expanded...)
 		}
 
-		var examStartStr, examEndStr string
+		var examStart, examEnd time.Time
 		if s.ExamDate != "" {
-			es, ee, err := parseThaiExamDate(s.ExamDate)
-			if err == nil {
-				examStartStr = es
-				examEndStr = ee
-			} else {
-				log.Printf("Error parsing exam date: %v", err)
+			es, ee, err := thaicalendar.ParseExamDate(s.ExamDate)
+			if err != nil {
+				errs.Add(fmt.Sprintf("sections[%d].exam_date", i), err.Error())
 			}
+			examStart, examEnd = es, ee
 		}
 
-		var midtermStartStr, midtermEndStr string
+		var midtermStart, midtermEnd time.Time
 		if s.MidtermDate != "" {
-			ms, me, err := parseThaiExamDate(s.MidtermDate)
-			if err == nil {
-				midtermStartStr = ms
-				midtermEndStr = me
-			} else {
-				log.Printf("Error parsing midterm date: %v", err)
+			ms, me, err := thaicalendar.ParseExamDate(s.MidtermDate)
+			if err != nil {
+				errs.Add(fmt.Sprintf("sections[%d].midterm_date", i), err.Error())
 			}
+			midtermStart, midtermEnd = ms, me
 		}
 
 		sections[i] = entity.Section{
@@ -99,10 +88,10 @@ func (r *CreateCourseRequest) ToEntity() *entity.Course {
 			Schedules:    schedules,
 			Seats:        s.Seats,
 			Instructor:   s.Instructor,
-			ExamStart:    examStartStr,
-			ExamEnd:      examEndStr,
-			MidtermStart: midtermStartStr,
-			MidtermEnd:   midtermEndStr,
+			ExamStart:    examStart,
+			ExamEnd:      examEnd,
+			MidtermStart: midtermStart,
+			MidtermEnd:   midtermEnd,
 			Note:         s.Note,
 			ReservedFor:  s.ReservedFor,
 			Campus:       s.Campus,
@@ -110,6 +99,10 @@ func (r *CreateCourseRequest) ToEntity() *entity.Course {
 		}
 	}
 
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
 	return &entity.Course{
 		Code:         r.Code,
 		NameEN:       r.NameEN,
@@ -121,7 +114,7 @@ func (r *CreateCourseRequest) ToEntity() *entity.Course {
 		Semester:     r.Semester,
 		Year:         r.Year,
 		Sections:     sections,
-	}
+	}, nil
 }
 
 // --- Response DTOs ---
@@ -140,6 +133,9 @@ type CourseResponse struct {
 	Year         int               `json:"year"`
 	UpdatedAt    string            `json:"updated_at"`
 	Sections     []SectionResponse `json:"sections"`
+	Source       string            `json:"source,omitempty" example:"grpc"`
+	FilledFrom   map[string]string `json:"filled_from,omitempty"`
+	Version      int               `json:"version" example:"3"`
 }
 
 // SectionResponse represents a section in the response.
@@ -149,8 +145,8 @@ type SectionResponse struct {
 	Schedules    []ScheduleResponse `json:"schedules"`
 	Seats        int                `json:"seats"`
 	Instructor   []string           `json:"instructor"`
-	ExamStart    string             `json:"exam_start,omitempty"`
-	ExamEnd      string             `json:"exam_end,omitempty"`
+	ExamStart    string             `json:"exam_start,omitempty" example:"2026-03-31T13:00:00+07:00"`
+	ExamEnd      string             `json:"exam_end,omitempty" example:"2026-03-31T16:00:00+07:00"`
 	MidtermStart string             `json:"midterm_start,omitempty"`
 	MidtermEnd   string             `json:"midterm_end,omitempty"`
 	Note         string             `json:"note,omitempty"`
@@ -161,11 +157,14 @@ type SectionResponse struct {
 
 // ScheduleResponse represents a schedule slot in the response.
 type ScheduleResponse struct {
-	Day       string `json:"day"`
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	Day       string `json:"day" example:"จันทร์"`
+	Weekday   string `json:"weekday" example:"MON" enums:"MON,TUE,WED,THU,FRI,SAT,SUN,"`
+	StartTime string `json:"start_time" example:"13:00"`
+	EndTime   string `json:"end_time" example:"15:00"`
+	TBA       bool   `json:"tba,omitempty"`
 	Room      string `json:"room"`
-	Type      string `json:"type"`
+	Type      string `json:"type" example:"C"`
+	Kind      string `json:"kind" example:"LECTURE" enums:"LECTURE,LAB,"`
 }
 
 // ToCourseResponse converts a Course entity to a CourseResponse DTO.
@@ -180,10 +179,13 @@ func ToCourseResponse(c *entity.Course) *CourseResponse {
 		for j, sc := range s.Schedules {
 			schedules[j] = ScheduleResponse{
 				Day:       sc.Day,
-				StartTime: sc.StartTime,
-				EndTime:   sc.EndTime,
+				Weekday:   string(sc.Weekday),
+				StartTime: sc.StartTime.String(),
+				EndTime:   sc.EndTime.String(),
+				TBA:       sc.TBA,
 				Room:      sc.Room,
 				Type:      sc.Type,
+				Kind:      string(sc.Kind),
 			}
 		}
 
@@ -193,10 +195,10 @@ func ToCourseResponse(c *entity.Course) *CourseResponse {
 			Schedules:    schedules,
 			Seats:        s.Seats,
 			Instructor:   s.Instructor,
-			ExamStart:    s.ExamStart,
-			ExamEnd:      s.ExamEnd,
-			MidtermStart: s.MidtermStart,
-			MidtermEnd:   s.MidtermEnd,
+			ExamStart:    formatDateTime(s.ExamStart),
+			ExamEnd:      formatDateTime(s.ExamEnd),
+			MidtermStart: formatDateTime(s.MidtermStart),
+			MidtermEnd:   formatDateTime(s.MidtermEnd),
 			Note:         s.Note,
 			ReservedFor:  s.ReservedFor,
 			Campus:       s.Campus,
@@ -216,10 +218,21 @@ func ToCourseResponse(c *entity.Course) *CourseResponse {
 		Semester:     c.Semester,
 		Year:         c.Year,
 		Sections:     sections,
+		Source:       c.Source,
+		FilledFrom:   c.FilledFrom,
+		Version:      c.Version,
 		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
+// ApplyEra rewrites Year into the requested era. Stored years are always BE.
+func (r *CourseResponse) ApplyEra(era thaicalendar.Era) *CourseResponse {
+	if r != nil {
+		r.Year = era.FromBE(r.Year)
+	}
+	return r
+}
+
 // ToCourseResponses converts a slice of Course entities to CourseResponse DTOs.
 func ToCourseResponses(courses []*entity.Course) []*CourseResponse {
 	responses := make([]*CourseResponse, len(courses))
@@ -264,6 +277,14 @@ func ToCourseSummaryResponse(c *entity.Course) *CourseSummaryResponse {
 	}
 }
 
+// ApplyEra rewrites Year into the requested era. Stored years are always BE.
+func (r *CourseSummaryResponse) ApplyEra(era thaicalendar.Era) *CourseSummaryResponse {
+	if r != nil {
+		r.Year = era.FromBE(r.Year)
+	}
+	return r
+}
+
 // ToCourseSummaryResponses converts a slice of Course entities to CourseSummaryResponse DTOs.
 func ToCourseSummaryResponses(courses []*entity.Course) []*CourseSummaryResponse {
 	responses := make([]*CourseSummaryResponse, len(courses))
@@ -273,98 +294,10 @@ func ToCourseSummaryResponses(courses []*entity.Course) []*CourseSummaryResponse
 	return responses
 }
 
-// parseThaiExamDate parses strings like "31 มี.ค. 2569 เวลา 13:00 - 16:00"
-func parseThaiExamDate(dateStr string) (string, string, error) {
-	// Expected format: "dd MMM yyyy เวลา HH:mm - HH:mm"
-	// Example: "31 มี.ค. 2569 เวลา 13:00 - 16:00"
-
-	// Remove known suffixes or noise if any (though example is clean)
-	dateStr = strings.TrimSpace(dateStr)
-
-	parts := strings.Split(dateStr, " เวลา ")
-	if len(parts) != 2 {
-		return "", "", fmt.Errorf("invalid format: missing ' เวลา ' separator")
-	}
-
-	datePart := strings.TrimSpace(parts[0]) // "31 มี.ค. 2569"
-	timePart := strings.TrimSpace(parts[1]) // "13:00 - 16:00"
-
-	// Parse date part
-	dateFields := strings.Fields(datePart)
-	if len(dateFields) != 3 {
-		return "", "", fmt.Errorf("invalid date format")
-	}
-
-	day, err := strconv.Atoi(dateFields[0])
-	if err != nil {
-		return "", "", fmt.Errorf("invalid day: %v", err)
-	}
-
-	monthStr := dateFields[1]
-	yearBE, err := strconv.Atoi(dateFields[2])
-	if err != nil {
-		return "", "", fmt.Errorf("invalid year: %v", err)
-	}
-	// Convert Buddhist Era to Common Era
-	yearCE := yearBE - 543
-
-	month := parseThaiMonth(monthStr)
-	if month == 0 {
-		return "", "", fmt.Errorf("invalid month: %s", monthStr)
-	}
-
-	// Parse time part
-	times := strings.Split(timePart, "-")
-	if len(times) != 2 {
-		return "", "", fmt.Errorf("invalid time range format")
-	}
-
-	startStr := strings.TrimSpace(times[0])
-	endStr := strings.TrimSpace(times[1])
-
-	startT, err := time.Parse("15:04", startStr)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid start time: %v", err)
-	}
-	endT, err := time.Parse("15:04", endStr)
-	if err != nil {
-		return "", "", fmt.Errorf("invalid end time: %v", err)
-	}
-
-	// Combine into strings "YYYY-MM-DD HH:mm:00"
-	examStart := fmt.Sprintf("%04d-%02d-%02d %02d:%02d:00", yearCE, month, day, startT.Hour(), startT.Minute())
-	examEnd := fmt.Sprintf("%04d-%02d-%02d %02d:%02d:00", yearCE, month, day, endT.Hour(), endT.Minute())
-
-	return examStart, examEnd, nil
-}
-
-func parseThaiMonth(abbr string) time.Month {
-	switch abbr {
-	case "ม.ค.":
-		return time.January
-	case "ก.พ.":
-		return time.February
-	case "มี.ค.":
-		return time.March
-	case "เม.ย.":
-		return time.April
-	case "พ.ค.":
-		return time.May
-	case "มิ.ย.":
-		return time.June
-	case "ก.ค.":
-		return time.July
-	case "ส.ค.":
-		return time.August
-	case "ก.ย.":
-		return time.September
-	case "ต.ค.":
-		return time.October
-	case "พ.ย.":
-		return time.November
-	case "ธ.ค.":
-		return time.December
-	default:
-		return 0
+// formatDateTime renders t as RFC 3339 in Asia/Bangkok, or "" when unset.
+func formatDateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
+	return t.In(thaicalendar.Location).Format(time.RFC3339)
 }
@@ -1,11 +1,13 @@
 package dto
 
 import (
-	"log"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,8 +34,9 @@ func TestToEntity(t *testing.T) {
 		},
 	}
 
-	entityCourse := req.ToEntity()
+	entityCourse, err := req.ToEntity()
 
+	assert.NoError(t, err)
 	assert.NotNil(t, entityCourse)
 	assert.Equal(t, "วิทยาลัยการคอมพิวเตอร์", entityCourse.Faculty)
 	assert.Equal(t, "วิทยาการคอมพิวเตอร์", entityCourse.Department)
@@ -44,60 +47,96 @@ func TestToEntity(t *testing.T) {
 
 	// Verify Schedule Time Split
 	schedule := section.Schedules[0]
-	assert.Equal(t, "13:00", schedule.StartTime)
-	assert.Equal(t, "15:00", schedule.EndTime)
+	assert.Equal(t, entity.NewTimeOfDay(13, 0), schedule.StartTime)
+	assert.Equal(t, entity.NewTimeOfDay(15, 0), schedule.EndTime)
 
 	// Verify ExamDate Parsing
 	// 2569 - 543 = 2026
 	// 31 March
 	// 13:00 - 16:00
-	expectedExamStart := "2026-03-31 13:00:00"
-	expectedExamEnd := "2026-03-31 16:00:00"
+	expectedExamStart := time.Date(2026, time.March, 31, 13, 0, 0, 0, thaicalendar.Location)
+	expectedExamEnd := time.Date(2026, time.March, 31, 16, 0, 0, 0, thaicalendar.Location)
 
-	assert.Equal(t, expectedExamStart, section.ExamStart)
-	assert.Equal(t, expectedExamEnd, section.ExamEnd)
+	assert.True(t, expectedExamStart.Equal(section.ExamStart))
+	assert.True(t, expectedExamEnd.Equal(section.ExamEnd))
 	assert.Equal(t, []string{"Students who failed"}, section.ReservedFor)
 
 	// Verify MidtermDate Parsing
-	expectedMidtermStart := "2026-02-15 09:00:00"
-	expectedMidtermEnd := "2026-02-15 12:00:00"
-	assert.Equal(t, expectedMidtermStart, section.MidtermStart)
-	assert.Equal(t, expectedMidtermEnd, section.MidtermEnd)
+	expectedMidtermStart := time.Date(2026, time.February, 15, 9, 0, 0, 0, thaicalendar.Location)
+	expectedMidtermEnd := time.Date(2026, time.February, 15, 12, 0, 0, 0, thaicalendar.Location)
+	assert.True(t, expectedMidtermStart.Equal(section.MidtermStart))
+	assert.True(t, expectedMidtermEnd.Equal(section.MidtermEnd))
 }
 
 func TestToEntity_InvalidTimes(t *testing.T) {
-	// Capture stderr to avoid polluting test output with logs
-	// But in this environment we can just let it log.
-	// Ideally we mock the logger or just accept the output.
-	log.SetOutput(log.Writer())
-
 	req := CreateCourseRequest{
 		Sections: []SectionRequest{
 			{
 				Schedules: []ScheduleRequest{
 					{Time: "Invalid"},       // Invalid split
 					{Time: "13:00-Invalid"}, // Invalid parse
+					{Time: "15:00-13:00"},   // Ends before it starts
 				},
 				ExamDate:    "Invalid Exam Date",
 				MidtermDate: "Invalid Midterm Date",
 			},
 		},
 	}
-	entityCourse := req.ToEntity()
-	assert.NotNil(t, entityCourse)
-	assert.Equal(t, "", entityCourse.Sections[0].Schedules[0].StartTime)
-	assert.Equal(t, "13:00", entityCourse.Sections[0].Schedules[1].StartTime)
-	assert.Equal(t, "Invalid", entityCourse.Sections[0].Schedules[1].EndTime)
-	assert.Equal(t, "", entityCourse.Sections[0].ExamStart)
-	assert.Equal(t, "", entityCourse.Sections[0].MidtermStart)
+	entityCourse, err := req.ToEntity()
+	assert.Nil(t, entityCourse)
+
+	var verrs validation.Errors
+	assert.True(t, errors.As(err, &verrs))
+
+	fields := make([]string, len(verrs))
+	for i, fe := range verrs {
+		fields[i] = fe.Field
+	}
+	assert.Equal(t, []string{
+		"sections[0].schedules[0].time",
+		"sections[0].schedules[1].time",
+		"sections[0].schedules[2].time",
+		"sections[0].exam_date",
+		"sections[0].midterm_date",
+	}, fields)
+}
+
+func TestToEntity_RegistrarTimeFormats(t *testing.T) {
+	req := CreateCourseRequest{
+		Sections: []SectionRequest{{
+			Schedules: []ScheduleRequest{
+				{Day: "จันทร์", Time: "13:00 - 15:00 น."},
+				{Day: "อังคาร", Time: "09.00-10.30 / 13.00-14.30"},
+				{Day: "พุธ", Time: "TBA"},
+				{Day: "พฤหัสบดี", Time: ""},
+			},
+		}},
+	}
+
+	course, err := req.ToEntity()
+	assert.NoError(t, err)
+
+	schedules := course.Sections[0].Schedules
+	assert.Len(t, schedules, 5)
+	assert.Equal(t, "13:00", schedules[0].StartTime.String())
+	assert.Equal(t, "15:00", schedules[0].EndTime.String())
+	assert.Equal(t, "09:00", schedules[1].StartTime.String())
+	assert.Equal(t, "14:30", schedules[2].EndTime.String())
+	assert.Equal(t, entity.WeekdayTuesday, schedules[2].Weekday)
+	assert.True(t, schedules[3].TBA)
+	assert.False(t, schedules[3].StartTime.Valid)
+	assert.False(t, schedules[4].TBA)
+
+	resp := ToCourseResponse(course)
+	assert.True(t, resp.Sections[0].Schedules[3].TBA)
 }
 
 func TestToCourseResponse(t *testing.T) {
-	start := "09:00"
-	end := "12:00"
+	start := entity.NewTimeOfDay(9, 0)
+	end := entity.NewTimeOfDay(12, 0)
 
-	examStart := "2026-03-31 13:00:00"
-	examEnd := "2026-03-31 16:00:00"
+	examStart := time.Date(2026, time.March, 31, 13, 0, 0, 0, thaicalendar.Location)
+	examEnd := time.Date(2026, time.March, 31, 16, 0, 0, 0, thaicalendar.Location)
 
 	// Mock UpdatedAt
 	updatedAt := time.Date(2026, time.February, 17, 14, 0, 0, 0, time.Local)
@@ -137,8 +176,8 @@ func TestToCourseResponse(t *testing.T) {
 	assert.Equal(t, updatedAt.Format(time.RFC3339), response.UpdatedAt)
 
 	secResp := response.Sections[0]
-	assert.Equal(t, "2026-03-31 13:00:00", secResp.ExamStart)
-	assert.Equal(t, "2026-03-31 16:00:00", secResp.ExamEnd)
+	assert.Equal(t, "2026-03-31T13:00:00+07:00", secResp.ExamStart)
+	assert.Equal(t, "2026-03-31T16:00:00+07:00", secResp.ExamEnd)
 	assert.Empty(t, secResp.MidtermStart)
 	assert.Empty(t, secResp.MidtermEnd)
 
@@ -153,8 +192,9 @@ func TestToCourseResponse_Nil(t *testing.T) {
 }
 
 func TestToCourseResponse_Midterm(t *testing.T) {
-	midtermStart := "2026-02-15 09:00:00"
-	midtermEnd := "2026-02-15 12:00:00"
+	// Stored instants come back from Mongo in UTC; output must be Bangkok time.
+	midtermStart := time.Date(2026, time.February, 15, 2, 0, 0, 0, time.UTC)
+	midtermEnd := time.Date(2026, time.February, 15, 5, 0, 0, 0, time.UTC)
 
 	entityCourse := &entity.Course{
 		Sections: []entity.Section{
@@ -166,8 +206,8 @@ func TestToCourseResponse_Midterm(t *testing.T) {
 	}
 
 	response := ToCourseResponse(entityCourse)
-	assert.Equal(t, "2026-02-15 09:00:00", response.Sections[0].MidtermStart)
-	assert.Equal(t, "2026-02-15 12:00:00", response.Sections[0].MidtermEnd)
+	assert.Equal(t, "2026-02-15T09:00:00+07:00", response.Sections[0].MidtermStart)
+	assert.Equal(t, "2026-02-15T12:00:00+07:00", response.Sections[0].MidtermEnd)
 }
 
 func TestToCourseResponses(t *testing.T) {
@@ -219,66 +259,102 @@ func TestToCourseSummaryResponses(t *testing.T) {
 	assert.Equal(t, "C2", responses[1].Code)
 }
 
-func TestParseThaiExamDate_InvalidFormat(t *testing.T) {
-	// Missing " เวลา "
-	_, _, err := parseThaiExamDate("31 มี.ค. 2569 13:00 - 16:00")
-	assert.Error(t, err)
-
-	// Invalid Date Part
-	_, _, err = parseThaiExamDate("31 Invalid 2569 เวลา 13:00 - 16:00")
-	assert.Error(t, err)
+func TestCourseResponse_ApplyEra(t *testing.T) {
+	entityCourse := &entity.Course{Code: "CP353004", Year: 2568}
 
-	// Invalid Date Fields Count
-	_, _, err = parseThaiExamDate("31 มี.ค. 2569 Extra เวลา 13:00 - 16:00")
-	assert.Error(t, err)
-
-	// Invalid Day
-	_, _, err = parseThaiExamDate("Invalid มี.ค. 2569 เวลา 13:00 - 16:00")
-	assert.Error(t, err)
-
-	// Invalid Year
-	_, _, err = parseThaiExamDate("31 มี.ค. Invalid เวลา 13:00 - 16:00")
-	assert.Error(t, err)
-
-	// Invalid Month
-	_, _, err = parseThaiExamDate("31 Inval 2569 เวลา 13:00 - 16:00")
-	assert.Error(t, err)
-
-	// Invalid Time Range
-	_, _, err = parseThaiExamDate("31 มี.ค. 2569 เวลา 13:00")
-	assert.Error(t, err)
+	assert.Equal(t, 2568, ToCourseResponse(entityCourse).ApplyEra(thaicalendar.EraBE).Year)
+	assert.Equal(t, 2025, ToCourseResponse(entityCourse).ApplyEra(thaicalendar.EraCE).Year)
+	assert.Equal(t, 2025, ToCourseSummaryResponse(entityCourse).ApplyEra(thaicalendar.EraCE).Year)
+	assert.Nil(t, ToCourseResponse(nil).ApplyEra(thaicalendar.EraCE))
+}
 
-	// Invalid Start Time
-	_, _, err = parseThaiExamDate("31 มี.ค. 2569 เวลา In:va - 16:00")
-	assert.Error(t, err)
+func TestToEntity_NormalizesScheduleEnums(t *testing.T) {
+	req := CreateCourseRequest{
+		Sections: []SectionRequest{{
+			Schedules: []ScheduleRequest{
+				{Day: "จันทร์", Type: "C"},
+				{Day: "วันพฤหัสบดี", Type: "ปฏิบัติการ"},
+				{Day: "Friday", Type: "Lecture"},
+				{Day: "ศ.", Type: "lab"},
+				{Day: "Someday", Type: "X"},
+			},
+		}},
+	}
 
-	// Invalid End Time
-	_, _, err = parseThaiExamDate("31 มี.ค. 2569 เวลา 13:00 - In:va")
-	assert.Error(t, err)
+	course, err := req.ToEntity()
+	assert.NoError(t, err)
+
+	schedules := course.Sections[0].Schedules
+	assert.Equal(t, entity.WeekdayMonday, schedules[0].Weekday)
+	assert.Equal(t, entity.ScheduleKindLecture, schedules[0].Kind)
+	assert.Equal(t, entity.WeekdayThursday, schedules[1].Weekday)
+	assert.Equal(t, entity.ScheduleKindLab, schedules[1].Kind)
+	assert.Equal(t, entity.WeekdayFriday, schedules[2].Weekday)
+	assert.Equal(t, entity.ScheduleKindLecture, schedules[2].Kind)
+	assert.Equal(t, entity.WeekdayFriday, schedules[3].Weekday)
+	assert.Equal(t, entity.ScheduleKindLab, schedules[3].Kind)
+
+	// Unknown values keep the raw input and report themselves.
+	assert.Equal(t, entity.WeekdayUnknown, schedules[4].Weekday)
+	assert.Equal(t, "Someday", schedules[4].Day)
+	assert.Equal(t, map[string]string{"day": "Someday", "type": "X"}, schedules[4].UnmappedFields())
+
+	resp := ToCourseResponse(course)
+	assert.Equal(t, "MON", resp.Sections[0].Schedules[0].Weekday)
+	assert.Equal(t, "จันทร์", resp.Sections[0].Schedules[0].Day)
+	assert.Equal(t, "LECTURE", resp.Sections[0].Schedules[0].Kind)
 }
 
-func TestParseThaiMonth(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected time.Month
-	}{
-		{"ม.ค.", time.January},
-		{"ก.พ.", time.February},
-		{"มี.ค.", time.March},
-		{"เม.ย.", time.April},
-		{"พ.ค.", time.May},
-		{"มิ.ย.", time.June},
-		{"ก.ค.", time.July},
-		{"ส.ค.", time.August},
-		{"ก.ย.", time.September},
-		{"ต.ค.", time.October},
-		{"พ.ย.", time.November},
-		{"ธ.ค.", time.December},
-		{"Invalid", 0},
+func TestCreateCourseRequest_Validate(t *testing.T) {
+	valid := CreateCourseRequest{
+		Code:     "CP353004",
+		NameEN:   "Software Engineering",
+		Credits:  "3",
+		Semester: 2,
+		Year:     2568,
+		Sections: []SectionRequest{{Number: "01"}},
 	}
+	assert.Empty(t, validation.Struct(&valid))
 
-	for _, test := range tests {
-		result := parseThaiMonth(test.input)
-		assert.Equal(t, test.expected, result)
+	invalid := CreateCourseRequest{
+		Code:     "CP35",
+		Semester: 0,
+		Sections: []SectionRequest{{Number: "01"}, {Seats: -1}},
 	}
+	errs := fieldErrors(validation.Struct(&invalid))
+	assert.Contains(t, errs["code"], "2 letters followed by 6 digits")
+	assert.Equal(t, "is required", errs["name_en"])
+	assert.Equal(t, "is required", errs["credits"])
+	assert.Equal(t, "must be at least 1", errs["semester"])
+	assert.Equal(t, "is required", errs["year"])
+	assert.Equal(t, "is required", errs["sections[1].number"])
+	assert.Equal(t, "must be at least 0", errs["sections[1].seats"])
+
+	errs = fieldErrors(validation.Struct(&CreateCourseRequest{}))
+	assert.Equal(t, "is required", errs["sections"])
+}
+
+func TestToCourseChangeEventResponses(t *testing.T) {
+	detected := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	res := ToCourseChangeEventResponses([]*entity.CourseChangeEvent{{
+		ID: "e1", CourseCode: "CP353004", Year: 2568, Semester: 1, Source: "grpc", DetectedAt: detected,
+		Changes: []entity.CourseChange{{Kind: entity.CourseChangeRoom, SectionID: "s1", Section: "01", Detail: "MON 13:00-15:00 (C)", Old: "CP9127", New: "SC2101"}},
+	}})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "CP353004", res[0].CourseCode)
+	assert.Equal(t, detected, res[0].DetectedAt)
+	assert.Equal(t, CourseChangeResponse{Kind: "ROOM_CHANGED", SectionID: "s1", Section: "01", Detail: "MON 13:00-15:00 (C)", Old: "CP9127", New: "SC2101"}, res[0].Changes[0])
+}
+
+func TestToCourseVersionResponses(t *testing.T) {
+	from := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	res := ToCourseVersionResponses([]*entity.CourseVersion{{
+		CourseID: "c1", Version: 2, ValidFrom: from, ValidTo: from.Add(24 * time.Hour),
+		Course: &entity.Course{Code: "CP353004", Year: 2568, Semester: 1, Version: 2},
+	}}, thaicalendar.EraCE)
+	assert.Len(t, res, 1)
+	assert.Equal(t, 2, res[0].Version)
+	assert.Equal(t, from.Add(24*time.Hour), res[0].ValidTo)
+	assert.Equal(t, 2, res[0].Course.Version)
+	assert.Equal(t, 2025, res[0].Course.Year)
 }
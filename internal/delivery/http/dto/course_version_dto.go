@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+)
+
+// --- Course Version Response DTOs ---
+
+// CourseVersionResponse is a previous version of a course and the period it
+// was served.
+type CourseVersionResponse struct {
+	Version   int             `json:"version" example:"2"`
+	ValidFrom time.Time       `json:"valid_from"`
+	ValidTo   time.Time       `json:"valid_to"`
+	Course    *CourseResponse `json:"course"`
+}
+
+// ToCourseVersionResponse converts a CourseVersion entity to a response DTO.
+func ToCourseVersionResponse(v *entity.CourseVersion) *CourseVersionResponse {
+	return &CourseVersionResponse{
+		Version:   v.Version,
+		ValidFrom: v.ValidFrom,
+		ValidTo:   v.ValidTo,
+		Course:    ToCourseResponse(v.Course),
+	}
+}
+
+// ToCourseVersionResponses converts a slice of CourseVersion entities to
+// response DTOs, with years in the requested era.
+func ToCourseVersionResponses(versions []*entity.CourseVersion, era thaicalendar.Era) []*CourseVersionResponse {
+	out := make([]*CourseVersionResponse, len(versions))
+	for i, v := range versions {
+		out[i] = ToCourseVersionResponse(v)
+		out[i].Course.ApplyEra(era)
+	}
+	return out
+}
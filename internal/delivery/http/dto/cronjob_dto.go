@@ -10,11 +10,11 @@ import (
 
 // CreateCronJobRequest represents the request body for creating a cron job.
 type CreateCronJobRequest struct {
-	Name        string   `json:"name"`
-	CourseCodes []string `json:"course_codes"`
-	Acadyear    int      `json:"acadyear"`
-	Semester    int      `json:"semester"`
-	CronExpr    string   `json:"cron_expr"` // e.g. "0 */6 * * *"
+	Name        string   `json:"name" validate:"required"`
+	CourseCodes []string `json:"course_codes" validate:"required,dive,course_code"`
+	Acadyear    int      `json:"acadyear" example:"2568"`
+	Semester    int      `json:"semester" validate:"omitempty,min=1,max=3" minimum:"1" maximum:"3"`
+	CronExpr    string   `json:"cron_expr" validate:"required,cron" example:"0 */6 * * *"`
 	Enabled     bool     `json:"enabled"`
 }
 
@@ -32,11 +32,11 @@ func (r *CreateCronJobRequest) ToEntity() *entity.CronJob {
 
 // UpdateCronJobRequest represents the request body for updating a cron job.
 type UpdateCronJobRequest struct {
-	Name        string   `json:"name"`
-	CourseCodes []string `json:"course_codes"`
-	Acadyear    int      `json:"acadyear"`
-	Semester    int      `json:"semester"`
-	CronExpr    string   `json:"cron_expr"`
+	Name        string   `json:"name" validate:"required"`
+	CourseCodes []string `json:"course_codes" validate:"required,dive,course_code"`
+	Acadyear    int      `json:"acadyear" example:"2568"`
+	Semester    int      `json:"semester" validate:"omitempty,min=1,max=3" minimum:"1" maximum:"3"`
+	CronExpr    string   `json:"cron_expr" validate:"required,cron" example:"0 */6 * * *"`
 	Enabled     bool     `json:"enabled"`
 }
 
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+
+// EventData renders the domain data of an outbox event the way the API
+// returns it elsewhere, so users never carry their password hash. Unknown
+// types are passed through unchanged.
+func EventData(data interface{}) interface{} {
+	switch v := data.(type) {
+	case *entity.Course:
+		return ToCourseResponse(v)
+	case *entity.CronJob:
+		return ToCronJobResponse(v)
+	case *entity.CronJobRun:
+		return ToCronJobRunResponse(v)
+	case *entity.User:
+		return ToUserResponse(v)
+	default:
+		return data
+	}
+}
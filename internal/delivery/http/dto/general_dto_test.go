@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -87,6 +88,21 @@ func TestToHealthResponse(t *testing.T) {
 	res := ToHealthResponse(h)
 	assert.Equal(t, "OK", res.Status)
 	assert.Equal(t, "2024-01-01", res.Timestamp)
+	assert.Nil(t, res.CourseAPI)
+}
+
+func TestToHealthResponse_CourseAPI(t *testing.T) {
+	openUntil := time.Date(2026, 1, 1, 0, 0, 30, 0, time.UTC)
+	h := entity.Health{Status: "degraded", CourseAPI: &entity.UpstreamHealth{Breaker: "open", ConsecutiveFailures: 5, OpenUntil: openUntil, MaxConcurrent: 4, Connection: "TRANSIENT_FAILURE"}}
+	res := ToHealthResponse(h)
+	assert.False(t, res.Ready)
+	assert.Equal(t, "open", res.CourseAPI.Breaker)
+	assert.Equal(t, "TRANSIENT_FAILURE", res.CourseAPI.Connection)
+	assert.Equal(t, 5, res.CourseAPI.ConsecutiveFailures)
+	assert.Equal(t, &openUntil, res.CourseAPI.OpenUntil)
+
+	closed := ToUpstreamHealthResponse(&entity.UpstreamHealth{Breaker: "closed"})
+	assert.Nil(t, closed.OpenUntil)
 }
 
 // --- Version DTO Tests ---
@@ -145,3 +161,113 @@ func TestToUserResponses(t *testing.T) {
 	assert.Equal(t, "u1", res[0].Username)
 	assert.Equal(t, "u2", res[1].Username)
 }
+
+// --- Validation Tests ---
+
+// fieldErrors flattens validation errors into field → message for assertions.
+func fieldErrors(errs validation.Errors) map[string]string {
+	m := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		m[fe.Field] = fe.Message
+	}
+	return m
+}
+
+func TestCreateCronJobRequest_Validate(t *testing.T) {
+	valid := CreateCronJobRequest{
+		Name:        "Nightly",
+		CourseCodes: []string{"CP353004"},
+		Semester:    1,
+		CronExpr:    "0 */6 * * *",
+	}
+	assert.Empty(t, validation.Struct(&valid))
+
+	invalid := CreateCronJobRequest{
+		CourseCodes: []string{"CP353004", "bad"},
+		Semester:    4,
+		CronExpr:    "every day",
+	}
+	errs := fieldErrors(validation.Struct(&invalid))
+	assert.Equal(t, "is required", errs["name"])
+	assert.Contains(t, errs, "course_codes[1]")
+	assert.NotContains(t, errs, "course_codes[0]")
+	assert.Equal(t, "must be at most 3", errs["semester"])
+	assert.Contains(t, errs["cron_expr"], "invalid cron expression")
+}
+
+func TestRegisterRequest_Validate(t *testing.T) {
+	assert.Empty(t, validation.Struct(&RegisterRequest{Username: "u", Password: "secret1"}))
+
+	// Password strength is the password policy's job; the DTO only requires one.
+	errs := fieldErrors(validation.Struct(&RegisterRequest{Role: "root"}))
+	assert.Equal(t, "is required", errs["username"])
+	assert.Equal(t, "is required", errs["password"])
+	assert.Equal(t, "must be one of: superadmin, admin, student", errs["role"])
+
+	errs = fieldErrors(validation.Struct(&LoginRequest{}))
+	assert.Len(t, errs, 2)
+}
+
+func TestUpdateRolePermissionsRequest_Validate(t *testing.T) {
+	assert.Empty(t, validation.Struct(&UpdateRolePermissionsRequest{}))
+
+	errs := fieldErrors(validation.Struct(&UpdateRolePermissionsRequest{Permissions: []string{"course:write", "course:delete"}}))
+	assert.Equal(t, map[string]string{"permissions[1]": "unknown permission"}, errs)
+}
+
+func TestToPermissionsResponse(t *testing.T) {
+	now := time.Now()
+	resp := ToPermissionsResponse([]string{"a", "b"}, []*entity.RolePermissions{
+		{Role: "admin", Permissions: []string{"a"}, UpdatedAt: now, UpdatedBy: "u1"},
+		{Role: "student"},
+	})
+	assert.Equal(t, []string{"a", "b"}, resp.Permissions)
+	assert.Len(t, resp.Roles, 2)
+	assert.Equal(t, &now, resp.Roles[0].UpdatedAt)
+	assert.Equal(t, []string{}, resp.Roles[1].Permissions)
+	assert.Nil(t, resp.Roles[1].UpdatedAt)
+}
+
+// --- Webhook DTO Tests ---
+
+func TestCreateWebhookRequest_Validate(t *testing.T) {
+	assert.Empty(t, validation.Struct(&CreateWebhookRequest{URL: "https://example.com/hook", Events: []string{"course.updated"}}))
+
+	errs := fieldErrors(validation.Struct(&CreateWebhookRequest{Events: []string{"course.updated", "course.renamed"}, Secret: "short"}))
+	assert.Equal(t, "is required", errs["url"])
+	assert.Equal(t, "unknown webhook event", errs["events[1]"])
+	assert.NotContains(t, errs, "events[0]")
+	assert.Contains(t, errs, "secret")
+}
+
+func TestToWebhookDeliveryResponse(t *testing.T) {
+	next := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	pending := ToWebhookDeliveryResponse(&entity.WebhookDelivery{
+		ID: "d1", Payload: []byte(`{"id":"e1"}`), Status: entity.WebhookDeliveryPending, NextAttemptAt: next,
+	})
+	assert.JSONEq(t, `{"id":"e1"}`, string(pending.Payload))
+	assert.Equal(t, "pending", pending.Status)
+	assert.Equal(t, &next, pending.NextAttemptAt)
+
+	done := ToWebhookDeliveryResponse(&entity.WebhookDelivery{ID: "d2", Payload: []byte(`{}`), Status: entity.WebhookDeliverySucceeded, NextAttemptAt: next})
+	assert.Nil(t, done.NextAttemptAt, "only pending deliveries have a next attempt")
+}
+
+func TestEventData(t *testing.T) {
+	course, ok := EventData(&entity.Course{Code: "CP353004"}).(*CourseResponse)
+	if assert.True(t, ok) {
+		assert.Equal(t, "CP353004", course.Code)
+	}
+	run, ok := EventData(&entity.CronJobRun{JobID: "j1", Refreshed: 3}).(*CronJobRunResponse)
+	if assert.True(t, ok) {
+		assert.Equal(t, "j1", run.JobID)
+		assert.Equal(t, 3, run.Refreshed)
+	}
+	user, ok := EventData(&entity.User{Username: "alice", Password: "hash"}).(*UserResponse)
+	if assert.True(t, ok, "users are never serialised with their password hash") {
+		assert.Equal(t, "alice", user.Username)
+	}
+	_, ok = EventData(&entity.CronJob{Name: "nightly"}).(*CronJobResponse)
+	assert.True(t, ok)
+	assert.Equal(t, "other", EventData("other"))
+}
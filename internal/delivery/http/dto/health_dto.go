@@ -1,17 +1,63 @@
 package dto
 
-import "github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/queue"
+)
 
 // HealthResponse is the DTO for the health/status endpoint.
 type HealthResponse struct {
-	Status    string `json:"status"`
-	Timestamp string `json:"timestamp"`
+	Status    string                  `json:"status" example:"ok"` // ok or degraded
+	Ready     bool                    `json:"ready" example:"true"`
+	Timestamp string                  `json:"timestamp"`
+	CourseAPI *UpstreamHealthResponse `json:"course_api,omitempty"`
+}
+
+// UpstreamHealthResponse describes the circuit breaker and concurrency
+// limit in front of an upstream service.
+type UpstreamHealthResponse struct {
+	Breaker             string     `json:"breaker" enums:"closed,open,half-open" example:"closed"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenUntil           *time.Time `json:"open_until,omitempty"`
+	InFlight            int        `json:"in_flight"`
+	MaxConcurrent       int        `json:"max_concurrent" example:"5"`
+	Connection          string     `json:"connection,omitempty" enums:"IDLE,CONNECTING,READY,TRANSIENT_FAILURE,SHUTDOWN" example:"READY"`
+}
+
+// QueueStatusResponse is the refresh queue status plus the state of the
+// course API it calls.
+type QueueStatusResponse struct {
+	queue.QueueStatus
+	CourseAPI *UpstreamHealthResponse `json:"course_api,omitempty"`
 }
 
 // ToHealthResponse converts a domain Health entity to a HealthResponse DTO.
 func ToHealthResponse(h entity.Health) HealthResponse {
 	return HealthResponse{
 		Status:    h.Status,
+		Ready:     h.Ready,
 		Timestamp: h.Timestamp,
+		CourseAPI: ToUpstreamHealthResponse(h.CourseAPI),
+	}
+}
+
+// ToUpstreamHealthResponse converts an UpstreamHealth; nil stays nil.
+func ToUpstreamHealthResponse(u *entity.UpstreamHealth) *UpstreamHealthResponse {
+	if u == nil {
+		return nil
+	}
+	res := &UpstreamHealthResponse{
+		Breaker:             u.Breaker,
+		ConsecutiveFailures: u.ConsecutiveFailures,
+		InFlight:            u.InFlight,
+		MaxConcurrent:       u.MaxConcurrent,
+		Connection:          u.Connection,
+	}
+	if !u.OpenUntil.IsZero() {
+		openUntil := u.OpenUntil
+		res.OpenUntil = &openUntil
 	}
+	return res
 }
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// --- Permission Request DTOs ---
+
+// UpdateRolePermissionsRequest replaces the permissions granted by a role.
+// An empty list revokes everything.
+type UpdateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"dive,permission" example:"course:write,queue:read"`
+}
+
+// --- Permission Response DTOs ---
+
+// RolePermissionsResponse is a role and the permissions it grants.
+type RolePermissionsResponse struct {
+	Role        string     `json:"role" example:"admin"`
+	Permissions []string   `json:"permissions" example:"course:write,queue:read"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy   string     `json:"updated_by,omitempty"`
+}
+
+// PermissionsResponse lists every known permission and the current role bindings.
+type PermissionsResponse struct {
+	Permissions []string                   `json:"permissions" example:"course:write,cronjob:trigger"`
+	Roles       []*RolePermissionsResponse `json:"roles"`
+}
+
+// ToRolePermissionsResponse converts a RolePermissions entity to a response DTO.
+func ToRolePermissionsResponse(rp *entity.RolePermissions) *RolePermissionsResponse {
+	resp := &RolePermissionsResponse{
+		Role:        rp.Role,
+		Permissions: rp.Permissions,
+		UpdatedBy:   rp.UpdatedBy,
+	}
+	if resp.Permissions == nil {
+		resp.Permissions = []string{}
+	}
+	if !rp.UpdatedAt.IsZero() {
+		t := rp.UpdatedAt
+		resp.UpdatedAt = &t
+	}
+	return resp
+}
+
+// ToPermissionsResponse builds the permission catalogue response.
+func ToPermissionsResponse(all []string, roles []*entity.RolePermissions) *PermissionsResponse {
+	resp := &PermissionsResponse{Permissions: all, Roles: make([]*RolePermissionsResponse, len(roles))}
+	for i, rp := range roles {
+		resp.Roles[i] = ToRolePermissionsResponse(rp)
+	}
+	return resp
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// UnmappedValueResponse represents a raw upstream value the normaliser could not map or parse.
+type UnmappedValueResponse struct {
+	Field         string `json:"field" example:"day"`
+	Value         string `json:"value" example:"Mon-Wed"`
+	Count         int64  `json:"count" example:"12"` // distinct courses the value was seen on
+	ExampleCourse string `json:"example_course" example:"CP353004"`
+	FirstSeen     string `json:"first_seen"`
+	LastSeen      string `json:"last_seen"`
+}
+
+// ToUnmappedValueResponses converts UnmappedValue entities to response DTOs.
+func ToUnmappedValueResponses(values []*entity.UnmappedValue) []*UnmappedValueResponse {
+	responses := make([]*UnmappedValueResponse, len(values))
+	for i, v := range values {
+		responses[i] = &UnmappedValueResponse{
+			Field:         v.Field,
+			Value:         v.Value,
+			Count:         v.Count,
+			ExampleCourse: v.ExampleCourse,
+			FirstSeen:     v.FirstSeen.Format(time.RFC3339),
+			LastSeen:      v.LastSeen.Format(time.RFC3339),
+		}
+	}
+	return responses
+}
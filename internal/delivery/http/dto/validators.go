@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"regexp"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/scheduler"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
+)
+
+// courseCodePattern matches registrar course codes such as "CP353004".
+var courseCodePattern = regexp.MustCompile(`^[A-Za-z]{2}[0-9]{6}$`)
+
+func init() {
+	validation.Register("course_code", func(v interface{}, _ string) string {
+		if s, _ := v.(string); !courseCodePattern.MatchString(s) {
+			return "must be 2 letters followed by 6 digits, e.g. CP353004"
+		}
+		return ""
+	})
+
+	validation.Register("cron", func(v interface{}, _ string) string {
+		s, _ := v.(string)
+		if err := scheduler.ValidateCronExpr(s); err != nil {
+			return "invalid cron expression: " + err.Error()
+		}
+		return ""
+	})
+
+	validation.Register("permission", func(v interface{}, _ string) string {
+		if s, _ := v.(string); !constants.ValidPermissions[s] {
+			return "unknown permission"
+		}
+		return ""
+	})
+
+	validation.Register("webhook_event", func(v interface{}, _ string) string {
+		if s, _ := v.(string); !constants.ValidWebhookEvents[s] {
+			return "unknown webhook event"
+		}
+		return ""
+	})
+}
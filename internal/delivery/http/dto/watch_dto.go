@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// --- Watch Request DTOs ---
+
+// CreateWatchRequest is the body of POST /watches.
+type CreateWatchRequest struct {
+	CourseID  string `json:"course_id" validate:"required" example:"665f1c2e8a1b2c3d4e5f6a7b"`
+	SectionID string `json:"section_id,omitempty" example:"665f1c2e8a1b2c3d4e5f6a7c"` // omit to watch the whole course
+}
+
+// --- Watch Response DTOs ---
+
+// WatchResponse describes a course or section the user watches.
+type WatchResponse struct {
+	ID         string    `json:"id"`
+	CourseID   string    `json:"course_id"`
+	SectionID  string    `json:"section_id,omitempty"`
+	CourseCode string    `json:"course_code" example:"CP353004"`
+	Year       int       `json:"year" example:"2568"`
+	Semester   int       `json:"semester" example:"1"`
+	Section    string    `json:"section,omitempty" example:"01"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ToWatchResponse converts a Watch entity to a response DTO.
+func ToWatchResponse(w *entity.Watch) *WatchResponse {
+	return &WatchResponse{
+		ID:         w.ID,
+		CourseID:   w.CourseID,
+		SectionID:  w.SectionID,
+		CourseCode: w.CourseCode,
+		Year:       w.Year,
+		Semester:   w.Semester,
+		Section:    w.Section,
+		CreatedAt:  w.CreatedAt,
+	}
+}
+
+// ToWatchResponses converts a slice of Watch entities to response DTOs.
+func ToWatchResponses(watches []*entity.Watch) []*WatchResponse {
+	out := make([]*WatchResponse, len(watches))
+	for i, w := range watches {
+		out[i] = ToWatchResponse(w)
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// --- Webhook Request DTOs ---
+
+// CreateWebhookRequest is the body of POST /webhooks.
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,max=2048" example:"https://example.com/hooks/courses"`
+	Events      []string `json:"events" validate:"required,dive,webhook_event" example:"course.updated,cronjob.run.completed"`
+	Secret      string   `json:"secret,omitempty" validate:"omitempty,min=16,max=256"`
+	Description string   `json:"description,omitempty" validate:"max=200"`
+}
+
+// ToEntity converts a CreateWebhookRequest to a domain entity created by createdBy.
+func (r *CreateWebhookRequest) ToEntity(createdBy string) *entity.Webhook {
+	return &entity.Webhook{
+		URL:         r.URL,
+		Events:      r.Events,
+		Secret:      r.Secret,
+		Description: r.Description,
+		CreatedBy:   createdBy,
+	}
+}
+
+// --- Webhook Response DTOs ---
+
+// WebhookResponse describes a webhook subscription. The secret is never returned.
+type WebhookResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url" example:"https://example.com/hooks/courses"`
+	Events      []string  `json:"events" example:"course.updated"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateWebhookResponse is returned once, on creation, and includes the signing secret.
+type CreateWebhookResponse struct {
+	*WebhookResponse
+	Secret string `json:"secret" example:"whsec_Ab12Cd34..."`
+}
+
+// ToWebhookResponse converts a Webhook entity to a response DTO.
+func ToWebhookResponse(w *entity.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:          w.ID,
+		URL:         w.URL,
+		Events:      w.Events,
+		Description: w.Description,
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+// ToWebhookResponses converts a slice of Webhook entities to response DTOs.
+func ToWebhookResponses(webhooks []*entity.Webhook) []*WebhookResponse {
+	out := make([]*WebhookResponse, len(webhooks))
+	for i, w := range webhooks {
+		out[i] = ToWebhookResponse(w)
+	}
+	return out
+}
+
+// WebhookDeliveryResponse describes one delivery of an event to a webhook.
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	Event          string          `json:"event" example:"course.updated"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status" example:"pending" enums:"pending,succeeded,failed"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty" example:"502"`
+	LastError      string          `json:"last_error,omitempty"`
+	ReplayOf       string          `json:"replay_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// ToWebhookDeliveryResponse converts a WebhookDelivery entity to a response DTO.
+func ToWebhookDeliveryResponse(d *entity.WebhookDelivery) *WebhookDeliveryResponse {
+	resp := &WebhookDeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		Event:          d.Event,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.Status == entity.WebhookDeliveryPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+// ToWebhookDeliveryResponses converts a slice of WebhookDelivery entities to response DTOs.
+func ToWebhookDeliveryResponses(deliveries []*entity.WebhookDelivery) []*WebhookDeliveryResponse {
+	out := make([]*WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		out[i] = ToWebhookDeliveryResponse(d)
+	}
+	return out
+}
+
+// CronJobRunResponse is the data of a cronjob.run.completed event.
+type CronJobRunResponse struct {
+	JobID       string    `json:"job_id"`
+	JobName     string    `json:"job_name"`
+	Manual      bool      `json:"manual"`
+	Acadyear    int       `json:"acadyear"`
+	Semester    int       `json:"semester"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	Refreshed   int       `json:"refreshed"`
+	Failed      int       `json:"failed"`
+	Skipped     int       `json:"skipped"`
+}
+
+// ToCronJobRunResponse converts a CronJobRun entity to a response DTO.
+func ToCronJobRunResponse(r *entity.CronJobRun) *CronJobRunResponse {
+	return &CronJobRunResponse{
+		JobID:       r.JobID,
+		JobName:     r.JobName,
+		Manual:      r.Manual,
+		Acadyear:    r.Acadyear,
+		Semester:    r.Semester,
+		StartedAt:   r.StartedAt,
+		CompletedAt: r.CompletedAt,
+		Refreshed:   r.Refreshed,
+		Failed:      r.Failed,
+		Skipped:     r.Skipped,
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyHandler handles HTTP requests for service API keys.
+type APIKeyHandler struct {
+	usecase usecase.APIKeyUsecase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance.
+func NewAPIKeyHandler(uc usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{usecase: uc}
+}
+
+// CreateAPIKey issues a new API key.
+// @Summary Create an API key
+// @Description Issue an API key for a service client. The key is returned once and only its hash is stored. Scopes must be permissions the caller's role holds. Requires the apikey:manage permission.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "API Key"
+// @Security BearerAuth
+// @Success 201 {object} dto.CreateAPIKeyResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	callerID, callerRole, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key, plaintext, err := h.usecase.Create(ctx, req.Name, req.Scopes, req.ExpiresAt, callerID, callerRole)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrScopeNotHeld):
+			return response.Forbidden(adapter.NewFiberResponder(c), err.Error())
+		case errors.Is(err, usecase.ErrExpiryInPast), errors.Is(err, usecase.ErrUnknownPermission):
+			return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.Created(adapter.NewFiberResponder(c), &dto.CreateAPIKeyResponse{
+		APIKeyResponse: dto.ToAPIKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+// GetAPIKeys lists API keys, including revoked and expired ones.
+// @Summary List API keys
+// @Description List every API key, newest first. Keys themselves are never returned. Requires the apikey:manage permission.
+// @Tags api-keys
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	keys, err := h.usecase.List(ctx)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToAPIKeyResponses(keys))
+}
+
+// RevokeAPIKey revokes an API key immediately.
+// @Summary Revoke an API key
+// @Description Revoke an API key. Requests using it are rejected from then on. Requires the apikey:manage permission.
+// @Tags api-keys
+// @Param id path string true "API key ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.Revoke(ctx, c.Params("id")); err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			return response.NotFound(adapter.NewFiberResponder(c), "API key not found")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
+}
+
+// AuditSnapshot returns the API key with the given ID, or nil. The key
+// itself is never part of the snapshot.
+func (h *APIKeyHandler) AuditSnapshot(ctx context.Context, id string) (interface{}, error) {
+	keys, err := h.usecase.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k.ID == id {
+			return dto.ToAPIKeyResponse(k), nil
+		}
+	}
+	return nil, nil
+}
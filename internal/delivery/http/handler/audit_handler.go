@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditHandler handles HTTP requests for the audit log.
+type AuditHandler struct {
+	usecase usecase.AuditUsecase
+}
+
+// NewAuditHandler creates a new AuditHandler instance.
+func NewAuditHandler(uc usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{usecase: uc}
+}
+
+// GetAuditLog lists recorded administrative actions, newest first.
+// @Summary Get audit log (paginated)
+// @Description List administrative changes with the acting user, client IP and before/after snapshots, newest first. Requires the audit:read permission. Use limit=0 to fetch all.
+// @Tags audit
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10, 0=all)"
+// @Param actor_id query string false "Only actions by this user ID (or apikey:<id>)"
+// @Param action query string false "Only this action, e.g. course.delete"
+// @Param target_type query string false "Only this target type" Enums(course, cronjob, user, role_permissions, apikey, webhook, webhook_delivery)
+// @Param target_id query string false "Only this target ID"
+// @Param from query string false "Earliest time, inclusive (RFC 3339)"
+// @Param to query string false "Latest time, exclusive (RFC 3339)"
+// @Security BearerAuth
+// @Success 200 {array} dto.AuditEntryResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditLog(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	pq := pagination.FromQuery(page, limit)
+
+	filter := repository.AuditFilter{
+		ActorID:    c.Query("actor_id"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+	var err error
+	if filter.From, err = queryTime(c, "from"); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+	if filter.To, err = queryTime(c, "to"); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := h.usecase.List(ctx, filter, pq)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAuditRange) {
+			return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c),
+		dto.ToAuditEntryResponses(result.Items),
+		result.GetMeta(),
+	)
+}
+
+// queryTime parses an optional RFC 3339 query parameter.
+func queryTime(c *fiber.Ctx, key string) (time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, errors.New(key + " must be an RFC 3339 time, e.g. 2025-06-01T00:00:00Z")
+	}
+	return t, nil
+}
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"math"
 	"strconv"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -27,13 +30,14 @@ func NewAuthHandler(uc usecase.AuthUsecase) *AuthHandler {
 
 // Register creates a new student user (public).
 // @Summary Register a new student
-// @Description Create a new user with student role (public endpoint)
+// @Description Create a new user with student role (public endpoint). The password must satisfy the password policy; violations are returned as 422 field errors.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.RegisterRequest true "Register Request"
 // @Success 201 {object} dto.RegisterResponse
 // @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
 // @Failure 409 {object} interface{}
 // @Failure 500 {object} interface{}
 // @Router /auth/register [post]
@@ -43,12 +47,8 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
 	}
 
-	if req.Username == "" || req.Password == "" {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Username and password are required")
-	}
-
-	if len(req.Password) < 6 {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Password must be at least 6 characters")
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
 	}
 
 	// Public register is always student role
@@ -59,6 +59,9 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 
 	user, err := h.usecase.Register(ctx, req.Username, req.Password, role, nil)
 	if err != nil {
+		if errors.Is(err, usecase.ErrWeakPassword) {
+			return weakPasswordError(c, "password", err)
+		}
 		if err.Error() == "username already exists" {
 			return response.Conflict(adapter.NewFiberResponder(c), err.Error())
 		}
@@ -70,7 +73,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 
 // CreateUser creates a user with any role (admin-only).
 // @Summary Create a user (admin)
-// @Description Create a new user with any role. Requires superadmin or admin JWT.
+// @Description Create a new user with any role. Requires the user:manage permission.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -78,6 +81,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Success 201 {object} dto.RegisterResponse
 // @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
 // @Failure 403 {object} interface{}
 // @Failure 409 {object} interface{}
 // @Failure 500 {object} interface{}
@@ -88,19 +92,13 @@ func (h *AuthHandler) CreateUser(c *fiber.Ctx) error {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
 	}
 
-	if req.Username == "" || req.Password == "" {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Username and password are required")
-	}
-
-	if len(req.Password) < 6 {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Password must be at least 6 characters")
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
 	}
 
 	role := req.Role
-	if req.Role == "" {
+	if role == "" {
 		role = constants.RoleStudent
-	} else if !constants.ValidRoles[role] {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid role. Allowed: superadmin, admin, student")
 	}
 
 	// Extract caller role from JWT claims
@@ -115,11 +113,16 @@ func (h *AuthHandler) CreateUser(c *fiber.Ctx) error {
 
 	user, err := h.usecase.Register(ctx, req.Username, req.Password, role, &callerRole)
 	if err != nil {
+		if errors.Is(err, usecase.ErrAdminGrantDenied) {
+			return response.Forbidden(adapter.NewFiberResponder(c), err.Error())
+		}
+		if errors.Is(err, usecase.ErrWeakPassword) {
+			return weakPasswordError(c, "password", err)
+		}
 		switch err.Error() {
 		case "username already exists":
 			return response.Conflict(adapter.NewFiberResponder(c), err.Error())
-		case "only superadmin or admin can create admin users",
-			"superadmin cannot be created via registration":
+		case "superadmin cannot be created via registration":
 			return response.Forbidden(adapter.NewFiberResponder(c), err.Error())
 		}
 		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
@@ -130,14 +133,20 @@ func (h *AuthHandler) CreateUser(c *fiber.Ctx) error {
 
 // Login authenticates a user and returns a JWT token.
 // @Summary Login
-// @Description Authenticate with username and password to receive a JWT token
+// @Description Authenticate with username and password to receive a JWT token.
+// @Description Repeated failures lock the username (423) or the client IP (429); see the Retry-After header.
+// @Description Accounts that must change their password (such as the seeded superadmin) get a 403 with a one-time password_change_token for POST /auth/password-reset instead of tokens.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "Login Request"
 // @Success 200 {object} dto.LoginResponse
 // @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
 // @Failure 401 {object} interface{}
+// @Failure 403 {object} response.Body{data=dto.PasswordChangeRequiredResponse} "Account disabled, or password change required"
+// @Failure 423 {object} interface{}
+// @Failure 429 {object} interface{}
 // @Failure 500 {object} interface{}
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
@@ -146,27 +155,125 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
 	}
 
-	if req.Username == "" || req.Password == "" {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Username and password are required")
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	token, err := h.usecase.Login(ctx, req.Username, req.Password)
+	tokens, err := h.usecase.Login(ctx, req.Username, req.Password, c.IP())
 	if err != nil {
+		var locked *usecase.LoginLockedError
+		if errors.As(err, &locked) {
+			return loginLocked(c, locked)
+		}
 		if err.Error() == "invalid credentials" {
 			return response.Unauthorized(adapter.NewFiberResponder(c), "Invalid username or password")
 		}
+		if errors.Is(err, usecase.ErrUserDisabled) {
+			return response.Forbidden(adapter.NewFiberResponder(c), "Account is disabled")
+		}
+		var change *usecase.PasswordChangeRequiredError
+		if errors.As(err, &change) {
+			return response.ForbiddenWithData(adapter.NewFiberResponder(c), "Password change required", &dto.PasswordChangeRequiredResponse{
+				PasswordChangeToken: change.Token,
+				ExpiresAt:           change.ExpiresAt,
+			})
+		}
 		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
 	}
 
-	return response.OK(adapter.NewFiberResponder(c), dto.LoginResponse{Token: token})
+	return response.OK(adapter.NewFiberResponder(c), dto.ToLoginResponse(tokens))
+}
+
+// loginLocked writes the lockout response. The message is identical for
+// account and client lockouts, and for known and unknown usernames.
+func loginLocked(c *fiber.Ctx, locked *usecase.LoginLockedError) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+	const msg = "Too many failed login attempts. Try again later."
+	if locked.Scope == usecase.LockScopeAccount {
+		return response.Locked(adapter.NewFiberResponder(c), msg)
+	}
+	return response.TooManyRequests(adapter.NewFiberResponder(c), msg)
+}
+
+// Refresh exchanges a refresh token for a new token pair.
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated; the old one stops working.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Refresh Request"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req dto.RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tokens, err := h.usecase.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidRefreshToken):
+			return response.Unauthorized(adapter.NewFiberResponder(c), "Invalid or expired refresh token")
+		case errors.Is(err, usecase.ErrUserDisabled):
+			return response.Forbidden(adapter.NewFiberResponder(c), "Account is disabled")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToLoginResponse(tokens))
+}
+
+// Logout revokes the session behind a refresh token.
+// @Summary Logout
+// @Description Revoke the session behind a refresh token. Access tokens issued for it stop working immediately.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Logout Request"
+// @Success 204
+// @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req dto.RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.Logout(ctx, req.RefreshToken); err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
 }
 
 // GetUsers retrieves users with pagination (admin-only).
 // @Summary Get users (paginated)
-// @Description Retrieve a paginated list of users. Requires superadmin or admin JWT. Use limit=0 to fetch all.
+// @Description Retrieve a paginated list of users. Requires the user:manage permission. Use limit=0 to fetch all.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -196,3 +303,294 @@ func (h *AuthHandler) GetUsers(c *fiber.Ctx) error {
 		result.GetMeta(),
 	)
 }
+
+// ---------- Self-service ----------
+
+// callerClaims returns the user ID, role and session ID from the JWT claims set by JWTAuth.
+func callerClaims(c *fiber.Ctx) (userID, role, sessionID string, ok bool) {
+	claims, ok := c.Locals("user").(jwt.MapClaims)
+	if !ok {
+		return "", "", "", false
+	}
+	userID, _ = claims["sub"].(string)
+	role, _ = claims["role"].(string)
+	sessionID, _ = claims["sid"].(string)
+	return userID, role, sessionID, userID != ""
+}
+
+// userError maps user-management usecase errors to HTTP responses.
+func userError(c *fiber.Ctx, err error) error {
+	r := adapter.NewFiberResponder(c)
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return response.NotFound(r, "User not found")
+	case errors.Is(err, usecase.ErrUsernameExists):
+		return response.Conflict(r, err.Error())
+	case errors.Is(err, usecase.ErrWrongPassword):
+		return response.BadRequest(r, err.Error())
+	case errors.Is(err, usecase.ErrSuperAdminProtected),
+		errors.Is(err, usecase.ErrSuperAdminRole),
+		errors.Is(err, usecase.ErrSelfModification),
+		errors.Is(err, usecase.ErrAdminGrantDenied):
+		return response.Forbidden(r, err.Error())
+	case errors.Is(err, usecase.ErrInvalidRole):
+		return response.BadRequest(r, err.Error())
+	}
+	return response.InternalError(r, err.Error())
+}
+
+// weakPasswordError reports each password policy violation as a field error on field.
+func weakPasswordError(c *fiber.Ctx, field string, err error) error {
+	var perr *usecase.PasswordPolicyError
+	if !errors.As(err, &perr) {
+		return response.UnprocessableEntity(adapter.NewFiberResponder(c), err.Error())
+	}
+	errs := make(validation.Errors, len(perr.Violations))
+	for i, v := range perr.Violations {
+		errs[i] = validation.FieldError{Field: field, Message: v}
+	}
+	return response.ValidationError(adapter.NewFiberResponder(c), errs)
+}
+
+// GetMe returns the authenticated user's profile.
+// @Summary Get my profile
+// @Description Return the profile of the authenticated user
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 401 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /auth/me [get]
+func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
+	userID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.usecase.GetProfile(ctx, userID)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToUserResponse(user))
+}
+
+// UpdateMe updates the authenticated user's profile.
+// @Summary Update my profile
+// @Description Change the authenticated user's username
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateProfileRequest true "Profile Update"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 409 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /auth/me [patch]
+func (h *AuthHandler) UpdateMe(c *fiber.Ctx) error {
+	userID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.usecase.UpdateProfile(ctx, userID, req.Username)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToUserResponse(user))
+}
+
+// ChangePassword changes the authenticated user's password.
+// @Summary Change my password
+// @Description Change the password after verifying the current one. The new password must satisfy the password policy. Other sessions are signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangePasswordRequest true "Password Change"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /auth/me/password [post]
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
+	userID, _, sessionID, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.ChangePassword(ctx, userID, sessionID, req.OldPassword, req.NewPassword); err != nil {
+		if errors.Is(err, usecase.ErrWeakPassword) {
+			return weakPasswordError(c, "new_password", err)
+		}
+		return userError(c, err)
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
+}
+
+// ---------- Administration ----------
+
+// ChangeUserRole changes another user's role (admin-only).
+// @Summary Change a user's role (admin)
+// @Description Change a user's role. Superadmin accounts cannot be changed and the superadmin role cannot be assigned.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.ChangeRoleRequest true "Role Change"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /auth/users/{id}/role [patch]
+func (h *AuthHandler) ChangeUserRole(c *fiber.Ctx) error {
+	callerID, callerRole, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.ChangeRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.usecase.ChangeRole(ctx, callerID, callerRole, c.Params("id"), req.Role)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToUserResponse(user))
+}
+
+// DisableUser disables a user (admin-only).
+// @Summary Disable a user (admin)
+// @Description Disable a user. Their sessions are revoked and they can no longer log in.
+// @Tags auth
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /auth/users/{id}/disable [post]
+func (h *AuthHandler) DisableUser(c *fiber.Ctx) error {
+	return h.setDisabled(c, true)
+}
+
+// EnableUser re-enables a disabled user (admin-only).
+// @Summary Enable a user (admin)
+// @Description Re-enable a disabled user
+// @Tags auth
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /auth/users/{id}/enable [post]
+func (h *AuthHandler) EnableUser(c *fiber.Ctx) error {
+	return h.setDisabled(c, false)
+}
+
+func (h *AuthHandler) setDisabled(c *fiber.Ctx, disabled bool) error {
+	callerID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user, err := h.usecase.SetDisabled(ctx, callerID, c.Params("id"), disabled)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToUserResponse(user))
+}
+
+// DeleteUser soft-deletes a user (admin-only).
+// @Summary Delete a user (admin)
+// @Description Soft-delete a user and revoke their sessions
+// @Tags auth
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /auth/users/{id} [delete]
+func (h *AuthHandler) DeleteUser(c *fiber.Ctx) error {
+	callerID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.DeleteUser(ctx, callerID, c.Params("id")); err != nil {
+		return userError(c, err)
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
+}
+
+// AuditSnapshot returns the user with the given ID, or nil.
+func (h *AuthHandler) AuditSnapshot(ctx context.Context, id string) (interface{}, error) {
+	user, err := h.usecase.GetProfile(ctx, id)
+	if errors.Is(err, usecase.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToUserResponse(user), nil
+}
@@ -3,14 +3,19 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -33,6 +38,7 @@ func NewCourseHandler(uc usecase.CourseUsecase) *CourseHandler {
 // @Param request body dto.CreateCourseRequest true "Course Request"
 // @Success 201 {object} dto.CourseResponse
 // @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
 // @Failure 500 {object} interface{}
 // @Router /courses [post]
 func (h *CourseHandler) CreateCourse(c *fiber.Ctx) error {
@@ -41,14 +47,22 @@ func (h *CourseHandler) CreateCourse(c *fiber.Ctx) error {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
 	}
 
-	if req.Code == "" || req.NameEN == "" || req.Credits == "" {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Missing required fields")
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	course := req.ToEntity()
+	course, err := req.ToEntity()
+	if err != nil {
+		var verrs validation.Errors
+		if errors.As(err, &verrs) {
+			return response.ValidationError(adapter.NewFiberResponder(c), verrs)
+		}
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+
 	if err := h.usecase.CreateCourse(ctx, course); err != nil {
 		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
 	}
@@ -64,7 +78,9 @@ func (h *CourseHandler) CreateCourse(c *fiber.Ctx) error {
 // @Produce json
 // @Param page query int false "Page number (default 1)"
 // @Param limit query int false "Items per page (default 10, 0=all)"
+// @Param era query string false "Year era in the response: be (default) or ce" Enums(be, ce)
 // @Success 200 {object} interface{}
+// @Failure 400 {object} interface{}
 // @Failure 500 {object} interface{}
 // @Router /courses [get]
 func (h *CourseHandler) GetCourses(c *fiber.Ctx) error {
@@ -72,6 +88,11 @@ func (h *CourseHandler) GetCourses(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit"))
 	pq := pagination.FromQuery(page, limit)
 
+	era, err := thaicalendar.ParseEra(c.Query("era"))
+	if err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -80,10 +101,12 @@ func (h *CourseHandler) GetCourses(c *fiber.Ctx) error {
 		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
 	}
 
-	return response.OK(adapter.NewFiberResponder(c),
-		dto.ToCourseSummaryResponses(result.Items),
-		result.GetMeta(),
-	)
+	items := dto.ToCourseSummaryResponses(result.Items)
+	for _, item := range items {
+		item.ApplyEra(era)
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), items, result.GetMeta())
 }
 
 // GetCourse retrieves a course by code.
@@ -93,8 +116,10 @@ func (h *CourseHandler) GetCourses(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param code path string true "Course Code"
-// @Param acadyear query int true "Academic Year"
+// @Param acadyear query int true "Academic Year (BE, e.g. 2568, or CE, e.g. 2025)"
 // @Param semester query int true "Semester"
+// @Param era query string false "Year era in the response: be (default) or ce" Enums(be, ce)
+// @Param as_of query string false "Return the course as stored at this RFC 3339 time, without fetching it, e.g. 2025-06-01T00:00:00+07:00"
 // @Success 200 {object} dto.CourseResponse
 // @Failure 400 {object} interface{}
 // @Failure 404 {object} interface{}
@@ -104,14 +129,36 @@ func (h *CourseHandler) GetCourse(c *fiber.Ctx) error {
 	code := c.Params("code")
 	acadyear, _ := strconv.Atoi(c.Query("acadyear"))
 	semester, _ := strconv.Atoi(c.Query("semester"))
+	acadyear = thaicalendar.NormalizeToBE(acadyear)
 
 	if acadyear == 0 || semester == 0 {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Missing or invalid acadyear/semester")
 	}
 
+	era, err := thaicalendar.ParseEra(c.Query("era"))
+	if err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	asOf, err := queryTime(c, "as_of")
+	if err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if !asOf.IsZero() {
+		course, err := h.usecase.GetCourseAsOf(ctx, code, acadyear, semester, asOf)
+		if err != nil {
+			if errors.Is(err, usecase.ErrCourseNotFound) {
+				return response.NotFound(adapter.NewFiberResponder(c), "Course not found at "+asOf.Format(time.RFC3339))
+			}
+			return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+		}
+		return response.OK(adapter.NewFiberResponder(c), dto.ToCourseResponse(course).ApplyEra(era))
+	}
+
 	course, err := h.usecase.GetCourseByCode(ctx, code, acadyear, semester)
 	if err != nil {
 		switch {
@@ -124,7 +171,98 @@ func (h *CourseHandler) GetCourse(c *fiber.Ctx) error {
 		}
 	}
 
-	return response.OK(adapter.NewFiberResponder(c), dto.ToCourseResponse(course))
+	return response.OK(adapter.NewFiberResponder(c), dto.ToCourseResponse(course).ApplyEra(era))
+}
+
+// GetCourseChanges lists the changes refreshes found in a course.
+// @Summary Get course change history (paginated)
+// @Description List what each refresh changed in a course (sections added or removed, schedule, room, instructor, seat and exam changes, sections closed), newest first. acadyear and semester are optional; without them every term is listed. Use limit=0 to fetch all.
+// @Tags courses
+// @Produce json
+// @Param code path string true "Course Code"
+// @Param acadyear query int false "Academic Year (BE, e.g. 2568, or CE, e.g. 2025)"
+// @Param semester query int false "Semester"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10, 0=all)"
+// @Success 200 {array} dto.CourseChangeEventResponse
+// @Failure 500 {object} interface{}
+// @Router /courses/{code}/changes [get]
+func (h *CourseHandler) GetCourseChanges(c *fiber.Ctx) error {
+	acadyear, _ := strconv.Atoi(c.Query("acadyear"))
+	semester, _ := strconv.Atoi(c.Query("semester"))
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	pq := pagination.FromQuery(page, limit)
+
+	filter := repository.CourseChangeFilter{
+		CourseCode: c.Params("code"),
+		Year:       thaicalendar.NormalizeToBE(acadyear),
+		Semester:   semester,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := h.usecase.GetCourseChanges(ctx, filter, pq)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c),
+		dto.ToCourseChangeEventResponses(result.Items),
+		result.GetMeta(),
+	)
+}
+
+// GetCourseVersions lists the previous versions of a course.
+// @Summary Get course version history (paginated)
+// @Description List every version of a course that an update has replaced, newest first, with the period each was served. The current version is GET /courses/{code}. Use limit=0 to fetch all.
+// @Tags courses
+// @Produce json
+// @Param code path string true "Course Code"
+// @Param acadyear query int true "Academic Year (BE, e.g. 2568, or CE, e.g. 2025)"
+// @Param semester query int true "Semester"
+// @Param era query string false "Year era in the response: be (default) or ce" Enums(be, ce)
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10, 0=all)"
+// @Success 200 {array} dto.CourseVersionResponse
+// @Failure 400 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /courses/{code}/versions [get]
+func (h *CourseHandler) GetCourseVersions(c *fiber.Ctx) error {
+	acadyear, _ := strconv.Atoi(c.Query("acadyear"))
+	semester, _ := strconv.Atoi(c.Query("semester"))
+	acadyear = thaicalendar.NormalizeToBE(acadyear)
+
+	if acadyear == 0 || semester == 0 {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Missing or invalid acadyear/semester")
+	}
+
+	era, err := thaicalendar.ParseEra(c.Query("era"))
+	if err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	pq := pagination.FromQuery(page, limit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := h.usecase.GetCourseVersions(ctx, c.Params("code"), acadyear, semester, pq)
+	if err != nil {
+		if errors.Is(err, usecase.ErrCourseNotFound) {
+			return response.NotFound(adapter.NewFiberResponder(c), "Course not found")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c),
+		dto.ToCourseVersionResponses(result.Items, era),
+		result.GetMeta(),
+	)
 }
 
 // DeleteCourse deletes a course by code.
@@ -134,6 +272,8 @@ func (h *CourseHandler) GetCourse(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param code path string true "Course Code"
+// @Param acadyear query int true "Academic Year (BE, e.g. 2568, or CE, e.g. 2025)"
+// @Param semester query int true "Semester"
 // @Success 200 {object} interface{}
 // @Failure 500 {object} interface{}
 // @Router /courses/{code} [delete]
@@ -141,6 +281,7 @@ func (h *CourseHandler) DeleteCourse(c *fiber.Ctx) error {
 	code := c.Params("code")
 	acadyear, _ := strconv.Atoi(c.Query("acadyear"))
 	semester, _ := strconv.Atoi(c.Query("semester"))
+	acadyear = thaicalendar.NormalizeToBE(acadyear)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -151,3 +292,57 @@ func (h *CourseHandler) DeleteCourse(c *fiber.Ctx) error {
 
 	return response.OK(adapter.NewFiberResponder(c), map[string]string{"message": "Course deleted"})
 }
+
+// GetUnmappedValues lists upstream values that could not be normalised or parsed.
+// @Summary Get unmapped schedule values
+// @Description Report of raw schedule day/type values with no canonical mapping, and of schedule times and exam dates that could not be parsed, those seen on the most courses first. Requires the normalization:read permission.
+// @Tags courses
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.UnmappedValueResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /normalization/unmapped [get]
+func (h *CourseHandler) GetUnmappedValues(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	values, err := h.usecase.GetUnmappedValues(ctx)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToUnmappedValueResponses(values))
+}
+
+// AuditID identifies the course a request targets as "CODE/year/semester",
+// from the path and query or, for creations, the response data.
+func (h *CourseHandler) AuditID(c *fiber.Ctx, data map[string]interface{}) string {
+	if code := c.Params("code"); code != "" {
+		acadyear, _ := strconv.Atoi(c.Query("acadyear"))
+		semester, _ := strconv.Atoi(c.Query("semester"))
+		return fmt.Sprintf("%s/%d/%d", strings.ToUpper(code), thaicalendar.NormalizeToBE(acadyear), semester)
+	}
+	code, _ := data["code"].(string)
+	year, _ := data["year"].(float64)
+	semester, _ := data["semester"].(float64)
+	if code == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d/%d", code, int(year), int(semester))
+}
+
+// AuditSnapshot returns the stored course identified by an AuditID, or nil.
+func (h *CourseHandler) AuditSnapshot(ctx context.Context, id string) (interface{}, error) {
+	var code string
+	var acadyear, semester int
+	if _, err := fmt.Sscanf(strings.ReplaceAll(id, "/", " "), "%s %d %d", &code, &acadyear, &semester); err != nil {
+		return nil, fmt.Errorf("invalid course audit ID %q: %w", id, err)
+	}
+	course, err := h.usecase.FindCourse(ctx, code, acadyear, semester)
+	if err != nil || course == nil {
+		return nil, err
+	}
+	return dto.ToCourseResponse(course), nil
+}
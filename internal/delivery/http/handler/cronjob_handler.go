@@ -8,6 +8,7 @@ import (
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -30,6 +31,7 @@ func NewCronJobHandler(uc usecase.CronJobUsecase) *CronJobHandler {
 // @Param request body dto.CreateCronJobRequest true "CronJob Request"
 // @Success 201 {object} dto.CronJobResponse
 // @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
 // @Failure 500 {object} interface{}
 // @Router /cronjobs [post]
 func (h *CronJobHandler) CreateCronJob(c *fiber.Ctx) error {
@@ -38,8 +40,8 @@ func (h *CronJobHandler) CreateCronJob(c *fiber.Ctx) error {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
 	}
 
-	if req.Name == "" || len(req.CourseCodes) == 0 || req.CronExpr == "" {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Missing required fields: name, course_codes, cron_expr")
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -110,6 +112,7 @@ func (h *CronJobHandler) GetCronJob(c *fiber.Ctx) error {
 // @Param request body dto.UpdateCronJobRequest true "CronJob Update Request"
 // @Success 200 {object} dto.CronJobResponse
 // @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
 // @Failure 500 {object} interface{}
 // @Router /cronjobs/{id} [put]
 func (h *CronJobHandler) UpdateCronJob(c *fiber.Ctx) error {
@@ -120,8 +123,8 @@ func (h *CronJobHandler) UpdateCronJob(c *fiber.Ctx) error {
 		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
 	}
 
-	if req.Name == "" || len(req.CourseCodes) == 0 || req.CronExpr == "" {
-		return response.BadRequest(adapter.NewFiberResponder(c), "Missing required fields: name, course_codes, cron_expr")
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -177,3 +180,12 @@ func (h *CronJobHandler) TriggerCronJob(c *fiber.Ctx) error {
 
 	return response.OK(adapter.NewFiberResponder(c), map[string]string{"message": "Cron job triggered"})
 }
+
+// AuditSnapshot returns the cron job with the given ID, or nil.
+func (h *CronJobHandler) AuditSnapshot(ctx context.Context, id string) (interface{}, error) {
+	job, err := h.usecase.GetCronJobByID(ctx, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return dto.ToCronJobResponse(job), nil
+}
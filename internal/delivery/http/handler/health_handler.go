@@ -20,7 +20,7 @@ func NewHealthHandler(uc usecase.HealthUsecase) *HealthHandler {
 
 // GetStatus returns the current health status of the service.
 // @Summary Check service health
-// @Description Get the current health status of the service
+// @Description Get the current health status of the service. The status is "degraded" while the course API circuit breaker is open or half-open, or its gRPC connection is failing.
 // @Tags health
 // @Accept json
 // @Produce json
@@ -31,3 +31,21 @@ func (h *HealthHandler) GetStatus(c *fiber.Ctx) error {
 	res := dto.ToHealthResponse(status)
 	return response.OK(adapter.NewFiberResponder(c), res)
 }
+
+// GetReadiness reports whether the service should receive traffic.
+// @Summary Check service readiness
+// @Description Returns 200 while the service is ready and 503 while the course API circuit breaker is open or its gRPC connection is failing. The body is the same health report as /status.
+// @Tags health
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Failure 503 {object} dto.HealthResponse
+// @Router /ready [get]
+func (h *HealthHandler) GetReadiness(c *fiber.Ctx) error {
+	status := h.usecase.GetStatus()
+	res := dto.ToHealthResponse(status)
+	if !status.Ready {
+		return response.ServiceUnavailableWithData(adapter.NewFiberResponder(c), "service not ready", res)
+	}
+	return response.OK(adapter.NewFiberResponder(c), res)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// JWKSHandler publishes the public keys that verify our access tokens.
+type JWKSHandler struct {
+	keys *jwtkeys.KeySet
+}
+
+// NewJWKSHandler creates a new JWKSHandler instance.
+func NewJWKSHandler(keys *jwtkeys.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// GetJWKS serves the JSON Web Key Set at /.well-known/jwks.json.
+// The body is a bare JWKS document (RFC 7517), not the usual response
+// envelope, so standard JWT libraries can consume it directly. It sits
+// outside /api/v1 and is therefore not part of the Swagger spec.
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return adapter.NewFiberResponder(c).Status(response.StatusOK).JSON(h.keys.JWKS())
+}
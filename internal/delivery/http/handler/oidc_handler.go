@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// oidcStateCookie binds a pending SSO login to the browser that started it,
+// so a callback URL planted in someone else's browser cannot sign them in.
+const oidcStateCookie = "oidc_state"
+
+// OIDCHandler handles single sign-on through an OpenID Connect provider.
+type OIDCHandler struct {
+	usecase  usecase.OIDCUsecase
+	stateTTL time.Duration
+}
+
+// NewOIDCHandler creates a new OIDCHandler instance. stateTTL is the
+// lifetime of the state cookie and should match the usecase's StateTTL.
+func NewOIDCHandler(uc usecase.OIDCUsecase, stateTTL time.Duration) *OIDCHandler {
+	if stateTTL <= 0 {
+		stateTTL = usecase.DefaultOIDCStateTTL
+	}
+	return &OIDCHandler{usecase: uc, stateTTL: stateTTL}
+}
+
+// Login starts an SSO login by redirecting to the identity provider.
+// @Summary Start SSO login
+// @Description Redirects the browser to the university identity provider (OpenID Connect authorization code flow with PKCE).
+// @Tags auth
+// @Success 302
+// @Failure 500 {object} interface{}
+// @Router /auth/oidc/login [get]
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authURL, state, err := h.usecase.BeginLogin(ctx)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     strings.TrimSuffix(c.Path(), "/login"),
+		MaxAge:   int(h.stateTTL.Seconds()),
+		Secure:   c.Protocol() == "https",
+		HTTPOnly: true,
+		// Lax lets the cookie through on the provider's top-level redirect back.
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback completes an SSO login and returns our own token pair.
+// @Summary SSO callback
+// @Description Redirect target for the identity provider. Exchanges the authorization code, provisions the user on first login and returns access and refresh tokens.
+// @Tags auth
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "Login state"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 409 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	cookie := c.Cookies(oidcStateCookie)
+	c.ClearCookie(oidcStateCookie)
+
+	if c.Query("error") != "" {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Sign-in was cancelled or refused by the identity provider")
+	}
+
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Missing code or state")
+	}
+	if subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Login state does not match this browser; start again")
+	}
+
+	// The token exchange and key fetch are remote calls, so allow longer.
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	tokens, err := h.usecase.CompleteLogin(ctx, state, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidOIDCState):
+			return response.BadRequest(adapter.NewFiberResponder(c), "Login expired or already completed; start again")
+		case errors.Is(err, repository.ErrIdentityRejected):
+			return response.Unauthorized(adapter.NewFiberResponder(c), "Sign-in could not be verified")
+		case errors.Is(err, usecase.ErrOIDCRoleDenied), errors.Is(err, usecase.ErrMissingUsername):
+			return response.Forbidden(adapter.NewFiberResponder(c), err.Error())
+		case errors.Is(err, usecase.ErrUserDisabled):
+			return response.Forbidden(adapter.NewFiberResponder(c), "Account is disabled")
+		case errors.Is(err, usecase.ErrUsernameExists):
+			return response.Conflict(adapter.NewFiberResponder(c), "A local account with this username already exists; ask an administrator to link it")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToLoginResponse(tokens))
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PasswordResetHandler handles admin-initiated password resets.
+type PasswordResetHandler struct {
+	usecase usecase.PasswordResetUsecase
+}
+
+// NewPasswordResetHandler creates a new PasswordResetHandler instance.
+func NewPasswordResetHandler(uc usecase.PasswordResetUsecase) *PasswordResetHandler {
+	return &PasswordResetHandler{usecase: uc}
+}
+
+// IssueReset sends a user a single-use password reset token (admin-only).
+// @Summary Issue a password reset (admin)
+// @Description Send the user a single-use, expiring password reset token through the configured notifier. Any earlier token for the user stops working. The token is never returned by the API. Requires the user:manage permission.
+// @Tags auth
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.PasswordResetIssuedResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 409 {object} interface{} "The account signs in through single sign-on"
+// @Failure 500 {object} interface{}
+// @Router /auth/users/{id}/password-reset [post]
+func (h *PasswordResetHandler) IssueReset(c *fiber.Ctx) error {
+	callerID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	expiresAt, err := h.usecase.IssueReset(ctx, callerID, c.Params("id"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrExternalAccount) {
+			return response.Conflict(adapter.NewFiberResponder(c), err.Error())
+		}
+		return userError(c, err)
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), &dto.PasswordResetIssuedResponse{ExpiresAt: expiresAt})
+}
+
+// ResetPassword sets a new password using a reset token (public).
+// @Summary Reset password with a token
+// @Description Set a new password using a token issued by an administrator. The token works once; the user is signed out everywhere. A password rejected by the policy leaves the token usable.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Password Reset"
+// @Success 204
+// @Failure 400 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /auth/password-reset [post]
+func (h *PasswordResetHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidResetToken):
+			return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+		case errors.Is(err, usecase.ErrWeakPassword):
+			return weakPasswordError(c, "new_password", err)
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
+}
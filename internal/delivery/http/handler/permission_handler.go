@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PermissionHandler handles HTTP requests for role→permission bindings.
+type PermissionHandler struct {
+	usecase usecase.PermissionUsecase
+}
+
+// NewPermissionHandler creates a new PermissionHandler instance.
+func NewPermissionHandler(uc usecase.PermissionUsecase) *PermissionHandler {
+	return &PermissionHandler{usecase: uc}
+}
+
+// GetPermissions lists every permission and the permissions each role grants.
+// @Summary List permissions and role bindings
+// @Description List every named permission and the permissions granted by each role. Requires the role:manage permission.
+// @Tags permissions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.PermissionsResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /permissions [get]
+func (h *PermissionHandler) GetPermissions(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	roles, err := h.usecase.GetRolePermissions(ctx)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToPermissionsResponse(constants.AllPermissions, roles))
+}
+
+// UpdateRolePermissions replaces the permissions granted by a role.
+// @Summary Update a role's permissions
+// @Description Replace the permissions granted by a role. Superadmin always holds every permission and cannot be edited. Requires the role:manage permission.
+// @Tags permissions
+// @Accept json
+// @Produce json
+// @Param role path string true "Role" Enums(admin, student)
+// @Param request body dto.UpdateRolePermissionsRequest true "Permissions"
+// @Security BearerAuth
+// @Success 200 {object} dto.RolePermissionsResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /permissions/roles/{role} [put]
+func (h *PermissionHandler) UpdateRolePermissions(c *fiber.Ctx) error {
+	callerID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.UpdateRolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rp, err := h.usecase.SetRolePermissions(ctx, c.Params("role"), req.Permissions, callerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidRole), errors.Is(err, usecase.ErrUnknownPermission):
+			return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+		case errors.Is(err, usecase.ErrSuperAdminPermissions):
+			return response.Forbidden(adapter.NewFiberResponder(c), err.Error())
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToRolePermissionsResponse(rp))
+}
+
+// AuditSnapshot returns the effective permissions of the given role, or nil.
+func (h *PermissionHandler) AuditSnapshot(ctx context.Context, role string) (interface{}, error) {
+	roles, err := h.usecase.GetRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rp := range roles {
+		if rp.Role == role {
+			return dto.ToRolePermissionsResponse(rp), nil
+		}
+	}
+	return nil, nil
+}
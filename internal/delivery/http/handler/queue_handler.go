@@ -2,6 +2,8 @@ package handler
 
 import (
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/queue"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
 	"github.com/gofiber/fiber/v2"
@@ -9,21 +11,31 @@ import (
 
 // QueueHandler handles HTTP requests for queue status.
 type QueueHandler struct {
-	queue *queue.RefreshQueue
+	queue     *queue.RefreshQueue
+	courseAPI func() entity.UpstreamHealth
 }
 
 // NewQueueHandler creates a new QueueHandler instance.
-func NewQueueHandler(q *queue.RefreshQueue) *QueueHandler {
-	return &QueueHandler{queue: q}
+// courseAPI may be nil, in which case the course API is not reported.
+func NewQueueHandler(q *queue.RefreshQueue, courseAPI func() entity.UpstreamHealth) *QueueHandler {
+	return &QueueHandler{queue: q, courseAPI: courseAPI}
 }
 
 // GetStatus returns the current refresh queue status.
 // @Summary Get queue status
-// @Description Returns pending, processed, dropped counts and capacity
+// @Description Returns pending, processed, dropped counts and capacity, and the circuit breaker state of the course API. Requires the queue:read permission.
 // @Tags queue
 // @Produce json
-// @Success 200 {object} queue.QueueStatus
+// @Security BearerAuth
+// @Success 200 {object} dto.QueueStatusResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
 // @Router /queue/status [get]
 func (h *QueueHandler) GetStatus(c *fiber.Ctx) error {
-	return response.OK(adapter.NewFiberResponder(c), h.queue.Status())
+	res := dto.QueueStatusResponse{QueueStatus: h.queue.Status()}
+	if h.courseAPI != nil {
+		upstream := h.courseAPI()
+		res.CourseAPI = dto.ToUpstreamHealthResponse(&upstream)
+	}
+	return response.OK(adapter.NewFiberResponder(c), res)
 }
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WatchHandler handles HTTP requests for the caller's course watches.
+type WatchHandler struct {
+	usecase usecase.WatchUsecase
+}
+
+// NewWatchHandler creates a new WatchHandler instance.
+func NewWatchHandler(uc usecase.WatchUsecase) *WatchHandler {
+	return &WatchHandler{usecase: uc}
+}
+
+// CreateWatch subscribes the caller to a course or section.
+// @Summary Watch a course or section
+// @Description Be notified when a refresh finds changes in a course, or only in one of its sections when section_id is set (schedule, room, instructor, seats, exams, or the section being closed). IDs are those returned by GET /courses/{code}.
+// @Tags watches
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWatchRequest true "Watch"
+// @Security BearerAuth
+// @Success 201 {object} dto.WatchResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 409 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /watches [post]
+func (h *WatchHandler) CreateWatch(c *fiber.Ctx) error {
+	userID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.CreateWatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watch, err := h.usecase.Create(ctx, userID, req.CourseID, req.SectionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrCourseNotFound):
+			return response.NotFound(adapter.NewFiberResponder(c), "Course not found")
+		case errors.Is(err, usecase.ErrSectionNotFound):
+			return response.NotFound(adapter.NewFiberResponder(c), "Section not found")
+		case errors.Is(err, usecase.ErrWatchExists):
+			return response.Conflict(adapter.NewFiberResponder(c), err.Error())
+		case errors.Is(err, usecase.ErrWatchLimit):
+			return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.Created(adapter.NewFiberResponder(c), dto.ToWatchResponse(watch))
+}
+
+// GetWatches lists the caller's watches.
+// @Summary List my watches
+// @Description List the courses and sections the authenticated user watches, oldest first.
+// @Tags watches
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.WatchResponse
+// @Failure 401 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /watches [get]
+func (h *WatchHandler) GetWatches(c *fiber.Ctx) error {
+	userID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watches, err := h.usecase.List(ctx, userID)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToWatchResponses(watches))
+}
+
+// DeleteWatch stops one of the caller's watches.
+// @Summary Stop watching
+// @Description Delete one of the authenticated user's watches.
+// @Tags watches
+// @Param id path string true "Watch ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /watches/{id} [delete]
+func (h *WatchHandler) DeleteWatch(c *fiber.Ctx) error {
+	userID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.Delete(ctx, userID, c.Params("id")); err != nil {
+		if errors.Is(err, usecase.ErrWatchNotFound) {
+			return response.NotFound(adapter.NewFiberResponder(c), "Watch not found")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
+}
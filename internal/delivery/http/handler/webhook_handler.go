@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookHandler handles HTTP requests for outbound webhooks.
+type WebhookHandler struct {
+	usecase usecase.WebhookUsecase
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance.
+func NewWebhookHandler(uc usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{usecase: uc}
+}
+
+// CreateWebhook subscribes a URL to events.
+// @Summary Create a webhook
+// @Description Subscribe a URL to course and cron job events. Each delivery is a JSON POST signed with HMAC-SHA256 of the body in the X-Signature-256 header ("sha256=<hex>"). Failed deliveries are retried with exponential backoff. The secret is generated when omitted and is returned only once. Requires the webhook:manage permission.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWebhookRequest true "Webhook"
+// @Security BearerAuth
+// @Success 201 {object} dto.CreateWebhookResponse
+// @Failure 400 {object} interface{}
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 422 {object} response.Body{data=[]validation.FieldError} "Field-level validation errors"
+// @Failure 500 {object} interface{}
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	callerID, _, _, ok := callerClaims(c)
+	if !ok {
+		return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(adapter.NewFiberResponder(c), "Invalid request body")
+	}
+
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return response.ValidationError(adapter.NewFiberResponder(c), errs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook := req.ToEntity(callerID)
+	if err := h.usecase.Create(ctx, webhook); err != nil {
+		if errors.Is(err, usecase.ErrInvalidWebhookURL) || errors.Is(err, usecase.ErrUnknownWebhookEvent) {
+			return response.BadRequest(adapter.NewFiberResponder(c), err.Error())
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.Created(adapter.NewFiberResponder(c), &dto.CreateWebhookResponse{
+		WebhookResponse: dto.ToWebhookResponse(webhook),
+		Secret:          webhook.Secret,
+	})
+}
+
+// GetWebhooks lists webhooks.
+// @Summary List webhooks
+// @Description List every webhook, oldest first. Secrets are never returned. Requires the webhook:manage permission.
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.WebhookResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhooks, err := h.usecase.List(ctx)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c), dto.ToWebhookResponses(webhooks))
+}
+
+// DeleteWebhook removes a webhook.
+// @Summary Delete a webhook
+// @Description Delete a webhook. Its pending deliveries are marked failed when next due. Requires the webhook:manage permission.
+// @Tags webhooks
+// @Param id path string true "Webhook ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.usecase.Delete(ctx, c.Params("id")); err != nil {
+		if errors.Is(err, usecase.ErrWebhookNotFound) {
+			return response.NotFound(adapter.NewFiberResponder(c), "Webhook not found")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.NoContent(adapter.NewFiberResponder(c))
+}
+
+// GetWebhookDeliveries lists webhook deliveries, newest first.
+// @Summary List webhook deliveries (paginated)
+// @Description List deliveries with their payload, status and last attempt, newest first. Requires the webhook:manage permission. Use limit=0 to fetch all.
+// @Tags webhooks
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10, 0=all)"
+// @Param webhook_id query string false "Only deliveries to this webhook"
+// @Param event query string false "Only this event type" Enums(course.created, course.updated, course.deleted, cronjob.run.completed)
+// @Param status query string false "Only this status" Enums(pending, succeeded, failed)
+// @Security BearerAuth
+// @Success 200 {array} dto.WebhookDeliveryResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /webhooks/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	pq := pagination.FromQuery(page, limit)
+
+	filter := repository.WebhookDeliveryFilter{
+		WebhookID: c.Query("webhook_id"),
+		Event:     c.Query("event"),
+		Status:    entity.WebhookDeliveryStatus(c.Query("status")),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := h.usecase.ListDeliveries(ctx, filter, pq)
+	if err != nil {
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.OK(adapter.NewFiberResponder(c),
+		dto.ToWebhookDeliveryResponses(result.Items),
+		result.GetMeta(),
+	)
+}
+
+// ReplayWebhookDelivery sends a delivery's payload again.
+// @Summary Replay a webhook delivery
+// @Description Queue a new delivery of the same payload to the same webhook, whatever the original's status. The payload keeps its event ID so receivers can recognise the replay. Requires the webhook:manage permission.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Security BearerAuth
+// @Success 201 {object} dto.WebhookDeliveryResponse
+// @Failure 401 {object} interface{}
+// @Failure 403 {object} interface{}
+// @Failure 404 {object} interface{}
+// @Failure 500 {object} interface{}
+// @Router /webhooks/deliveries/{id}/replay [post]
+func (h *WebhookHandler) ReplayWebhookDelivery(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	delivery, err := h.usecase.Replay(ctx, c.Params("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrWebhookDeliveryNotFound):
+			return response.NotFound(adapter.NewFiberResponder(c), "Webhook delivery not found")
+		case errors.Is(err, usecase.ErrWebhookNotFound):
+			return response.NotFound(adapter.NewFiberResponder(c), "Webhook no longer exists")
+		}
+		return response.InternalError(adapter.NewFiberResponder(c), err.Error())
+	}
+
+	return response.Created(adapter.NewFiberResponder(c), dto.ToWebhookDeliveryResponse(delivery))
+}
+
+// AuditSnapshot returns the webhook with the given ID, or nil. The secret
+// is never part of the snapshot.
+func (h *WebhookHandler) AuditSnapshot(ctx context.Context, id string) (interface{}, error) {
+	webhook, err := h.usecase.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrWebhookNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dto.ToWebhookResponse(webhook), nil
+}
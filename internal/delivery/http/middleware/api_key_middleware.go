@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// APIKeyHeader carries a service API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves a plaintext API key.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*entity.APIKey, error)
+}
+
+// APIKeyAuth returns a Fiber middleware that authenticates requests carrying
+// an X-API-Key header and hands every other request to fallback (normally
+// JWTAuth). For a valid key it stores claims in c.Locals("user") shaped like
+// a JWT's: "sub" is "apikey:<id>" and "scopes" lists the key's permissions,
+// which RequirePermission checks in place of a role.
+func APIKeyAuth(keys APIKeyAuthenticator, fallback fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		raw := c.Get(APIKeyHeader)
+		if raw == "" {
+			return fallback(c)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		key, err := keys.Authenticate(ctx, raw)
+		if err != nil {
+			return response.Unauthorized(adapter.NewFiberResponder(c), "Invalid or expired API key")
+		}
+
+		c.Locals("user", jwt.MapClaims{
+			"sub":    "apikey:" + key.ID,
+			"name":   key.Name,
+			"scopes": key.Scopes,
+		})
+		return c.Next()
+	}
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AuditRecorder appends entries to the audit log.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *entity.AuditEntry) error
+}
+
+// AuditTarget describes the resource an audited route changes.
+type AuditTarget struct {
+	Type string
+	// ID returns the target's ID. It is called with nil data before the
+	// handler runs and, if that yields "", again with the response data,
+	// which is how creations learn the new ID.
+	ID func(c *fiber.Ctx, data map[string]interface{}) string
+	// Load returns the target's current state, or nil if it does not
+	// exist. It provides the before and after snapshots; when nil, the
+	// response data is used as the after snapshot instead.
+	Load func(ctx context.Context, id string) (interface{}, error)
+}
+
+// IDFromParam returns an AuditTarget.ID that reads the route parameter
+// param, falling back to the response data field for creations.
+func IDFromParam(param, field string) func(*fiber.Ctx, map[string]interface{}) string {
+	return func(c *fiber.Ctx, data map[string]interface{}) string {
+		if id := c.Params(param); id != "" {
+			return id
+		}
+		if v, ok := data[field]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+}
+
+// Audit returns a Fiber middleware that records successful (2xx) requests
+// to the audit log with the acting user, client IP and before/after
+// snapshots of target. Place it after RequirePermission so rejected
+// requests are not recorded. Failing to record is logged, never returned:
+// the change has already happened.
+func Audit(rec AuditRecorder, action string, target AuditTarget) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := target.ID(c, nil)
+		var before map[string]interface{}
+		if id != "" && target.Load != nil {
+			before = loadSnapshot(target, id)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if status := c.Response().StatusCode(); status < 200 || status >= 300 {
+			return nil
+		}
+
+		data := responseData(c.Response().Body())
+		if id == "" {
+			id = target.ID(c, data)
+		}
+		var after map[string]interface{}
+		if c.Method() != fiber.MethodDelete {
+			if target.Load != nil {
+				after = loadSnapshot(target, id)
+			} else {
+				after = data
+			}
+		}
+
+		actorID, actorName := auditActor(c)
+		entry := &entity.AuditEntry{
+			ActorID:    actorID,
+			ActorName:  actorName,
+			Action:     action,
+			TargetType: target.Type,
+			TargetID:   id,
+			Before:     before,
+			After:      after,
+			IP:         c.IP(),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := rec.Record(ctx, entry); err != nil {
+			log.Printf("[audit] failed to record %s on %s %s by %s: %v", action, target.Type, id, actorID, err)
+		}
+		return nil
+	}
+}
+
+// auditActor returns the caller's ID and display name from the claims set
+// by JWTAuth or APIKeyAuth.
+func auditActor(c *fiber.Ctx) (id, name string) {
+	claims, ok := c.Locals("user").(jwt.MapClaims)
+	if !ok {
+		return "", ""
+	}
+	id, _ = claims["sub"].(string)
+	if name, _ = claims["username"].(string); name == "" {
+		name, _ = claims["name"].(string)
+	}
+	return id, name
+}
+
+func loadSnapshot(target AuditTarget, id string) map[string]interface{} {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	v, err := target.Load(ctx, id)
+	if err != nil {
+		log.Printf("[audit] failed to snapshot %s %s: %v", target.Type, id, err)
+		return nil
+	}
+	return toSnapshot(v)
+}
+
+// toSnapshot converts a response DTO to its JSON object form.
+func toSnapshot(v interface{}) map[string]interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// responseData extracts the payload object from a success envelope,
+// {"success":true,"data":{"data":{...}}}.
+func responseData(body []byte) map[string]interface{} {
+	var envelope struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil
+	}
+	return envelope.Data.Data
+}
@@ -1,18 +1,33 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
+// SessionValidator reports whether the user and session behind a valid
+// token are still active. It is consulted on every request so that disabling
+// a user or revoking a session takes effect immediately. It returns
+// usecase.ErrSessionRevoked or usecase.ErrUserDisabled for a token that is
+// no longer valid; any other error means the check itself failed.
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, userID, sessionID string) error
+}
+
 // JWTAuth returns a Fiber middleware that validates JWT tokens from the
 // Authorization header (Bearer scheme). On success it stores the parsed
 // claims in c.Locals("user") for downstream handlers/middleware.
-func JWTAuth(secret string) fiber.Handler {
+// A nil sessions skips the revocation check.
+func JWTAuth(keys *jwtkeys.KeySet, sessions SessionValidator) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -26,20 +41,27 @@ func JWTAuth(secret string) fiber.Handler {
 
 		tokenStr := parts[1]
 
-		token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-			// Ensure signing method is HMAC
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
+		// Resolves the key by kid and rejects any alg the key set doesn't use.
+		claims, err := keys.Parse(tokenStr)
+		if err != nil {
 			return response.Unauthorized(adapter.NewFiberResponder(c), "Invalid or expired token")
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return response.Unauthorized(adapter.NewFiberResponder(c), "Invalid token claims")
+		if sessions != nil {
+			sub, _ := claims["sub"].(string)
+			sid, _ := claims["sid"].(string)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := sessions.ValidateSession(ctx, sub, sid); err != nil {
+				if errors.Is(err, usecase.ErrSessionRevoked) || errors.Is(err, usecase.ErrUserDisabled) {
+					return response.Unauthorized(adapter.NewFiberResponder(c), "Token has been revoked")
+				}
+				// A database failure must not tell clients to drop their tokens.
+				log.Printf("[auth] failed to validate session %s: %v", sid, err)
+				return response.InternalError(adapter.NewFiberResponder(c), "Failed to validate session")
+			}
 		}
 
 		// Store claims for downstream use
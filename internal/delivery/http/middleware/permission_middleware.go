@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/adapter"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PermissionChecker resolves whether a role grants a permission. Lookups
+// are expected to be cached; the checker is consulted on every request.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role, perm string) (bool, error)
+}
+
+// RequirePermission returns a Fiber middleware that checks whether the
+// authenticated user's role grants every one of perms. API keys are checked
+// against their own "scopes" claim instead of a role.
+// It expects c.Locals("user") to contain jwt.MapClaims (set by JWTAuth or APIKeyAuth).
+func RequirePermission(checker PermissionChecker, perms ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("user").(jwt.MapClaims)
+		if !ok {
+			return response.Unauthorized(adapter.NewFiberResponder(c), "Authentication required")
+		}
+
+		if scopes, ok := claims["scopes"].([]string); ok {
+			for _, perm := range perms {
+				if !containsString(scopes, perm) {
+					return response.Forbidden(adapter.NewFiberResponder(c), "Insufficient permissions")
+				}
+			}
+			return c.Next()
+		}
+
+		role, _ := claims["role"].(string)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		for _, perm := range perms {
+			allowed, err := checker.HasPermission(ctx, role, perm)
+			if err != nil {
+				return response.InternalError(adapter.NewFiberResponder(c), "Failed to resolve permissions")
+			}
+			if !allowed {
+				return response.Forbidden(adapter.NewFiberResponder(c), "Insufficient permissions")
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
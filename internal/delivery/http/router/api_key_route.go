@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/middleware"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterAPIKeyRoutes registers API key management routes (apikey:manage).
+func RegisterAPIKeyRoutes(api fiber.Router, apiKeyH *handler.APIKeyHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker, audit middleware.AuditRecorder) {
+	keys := api.Group("/api-keys", requireAuth, middleware.RequirePermission(perms, constants.PermAPIKeyManage))
+	target := middleware.AuditTarget{Type: constants.AuditTargetAPIKey, ID: middleware.IDFromParam("id", "id"), Load: apiKeyH.AuditSnapshot}
+	keys.Post("", middleware.Audit(audit, constants.AuditAPIKeyCreate, target), apiKeyH.CreateAPIKey)
+	keys.Get("", apiKeyH.GetAPIKeys)
+	keys.Delete("/:id", middleware.Audit(audit, constants.AuditAPIKeyRevoke, target), apiKeyH.RevokeAPIKey)
+}
@@ -0,0 +1,13 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/middleware"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterAuditRoutes registers audit log routes (audit:read).
+func RegisterAuditRoutes(api fiber.Router, auditH *handler.AuditHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker) {
+	api.Get("/audit", requireAuth, middleware.RequirePermission(perms, constants.PermAuditRead), auditH.GetAuditLog)
+}
@@ -8,12 +8,27 @@ import (
 )
 
 // RegisterAuthRoutes registers authentication routes.
-func RegisterAuthRoutes(api fiber.Router, authH *handler.AuthHandler, jwtSecret string) {
+func RegisterAuthRoutes(api fiber.Router, authH *handler.AuthHandler, resetH *handler.PasswordResetHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker, audit middleware.AuditRecorder) {
 	auth := api.Group("/auth")
 	auth.Post("/register", authH.Register)
 	auth.Post("/login", authH.Login)
+	auth.Post("/refresh", authH.Refresh)
+	auth.Post("/logout", authH.Logout)
+	auth.Post("/password-reset", resetH.ResetPassword)
 
-	// Protected: create/list users (superadmin/admin only)
-	auth.Post("/users", middleware.JWTAuth(jwtSecret), middleware.RequireRole(constants.RoleSuperAdmin, constants.RoleAdmin), authH.CreateUser)
-	auth.Get("/users", middleware.JWTAuth(jwtSecret), middleware.RequireRole(constants.RoleSuperAdmin, constants.RoleAdmin), authH.GetUsers)
+	// Protected: own profile (any authenticated user)
+	auth.Get("/me", requireAuth, authH.GetMe)
+	auth.Patch("/me", requireAuth, authH.UpdateMe)
+	auth.Post("/me/password", requireAuth, authH.ChangePassword)
+
+	// Protected: user management
+	target := middleware.AuditTarget{Type: constants.AuditTargetUser, ID: middleware.IDFromParam("id", "id"), Load: authH.AuditSnapshot}
+	users := auth.Group("/users", requireAuth, middleware.RequirePermission(perms, constants.PermUserManage))
+	users.Post("", middleware.Audit(audit, constants.AuditUserCreate, target), authH.CreateUser)
+	users.Get("", authH.GetUsers)
+	users.Patch("/:id/role", middleware.Audit(audit, constants.AuditUserRoleChange, target), authH.ChangeUserRole)
+	users.Post("/:id/disable", middleware.Audit(audit, constants.AuditUserDisable, target), authH.DisableUser)
+	users.Post("/:id/enable", middleware.Audit(audit, constants.AuditUserEnable, target), authH.EnableUser)
+	users.Delete("/:id", middleware.Audit(audit, constants.AuditUserDelete, target), authH.DeleteUser)
+	users.Post("/:id/password-reset", middleware.Audit(audit, constants.AuditUserPasswordReset, target), resetH.IssueReset)
 }
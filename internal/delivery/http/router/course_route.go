@@ -8,18 +8,24 @@ import (
 )
 
 // RegisterCourseRoutes registers course routes.
-func RegisterCourseRoutes(api fiber.Router, courseH *handler.CourseHandler, queueH *handler.QueueHandler, jwtSecret string) {
+func RegisterCourseRoutes(api fiber.Router, courseH *handler.CourseHandler, queueH *handler.QueueHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker, audit middleware.AuditRecorder) {
 	courses := api.Group("/courses")
+	target := middleware.AuditTarget{Type: constants.AuditTargetCourse, ID: courseH.AuditID, Load: courseH.AuditSnapshot}
 
 	// Public: read-only
 	courses.Get("/", courseH.GetCourses)
 	courses.Get("/:code", courseH.GetCourse)
+	courses.Get("/:code/changes", courseH.GetCourseChanges)
+	courses.Get("/:code/versions", courseH.GetCourseVersions)
 
-	// Protected: superadmin and admin
-	adminCourses := courses.Group("", middleware.JWTAuth(jwtSecret), middleware.RequireRole(constants.RoleSuperAdmin, constants.RoleAdmin))
-	adminCourses.Post("/", courseH.CreateCourse)
-	adminCourses.Delete("/:code", courseH.DeleteCourse)
+	// Protected: course:write
+	adminCourses := courses.Group("", requireAuth, middleware.RequirePermission(perms, constants.PermCourseWrite))
+	adminCourses.Post("/", middleware.Audit(audit, constants.AuditCourseCreate, target), courseH.CreateCourse)
+	adminCourses.Delete("/:code", middleware.Audit(audit, constants.AuditCourseDelete, target), courseH.DeleteCourse)
+
+	// Normalisation report: raw schedule values with no canonical mapping
+	api.Get("/normalization/unmapped", requireAuth, middleware.RequirePermission(perms, constants.PermNormalizationRead), courseH.GetUnmappedValues)
 
 	// Queue status
-	api.Get("/queue/status", queueH.GetStatus)
+	api.Get("/queue/status", requireAuth, middleware.RequirePermission(perms, constants.PermQueueRead), queueH.GetStatus)
 }
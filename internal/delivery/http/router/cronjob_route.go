@@ -7,13 +7,18 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-// RegisterCronJobRoutes registers cron job routes (admin only).
-func RegisterCronJobRoutes(api fiber.Router, cronJobH *handler.CronJobHandler, jwtSecret string) {
-	cronjobs := api.Group("/cronjobs", middleware.JWTAuth(jwtSecret), middleware.RequireRole(constants.RoleSuperAdmin, constants.RoleAdmin))
-	cronjobs.Post("/", cronJobH.CreateCronJob)
-	cronjobs.Get("/", cronJobH.GetCronJobs)
-	cronjobs.Get("/:id", cronJobH.GetCronJob)
-	cronjobs.Put("/:id", cronJobH.UpdateCronJob)
-	cronjobs.Delete("/:id", cronJobH.DeleteCronJob)
-	cronjobs.Post("/:id/trigger", cronJobH.TriggerCronJob)
+// RegisterCronJobRoutes registers cron job routes.
+func RegisterCronJobRoutes(api fiber.Router, cronJobH *handler.CronJobHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker, audit middleware.AuditRecorder) {
+	read := middleware.RequirePermission(perms, constants.PermCronJobRead)
+	write := middleware.RequirePermission(perms, constants.PermCronJobWrite)
+	trigger := middleware.RequirePermission(perms, constants.PermCronJobTrigger)
+	target := middleware.AuditTarget{Type: constants.AuditTargetCronJob, ID: middleware.IDFromParam("id", "id"), Load: cronJobH.AuditSnapshot}
+
+	cronjobs := api.Group("/cronjobs", requireAuth)
+	cronjobs.Post("/", write, middleware.Audit(audit, constants.AuditCronJobCreate, target), cronJobH.CreateCronJob)
+	cronjobs.Get("/", read, cronJobH.GetCronJobs)
+	cronjobs.Get("/:id", read, cronJobH.GetCronJob)
+	cronjobs.Put("/:id", write, middleware.Audit(audit, constants.AuditCronJobUpdate, target), cronJobH.UpdateCronJob)
+	cronjobs.Delete("/:id", write, middleware.Audit(audit, constants.AuditCronJobDelete, target), cronJobH.DeleteCronJob)
+	cronjobs.Post("/:id/trigger", trigger, middleware.Audit(audit, constants.AuditCronJobTrigger, target), cronJobH.TriggerCronJob)
 }
@@ -8,5 +8,6 @@ import (
 // RegisterHealthRoutes registers health and version routes.
 func RegisterHealthRoutes(api fiber.Router, healthH *handler.HealthHandler, versionH *handler.VersionHandler) {
 	api.Get("/status", healthH.GetStatus)
+	api.Get("/ready", healthH.GetReadiness)
 	api.Get("/version", versionH.GetVersion)
 }
@@ -0,0 +1,11 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterJWKSRoutes registers the public key discovery endpoint on the app root.
+func RegisterJWKSRoutes(app fiber.Router, jwksH *handler.JWKSHandler) {
+	app.Get("/.well-known/jwks.json", jwksH.GetJWKS)
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterOIDCRoutes registers the single sign-on routes. Both are public:
+// the provider authenticates the user.
+func RegisterOIDCRoutes(api fiber.Router, oidcH *handler.OIDCHandler) {
+	oidc := api.Group("/auth/oidc")
+	oidc.Get("/login", oidcH.Login)
+	oidc.Get("/callback", oidcH.Callback)
+}
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/middleware"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterPermissionRoutes registers role→permission binding routes (role:manage).
+func RegisterPermissionRoutes(api fiber.Router, permH *handler.PermissionHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker, audit middleware.AuditRecorder) {
+	p := api.Group("/permissions", requireAuth, middleware.RequirePermission(perms, constants.PermRoleManage))
+	p.Get("/", permH.GetPermissions)
+	target := middleware.AuditTarget{Type: constants.AuditTargetRolePermissions, ID: middleware.IDFromParam("role", "role"), Load: permH.AuditSnapshot}
+	p.Put("/roles/:role", middleware.Audit(audit, constants.AuditRolePermissionsUpdate, target), permH.UpdateRolePermissions)
+}
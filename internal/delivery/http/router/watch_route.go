@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterWatchRoutes registers the authenticated user's watch routes.
+func RegisterWatchRoutes(api fiber.Router, watchH *handler.WatchHandler, requireAuth fiber.Handler) {
+	watches := api.Group("/watches", requireAuth)
+	watches.Post("", watchH.CreateWatch)
+	watches.Get("", watchH.GetWatches)
+	watches.Delete("/:id", watchH.DeleteWatch)
+}
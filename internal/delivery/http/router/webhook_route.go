@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/middleware"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterWebhookRoutes registers webhook management routes (webhook:manage).
+func RegisterWebhookRoutes(api fiber.Router, webhookH *handler.WebhookHandler, requireAuth fiber.Handler, perms middleware.PermissionChecker, audit middleware.AuditRecorder) {
+	webhooks := api.Group("/webhooks", requireAuth, middleware.RequirePermission(perms, constants.PermWebhookManage))
+	target := middleware.AuditTarget{Type: constants.AuditTargetWebhook, ID: middleware.IDFromParam("id", "id"), Load: webhookH.AuditSnapshot}
+	delivery := middleware.AuditTarget{Type: constants.AuditTargetWebhookDelivery, ID: middleware.IDFromParam("id", "id")}
+
+	// Static paths before /:id.
+	webhooks.Get("/deliveries", webhookH.GetWebhookDeliveries)
+	webhooks.Post("/deliveries/:id/replay", middleware.Audit(audit, constants.AuditWebhookDeliveryReplay, delivery), webhookH.ReplayWebhookDelivery)
+
+	webhooks.Post("", middleware.Audit(audit, constants.AuditWebhookCreate, target), webhookH.CreateWebhook)
+	webhooks.Get("", webhookH.GetWebhooks)
+	webhooks.Delete("/:id", middleware.Audit(audit, constants.AuditWebhookDelete, target), webhookH.DeleteWebhook)
+}
@@ -2,25 +2,35 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/config"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/dto"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/handler"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/middleware"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/delivery/http/router"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/usecase"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/externalapi"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/mongodb"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/notifier"
+	memoryRepo "github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/repository/memory"
 	mongoRepo "github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/repository/mongodb"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/queue"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/resilience"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/scheduler"
 	"github.com/gofiber/fiber/v2"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	mongoDriver "go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 // Start initialises dependencies and starts the HTTP server.
@@ -34,30 +44,38 @@ func Start(cfg *config.Config) {
 	if err != nil {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
-
-	// ---------- gRPC ----------
-	grpcConn, err := grpc.NewClient(cfg.CourseGRPCAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		log.Fatalf("Failed to connect to course gRPC service at %s: %v", cfg.CourseGRPCAddr, err)
+	if err := mongoRepo.RunMigrations(ctx, mongo.Database()); err != nil {
+		log.Fatalf("Failed to run MongoDB migrations: %v", err)
 	}
-	log.Printf("gRPC client connected to %s", cfg.CourseGRPCAddr)
+
+	// ---------- Domain Event Outbox ----------
+	outboxRepo := mongoRepo.NewOutboxRepository(mongo.Database())
+	transactor := mongoRepo.NewTransactor(mongo.Client(), mongoTransactions(ctx, mongo))
+	eventOutbox := usecase.NewEventOutbox(outboxRepo, transactor, dto.EventData)
+
+	// ---------- Course API ----------
+	courseExtAPI, courseAPIHealth, closeCourseSources := courseSources(cfg)
 
 	// ---------- Background Queue ----------
 	refreshQueue := queue.New(100, 5)
 
 	// ========== Fiber ==========
 
+	// Behind a proxy, c.IP() — used by the login throttle and the audit
+	// log — reads the client IP from ProxyHeader on trusted requests only.
 	app := fiber.New(fiber.Config{
-		AppName: cfg.AppName,
+		AppName:                 cfg.AppName,
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableTrustedProxyCheck: cfg.ProxyHeader != "",
+		TrustedProxies:          cfg.TrustedProxies,
+		EnableIPValidation:      true,
 	})
 	middleware.SetupMiddlewares(app)
 	api := app.Group("/api/v1")
 
 	// ========== Module: Health & Version ==========
 
-	healthUC := usecase.NewHealthUsecase()
+	healthUC := usecase.NewHealthUsecase(courseAPIHealth)
 	versionUC := usecase.NewVersionUsecase(cfg.AppName, cfg.AppVersion, cfg.AppEnv)
 	healthH := handler.NewHealthHandler(healthUC)
 	versionH := handler.NewVersionHandler(versionUC)
@@ -65,31 +83,133 @@ func Start(cfg *config.Config) {
 
 	// ========== Module: Auth ==========
 
+	jwtKeys := loadJWTKeys(cfg)
 	userRepo := mongoRepo.NewUserRepository(mongo.Database())
-	authUC := usecase.NewAuthUsecase(userRepo, cfg.JWTSecret)
+	sessionRepo := mongoRepo.NewSessionRepository(mongo.Database())
+	loginThrottle := usecase.NewLoginThrottle(loginAttemptStore(cfg, mongo.Database()), usecase.LoginThrottleConfig{
+		MaxFailures:      cfg.LoginMaxFailures,
+		MaxFailuresPerIP: cfg.LoginMaxFailuresPerIP,
+		Window:           cfg.LoginFailureWindow,
+		BaseLockout:      cfg.LoginLockoutBase,
+		MaxLockout:       cfg.LoginLockoutMax,
+	})
+	permissionUC := usecase.NewPermissionUsecase(mongoRepo.NewRolePermissionRepository(mongo.Database()))
+	passwordPolicy := loadPasswordPolicy(cfg)
+	passwordResetRepo := mongoRepo.NewPasswordResetRepository(mongo.Database())
+	authUC := usecase.NewAuthUsecase(userRepo, sessionRepo, jwtKeys, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, usecase.AuthOptions{
+		Throttle:    loginThrottle,
+		Permissions: permissionUC,
+		Policy:      passwordPolicy,
+		Resets:      passwordResetRepo,
+		Outbox:      eventOutbox,
+	})
 	authH := handler.NewAuthHandler(authUC)
-	router.RegisterAuthRoutes(api, authH, cfg.JWTSecret)
+	apiKeyUC := usecase.NewAPIKeyUsecase(mongoRepo.NewAPIKeyRepository(mongo.Database()), permissionUC)
+	requireAuth := middleware.APIKeyAuth(apiKeyUC, middleware.JWTAuth(jwtKeys, authUC))
+	auditUC := usecase.NewAuditUsecase(mongoRepo.NewAuditRepository(mongo.Database()))
+	passwordResetUC := usecase.NewPasswordResetUsecase(userRepo, sessionRepo, passwordResetRepo,
+		passwordResetNotifier(cfg), passwordPolicy, cfg.PasswordResetTTL)
+	router.RegisterAuthRoutes(api, authH, handler.NewPasswordResetHandler(passwordResetUC), requireAuth, permissionUC, auditUC)
+	router.RegisterPermissionRoutes(api, handler.NewPermissionHandler(permissionUC), requireAuth, permissionUC, auditUC)
+	router.RegisterAPIKeyRoutes(api, handler.NewAPIKeyHandler(apiKeyUC), requireAuth, permissionUC, auditUC)
+	router.RegisterAuditRoutes(api, handler.NewAuditHandler(auditUC), requireAuth, permissionUC)
+	router.RegisterJWKSRoutes(app, handler.NewJWKSHandler(jwtKeys))
+	if cfg.OIDCIssuer != "" {
+		oidcUC := usecase.NewOIDCUsecase(
+			externalapi.NewOIDCProvider(externalapi.OIDCProviderConfig{
+				Issuer:       cfg.OIDCIssuer,
+				ClientID:     cfg.OIDCClientID,
+				ClientSecret: cfg.OIDCClientSecret,
+				RedirectURL:  cfg.OIDCRedirectURL,
+				Scopes:       cfg.OIDCScopes,
+			}),
+			mongoRepo.NewOIDCStateRepository(mongo.Database()),
+			authUC,
+			usecase.OIDCConfig{
+				UsernameClaim: cfg.OIDCUsernameClaim,
+				RoleClaim:     cfg.OIDCRoleClaim,
+				RoleMap:       cfg.OIDCRoleMap,
+				DefaultRole:   cfg.OIDCDefaultRole,
+			},
+		)
+		router.RegisterOIDCRoutes(api, handler.NewOIDCHandler(oidcUC, usecase.DefaultOIDCStateTTL))
+		log.Printf("OIDC single sign-on enabled for issuer %s", cfg.OIDCIssuer)
+	}
 
 	authUC.SeedSuperAdmin(ctx, cfg.SuperAdminUser, cfg.SuperAdminPass)
+	permissionUC.SeedDefaults(ctx)
+
+	// ========== Module: Webhook ==========
+
+	webhookUC := usecase.NewWebhookUsecase(
+		mongoRepo.NewWebhookRepository(mongo.Database()),
+		mongoRepo.NewWebhookDeliveryRepository(mongo.Database()),
+		notifier.NewWebhookSender(cfg.WebhookTimeout),
+		usecase.WebhookRetryPolicy{
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			Backoff:     cfg.WebhookRetryBackoff,
+			MaxBackoff:  cfg.WebhookRetryBackoffMax,
+			// Long enough that a delivery still being sent is not claimed again.
+			Lease: 2 * cfg.WebhookTimeout,
+		},
+	)
+	router.RegisterWebhookRoutes(api, handler.NewWebhookHandler(webhookUC), requireAuth, permissionUC, auditUC)
+	webhookDispatcher := usecase.NewWebhookDispatcher(webhookUC, cfg.WebhookPollInterval)
+	webhookDispatcher.Start()
 
 	// ========== Module: Course ==========
 
 	courseRepo := mongoRepo.NewCourseRepository(mongo.Database())
-	courseExtAPI := externalapi.NewCourseExternalAPI(grpcConn)
-	courseUC := usecase.NewCourseUsecase(courseRepo, courseExtAPI, refreshQueue)
+	unmappedRepo := mongoRepo.NewUnmappedValueRepository(mongo.Database())
+	courseChangeRepo := mongoRepo.NewCourseChangeRepository(mongo.Database())
+	courseVersionRepo := mongoRepo.NewCourseVersionRepository(mongo.Database())
+	watchUC := usecase.NewWatchUsecase(
+		mongoRepo.NewWatchRepository(mongo.Database()),
+		mongoRepo.NewWatchNotificationRepository(mongo.Database()),
+		courseRepo,
+		userRepo,
+		changeNotifier(cfg),
+		usecase.NotificationRetryPolicy{
+			MaxAttempts: cfg.NotifyMaxAttempts,
+			Backoff:     cfg.NotifyRetryBackoff,
+			MaxBackoff:  cfg.NotifyRetryBackoffMax,
+			// Long enough that a notification still being sent is not claimed again.
+			Lease: 2 * max(cfg.SMTPTimeout, cfg.NotifyWebhookTimeout),
+		},
+	)
+	courseUC := usecase.NewCourseUsecase(courseRepo, courseExtAPI, refreshQueue, unmappedRepo, courseChangeRepo, courseVersionRepo, eventOutbox)
 	courseH := handler.NewCourseHandler(courseUC)
-	queueH := handler.NewQueueHandler(refreshQueue)
-	router.RegisterCourseRoutes(api, courseH, queueH, cfg.JWTSecret)
+	queueH := handler.NewQueueHandler(refreshQueue, courseAPIHealth)
+	router.RegisterCourseRoutes(api, courseH, queueH, requireAuth, permissionUC, auditUC)
+	router.RegisterWatchRoutes(api, handler.NewWatchHandler(watchUC), requireAuth)
+	watchNotifier := usecase.NewWatchNotifier(watchUC, cfg.NotifyPollInterval)
+	watchNotifier.Start()
+
+	// Up to 50 pending jobs of the same term share one FetchByCodes call.
+	refreshQueue.StartBatched(50, courseUC.ProcessRefreshBatch)
 
-	refreshQueue.Start(courseUC.ProcessRefreshJob)
+	// ========== Module: Outbox Relay ==========
+
+	outboxRelay := usecase.NewOutboxRelayWorker(usecase.NewOutboxRelay(outboxRepo, eventSinks(cfg, webhookUC, watchUC), usecase.OutboxRetryPolicy{
+		Backoff:    cfg.OutboxRetryBackoff,
+		MaxBackoff: cfg.OutboxRetryBackoffMax,
+	}), cfg.OutboxPollInterval)
+	outboxRelay.Start()
 
 	// ========== Module: CronJob ==========
 
 	cronJobRepo := mongoRepo.NewCronJobRepository(mongo.Database())
 	cronScheduler := scheduler.New(refreshQueue)
-	cronJobUC := usecase.NewCronJobUsecase(cronJobRepo, cronScheduler)
+	cronScheduler.OnRunCompleted(func(run entity.CronJobRun) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := eventOutbox.Record(ctx, constants.WebhookCronJobRunCompleted, run.JobID, &run); err != nil {
+			log.Printf("[cron] failed to record run of job %s: %v", run.JobID, err)
+		}
+	})
+	cronJobUC := usecase.NewCronJobUsecase(cronJobRepo, cronScheduler, eventOutbox)
 	cronJobH := handler.NewCronJobHandler(cronJobUC)
-	router.RegisterCronJobRoutes(api, cronJobH, cfg.JWTSecret)
+	router.RegisterCronJobRoutes(api, cronJobH, requireAuth, permissionUC, auditUC)
 
 	enabledJobs, err := cronJobRepo.GetEnabled(ctx)
 	if err != nil {
@@ -131,14 +251,23 @@ func Start(cfg *config.Config) {
 	// stop background worker (drain remaining jobs)
 	refreshQueue.Stop()
 
+	// stop relaying events; unpublished ones stay in the outbox
+	outboxRelay.Stop()
+
+	// stop webhook deliveries; unsent ones stay queued in MongoDB
+	webhookDispatcher.Stop()
+
+	// stop watch notifications; unsent ones stay queued in MongoDB
+	watchNotifier.Stop()
+
 	// shutdown Fiber
 	if err := app.Shutdown(); err != nil {
 		log.Printf("Fiber shutdown error: %v", err)
 	}
 
-	// close gRPC connection
-	if err := grpcConn.Close(); err != nil {
-		log.Printf("gRPC connection close error: %v", err)
+	// close course API connections
+	if err := closeCourseSources(); err != nil {
+		log.Printf("Course API connection close error: %v", err)
 	}
 
 	// disconnect MongoDB
@@ -148,3 +277,215 @@ func Start(cfg *config.Config) {
 
 	log.Println("Server stopped gracefully")
 }
+
+// loadJWTKeys builds the token key set: asymmetric keys from PEM files when
+// configured, otherwise the shared HS256 secret (development only).
+func loadJWTKeys(cfg *config.Config) *jwtkeys.KeySet {
+	if cfg.JWTSigningKeyFile == "" {
+		log.Println("Warning: JWT_SIGNING_KEY_FILE not set, signing tokens with the shared HS256 secret")
+		return jwtkeys.NewHMAC(cfg.JWTSecret)
+	}
+
+	keys, err := jwtkeys.LoadFiles(cfg.JWTSigningKeyFile, cfg.JWTVerifyKeyFiles...)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	log.Printf("JWT signing key %s loaded (%d keys published in JWKS)", keys.SigningKeyID(), len(keys.JWKS().Keys))
+	return keys
+}
+
+// loadPasswordPolicy builds the password policy, adding the passwords in
+// PASSWORD_BLOCKLIST_FILE (one per line, # for comments) to the built-in list.
+func loadPasswordPolicy(cfg *config.Config) *usecase.PasswordPolicy {
+	policy := &usecase.PasswordPolicy{
+		MinLength:     cfg.PasswordMinLength,
+		RequireUpper:  cfg.PasswordRequireUpper,
+		RequireLower:  cfg.PasswordRequireLower,
+		RequireDigit:  cfg.PasswordRequireDigit,
+		RequireSymbol: cfg.PasswordRequireSymbol,
+	}
+	if cfg.PasswordBlocklistFile == "" {
+		return policy
+	}
+
+	data, err := os.ReadFile(cfg.PasswordBlocklistFile)
+	if err != nil {
+		log.Fatalf("Failed to read PASSWORD_BLOCKLIST_FILE: %v", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			policy.Blocklist = append(policy.Blocklist, line)
+		}
+	}
+	log.Printf("Password blocklist loaded (%d entries)", len(policy.Blocklist))
+	return policy
+}
+
+// mongoTransactions reports whether MongoDB supports transactions, so that
+// outbox events commit atomically with the changes that raise them.
+func mongoTransactions(ctx context.Context, mongo *mongodb.MongoDB) bool {
+	ok, err := mongo.SupportsTransactions(ctx)
+	if err != nil {
+		log.Printf("Warning: could not detect MongoDB transaction support: %v", err)
+		return false
+	}
+	if !ok {
+		log.Println("Warning: MongoDB is a standalone server without transactions; outbox events are written after their changes and may be lost in a crash")
+	}
+	return ok
+}
+
+// eventSinks builds the sinks listed in OUTBOX_SINKS that the outbox relay
+// publishes domain events to, plus the watch sink, which notifies watchers
+// of course changes and is always on.
+func eventSinks(cfg *config.Config, webhooks usecase.WebhookUsecase, watches usecase.WatchUsecase) []repository.EventSink {
+	sinks := make([]repository.EventSink, 0, len(cfg.OutboxSinks)+1)
+	for _, name := range cfg.OutboxSinks {
+		switch name {
+		case "webhook":
+			sinks = append(sinks, webhooks)
+		case "log":
+			sinks = append(sinks, notifier.NewLogEventSink())
+		}
+	}
+	log.Printf("Domain events relayed to %s", strings.Join(cfg.OutboxSinks, ", "))
+	return append(sinks, watches)
+}
+
+// changeNotifier builds the notifier selected by NOTIFIER for watch
+// notifications.
+func changeNotifier(cfg *config.Config) repository.CourseChangeNotifier {
+	switch cfg.Notifier {
+	case "smtp":
+		log.Printf("Watch notifications sent by e-mail through %s:%d", cfg.SMTPHost, cfg.SMTPPort)
+		return notifier.NewSMTPNotifier(smtpConfig(cfg))
+	case "webhook":
+		log.Printf("Watch notifications posted to %s", cfg.NotifyWebhookURL)
+		return notifier.NewWebhookNotifier(cfg.NotifyWebhookURL, cfg.NotifyWebhookSecret, cfg.NotifyWebhookTimeout)
+	default:
+		return notifier.NewLogChangeNotifier()
+	}
+}
+
+// passwordResetNotifier builds the notifier selected by
+// PASSWORD_RESET_NOTIFIER. It shares the SMTP and webhook settings of the
+// watch notifications.
+func passwordResetNotifier(cfg *config.Config) repository.PasswordResetNotifier {
+	switch cfg.PasswordResetNotifier {
+	case "smtp":
+		log.Printf("Password reset links sent by e-mail through %s:%d", cfg.SMTPHost, cfg.SMTPPort)
+		return notifier.NewSMTPPasswordResetNotifier(smtpConfig(cfg), cfg.PasswordResetURL)
+	case "webhook":
+		log.Printf("Password reset links posted to %s", cfg.NotifyWebhookURL)
+		return notifier.NewWebhookPasswordResetNotifier(cfg.NotifyWebhookURL, cfg.NotifyWebhookSecret, cfg.NotifyWebhookTimeout, cfg.PasswordResetURL)
+	default:
+		log.Printf("Warning: password reset links are written to the server log")
+		return notifier.NewLogNotifier(cfg.PasswordResetURL)
+	}
+}
+
+func smtpConfig(cfg *config.Config) notifier.SMTPConfig {
+	return notifier.SMTPConfig{
+		Host:            cfg.SMTPHost,
+		Port:            cfg.SMTPPort,
+		Username:        cfg.SMTPUsername,
+		Password:        cfg.SMTPPassword,
+		From:            cfg.SMTPFrom,
+		RecipientDomain: cfg.SMTPRecipientDomain,
+		Timeout:         cfg.SMTPTimeout,
+	}
+}
+
+// courseSources builds the course sources listed in COURSE_API, highest
+// priority first. Remote sources each get their own circuit breaker; the
+// returned health reports the first of them (nil if there is none), with the
+// connection state for gRPC. The returned function closes the connections.
+func courseSources(cfg *config.Config) (repository.CourseExternalAPI, func() entity.UpstreamHealth, func() error) {
+	resilienceCfg := externalapi.ResilienceConfig{
+		CallTimeout:   cfg.CourseAPICallTimeout,
+		MaxConcurrent: cfg.CourseAPIMaxConcurrent,
+		Breaker: resilience.BreakerConfig{
+			FailureThreshold: cfg.CourseAPIBreakerFailures,
+			OpenTimeout:      cfg.CourseAPIBreakerOpenTime,
+			HalfOpenMaxCalls: cfg.CourseAPIBreakerTrials,
+		},
+	}
+
+	var (
+		sources []externalapi.CourseSource
+		health  func() entity.UpstreamHealth
+		closers []func() error
+	)
+	for _, name := range cfg.CourseSources {
+		var (
+			api       repository.CourseExternalAPI
+			connState func() string
+		)
+		switch name {
+		case "grpc":
+			grpcConn, err := externalapi.DialCourseGRPC(externalapi.GRPCConnConfig{
+				Addr:             cfg.CourseGRPCAddr,
+				TLS:              cfg.CourseGRPCTLS,
+				CAFile:           cfg.CourseGRPCCAFile,
+				CertFile:         cfg.CourseGRPCCertFile,
+				KeyFile:          cfg.CourseGRPCKeyFile,
+				ServerName:       cfg.CourseGRPCServerName,
+				Token:            cfg.CourseGRPCToken,
+				APIKey:           cfg.CourseGRPCAPIKey,
+				APIKeyHeader:     cfg.CourseGRPCAPIKeyHeader,
+				KeepaliveTime:    cfg.CourseGRPCKeepalive,
+				KeepaliveTimeout: cfg.CourseGRPCKeepaliveWait,
+				MaxAttempts:      cfg.CourseGRPCMaxAttempts,
+			})
+			if err != nil {
+				log.Fatalf("Failed to connect to course gRPC service at %s: %v", cfg.CourseGRPCAddr, err)
+			}
+			log.Printf("gRPC client connected to %s (tls=%t)", cfg.CourseGRPCAddr, cfg.CourseGRPCTLS)
+			closers = append(closers, grpcConn.Close)
+			api = externalapi.NewCourseExternalAPI(grpcConn)
+			connState = func() string { return grpcConn.GetState().String() }
+		case "http":
+			log.Printf("Course HTTP API at %s", cfg.CourseHTTPURL)
+			api = externalapi.NewCourseHTTPAPI(cfg.CourseHTTPURL, cfg.CourseHTTPTimeout)
+		case "file":
+			fileAPI, err := externalapi.NewCourseFileAPI(cfg.CourseFilePath)
+			if err != nil {
+				log.Fatalf("Failed to load course file: %v", err)
+			}
+			sources = append(sources, externalapi.CourseSource{Name: name, API: fileAPI})
+			continue
+		}
+
+		resilient := externalapi.NewResilientCourseAPI(api, resilienceCfg)
+		if health == nil {
+			health = resilient.Health
+			if connState != nil {
+				health = func() entity.UpstreamHealth {
+					h := resilient.Health()
+					h.Connection = connState()
+					return h
+				}
+			}
+		}
+		sources = append(sources, externalapi.CourseSource{Name: name, API: resilient})
+	}
+
+	closeAll := func() error {
+		var errs []error
+		for _, c := range closers {
+			errs = append(errs, c())
+		}
+		return errors.Join(errs...)
+	}
+	return externalapi.NewCompositeCourseAPI(sources...), health, closeAll
+}
+
+// loginAttemptStore picks the failed-login counter backend. The in-memory
+// store is per process, so multi-replica deployments should use Mongo.
+func loginAttemptStore(cfg *config.Config, db *mongoDriver.Database) repository.LoginAttemptRepository {
+	if cfg.LoginAttemptStore == "memory" {
+		log.Println("Warning: LOGIN_ATTEMPT_STORE=memory, login lockouts are not shared between replicas")
+		return memoryRepo.NewLoginAttemptRepository()
+	}
+	return mongoRepo.NewLoginAttemptRepository(db)
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// APIKey lets another service call the API without a user login. Only the
+// SHA-256 hash of the key is stored; the plaintext is shown once at creation.
+type APIKey struct {
+	BaseEntity
+	Name       string
+	Prefix     string   // first characters of the key, for identifying it in lists
+	KeyHash    string   // hex SHA-256 of the full key
+	Scopes     []string // permissions the key grants
+	CreatedBy  string   // user ID
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IsActive reports whether the key can authenticate at now.
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
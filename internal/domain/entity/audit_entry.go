@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// AuditEntry records one administrative change: who made it, from where,
+// and the target's state before and after. Entries are never modified.
+type AuditEntry struct {
+	ID         string
+	ActorID    string // JWT "sub": a user ID, or "apikey:<id>" for service keys
+	ActorName  string // username or API key name at the time of the action
+	Action     string // e.g. "course.delete"; see constants.Audit*
+	TargetType string
+	TargetID   string
+	Before     map[string]interface{} // nil for creations
+	After      map[string]interface{} // nil for deletions
+	IP         string
+	CreatedAt  time.Time
+}
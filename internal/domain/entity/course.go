@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 )
 
 // Course represents a university course.
@@ -17,6 +18,28 @@ type Course struct {
 	Semester     int       // e.g., 2
 	Year         int       // e.g., 2568
 	Sections     []Section // multiple sections per course
+	Version      int       // 1 when created, incremented by every update
+
+	// Source is the upstream that supplied the course, e.g. "grpc"; empty
+	// for courses entered by hand. FilledFrom maps fields that were missing
+	// there to the lower-priority source that filled them, e.g.
+	// "department" -> "file" or "sections[01].campus" -> "http".
+	Source     string
+	FilledFrom map[string]string
+
+	// Unparsed lists upstream values that could not be parsed. Their fields
+	// are left empty, and a schedule slot with an unreadable time is kept
+	// with TBA set; the values are reported for review and not stored.
+	Unparsed []UnparsedValue
+}
+
+// UnparsedValue is an upstream value that could not be parsed, such as an
+// unreadable exam date.
+type UnparsedValue struct {
+	Section string // section number, e.g. "01"
+	Field   string // "time", "exam_date" or "midterm_date"
+	Value   string // raw value as received
+	Err     error
 }
 
 // Key returns the composite lookup key: "code:year:semester".
@@ -31,10 +54,10 @@ type Section struct {
 	Schedules    []Schedule // multiple schedule slots per section
 	Seats        int        // e.g., 40
 	Instructor   []string   // e.g., ["ผศ.ดร.ชิตสุธา สุ่มเล็ก"]
-	ExamStart    string     // e.g., "2026-03-31 13:00:00"
-	ExamEnd      string     // e.g., "2026-03-31 16:00:00"
-	MidtermStart string     // สอบกลางภาค start
-	MidtermEnd   string     // สอบกลางภาค end
+	ExamStart    time.Time  // e.g., 2026-03-31 13:00 +07:00; zero = no exam
+	ExamEnd      time.Time  // e.g., 2026-03-31 16:00 +07:00
+	MidtermStart time.Time  // สอบกลางภาค start
+	MidtermEnd   time.Time  // สอบกลางภาค end
 	Note         string     // หมายเหตุ e.g., "ผู้สอบไม่ผ่าน", "Closed"
 	ReservedFor  []string   // สำรองสำหรับ e.g., ["ผู้ที่สอบไม่ผ่าน 50-49-1"]
 	Campus       string     // e.g., "ขอนแก่น", "หนองคาย"
@@ -43,9 +66,12 @@ type Section struct {
 
 // Schedule represents a single class meeting (day + time + room).
 type Schedule struct {
-	Day       string // e.g., "จันทร์"
-	StartTime string // e.g., "13:00"
-	EndTime   string // e.g., "15:00"
-	Room      string // e.g., "CP9 CP9127"
-	Type      string // e.g., "C" (lecture)
+	Day       string       // raw upstream value, e.g., "จันทร์"
+	Weekday   Weekday      // canonical, e.g., WeekdayMonday
+	StartTime TimeOfDay    // e.g., 13:00
+	EndTime   TimeOfDay    // e.g., 15:00
+	TBA       bool         // time "to be announced"; StartTime/EndTime unknown
+	Room      string       // e.g., "CP9 CP9127"
+	Type      string       // raw upstream value, e.g., "C" (lecture)
+	Kind      ScheduleKind // canonical, e.g., ScheduleKindLecture
 }
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// CourseChangeKind classifies a difference between two versions of a course.
+type CourseChangeKind string
+
+const (
+	CourseChangeInfo           CourseChangeKind = "COURSE_INFO_CHANGED" // name, credits or prerequisite; Detail names the field
+	CourseChangeSectionAdded   CourseChangeKind = "SECTION_ADDED"
+	CourseChangeSectionRemoved CourseChangeKind = "SECTION_REMOVED"
+	CourseChangeSchedule       CourseChangeKind = "SCHEDULE_CHANGED" // meeting days/times
+	CourseChangeRoom           CourseChangeKind = "ROOM_CHANGED"     // same meeting, new room; Detail is the meeting
+	CourseChangeInstructor     CourseChangeKind = "INSTRUCTOR_CHANGED"
+	CourseChangeSeats          CourseChangeKind = "SEATS_CHANGED"
+	CourseChangeExam           CourseChangeKind = "EXAM_CHANGED"
+	CourseChangeMidterm        CourseChangeKind = "MIDTERM_CHANGED"
+	CourseChangeNote           CourseChangeKind = "NOTE_CHANGED"
+	CourseChangeSectionClosed  CourseChangeKind = "SECTION_CLOSED" // note changed to "Closed"
+)
+
+// CourseChange is one difference found when a course was refreshed. Old and
+// New are display values, e.g. "MON 13:00-15:00 CP9127 (C)".
+type CourseChange struct {
+	Kind      CourseChangeKind
+	SectionID string // empty for course-level changes
+	Section   string // section number, e.g. "01"
+	Detail    string // what exactly changed, e.g. the field or meeting
+	Old       string
+	New       string
+}
+
+// CourseChangeEvent records the changes a single refresh found in a course.
+// Events are never modified.
+type CourseChangeEvent struct {
+	ID         string
+	CourseCode string
+	Year       int
+	Semester   int
+	Source     string // upstream that supplied the new version
+	Changes    []CourseChange
+	DetectedAt time.Time
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// CourseVersion is a stored course as it was before an update replaced it.
+// It was what readers saw from ValidFrom until ValidTo.
+type CourseVersion struct {
+	CourseID  string
+	Version   int
+	ValidFrom time.Time // when this version was saved
+	ValidTo   time.Time // when the next version replaced it
+	Course    *Course
+}
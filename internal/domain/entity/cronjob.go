@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 // CronJob represents a scheduled job that refreshes course data.
 type CronJob struct {
 	BaseEntity
@@ -10,3 +12,18 @@ type CronJob struct {
 	CronExpr    string   // cron expression, e.g. "0 */6 * * *" (every 6 hours)
 	Enabled     bool     // toggle on/off
 }
+
+// CronJobRun summarises one execution of a cron job once every course it
+// enqueued has been refreshed, or has failed.
+type CronJobRun struct {
+	JobID       string
+	JobName     string
+	Manual      bool // triggered through the API rather than by the schedule
+	Acadyear    int
+	Semester    int
+	StartedAt   time.Time
+	CompletedAt time.Time
+	Refreshed   int // courses fetched and saved
+	Failed      int // courses whose refresh failed or did not finish in time
+	Skipped     int // courses already being refreshed, or dropped by a full queue
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// DomainEvent records a change to a course, cron job or user. It is written
+// to the outbox together with the change and relayed to the event sinks
+// afterwards, so an event is never lost once its change is saved.
+type DomainEvent struct {
+	ID            string
+	Type          string // e.g. "course.updated"
+	AggregateID   string // ID of the changed course, cron job or user
+	Data          []byte // JSON
+	OccurredAt    time.Time
+	Attempts      int // relay attempts that left a sink unfinished
+	NextAttemptAt time.Time
+	LastError     string
+	HandledBy     []string   // sinks that have handled the event
+	PublishedAt   *time.Time // set once every sink has handled it
+}
+
+// WasHandledBy reports whether the named sink has handled the event.
+func (e *DomainEvent) WasHandledBy(sink string) bool {
+	for _, s := range e.HandledBy {
+		if s == sink {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,21 @@
 package entity
 
+import "time"
+
 // Health represents the health/status of the application.
 type Health struct {
 	Status    string
+	Ready     bool // false while the course API cannot be reached
 	Timestamp string
+	CourseAPI *UpstreamHealth // nil when the course API is not monitored
+}
+
+// UpstreamHealth is the client-side view of an upstream dependency.
+type UpstreamHealth struct {
+	Breaker             string // closed, open or half-open
+	ConsecutiveFailures int
+	OpenUntil           time.Time // zero unless the breaker is open
+	InFlight            int
+	MaxConcurrent       int
+	Connection          string // gRPC connectivity state, e.g. READY; empty for other transports
 }
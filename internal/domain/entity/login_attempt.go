@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// LoginAttempt counts failed logins for one throttling key, such as a
+// username or a client IP.
+type LoginAttempt struct {
+	Key         string
+	Failures    int       // failures since WindowStart
+	WindowStart time.Time // first failure of the current window
+	Lockouts    int       // lockouts so far; drives the exponential backoff
+	LockedUntil time.Time // zero when not locked
+	ExpiresAt   time.Time // the record may be discarded after this
+}
+
+// IsLocked reports whether the key is locked out at now.
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// ExternalIdentity is a user authenticated by an external OpenID Connect
+// provider, taken from a verified ID token.
+type ExternalIdentity struct {
+	Issuer  string
+	Subject string                 // "sub"; stable per issuer
+	Claims  map[string]interface{} // every ID token claim
+}
+
+// ID returns the key used to link the identity to a local user.
+func (i *ExternalIdentity) ID() string {
+	return i.Issuer + "|" + i.Subject
+}
+
+// OIDCLoginState is a pending authorization-code login, kept between the
+// redirect to the provider and its callback.
+type OIDCLoginState struct {
+	State        string // opaque value echoed back by the provider
+	Nonce        string // must match the ID token's nonce claim
+	CodeVerifier string // PKCE verifier for the S256 challenge sent to the provider
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PasswordResetToken is an admin-issued, single-use permission to set a
+// user's password without knowing the current one. Only a hash of the
+// token is stored.
+type PasswordResetToken struct {
+	TokenHash string // SHA-256 of the token sent to the user
+	UserID    string
+	CreatedBy string // ID of the admin who issued it
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// RolePermissions binds a role to the permissions it grants.
+type RolePermissions struct {
+	Role        string
+	Permissions []string
+	UpdatedAt   time.Time
+	UpdatedBy   string // user ID of the last editor; empty for seeded defaults
+}
+
+// Has reports whether the binding grants perm.
+func (r *RolePermissions) Has(perm string) bool {
+	for _, p := range r.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package entity
+
+import "strings"
+
+// Weekday is the canonical day-of-week of a schedule slot.
+type Weekday string
+
+// Canonical weekday values. WeekdayUnknown means the raw value could not be mapped.
+const (
+	WeekdayUnknown   Weekday = ""
+	WeekdayMonday    Weekday = "MON"
+	WeekdayTuesday   Weekday = "TUE"
+	WeekdayWednesday Weekday = "WED"
+	WeekdayThursday  Weekday = "THU"
+	WeekdayFriday    Weekday = "FRI"
+	WeekdaySaturday  Weekday = "SAT"
+	WeekdaySunday    Weekday = "SUN"
+)
+
+// ScheduleKind is the canonical kind of a schedule slot.
+type ScheduleKind string
+
+// Canonical schedule kinds. ScheduleKindUnknown means the raw value could not be mapped.
+const (
+	ScheduleKindUnknown ScheduleKind = ""
+	ScheduleKindLecture ScheduleKind = "LECTURE"
+	ScheduleKindLab     ScheduleKind = "LAB"
+)
+
+// weekdayAliases maps lower-cased raw day values (Thai and English) to canonical weekdays.
+var weekdayAliases = map[string]Weekday{
+	"จันทร์": WeekdayMonday, "จ": WeekdayMonday, "monday": WeekdayMonday, "mon": WeekdayMonday, "mo": WeekdayMonday,
+	"อังคาร": WeekdayTuesday, "อ": WeekdayTuesday, "tuesday": WeekdayTuesday, "tue": WeekdayTuesday, "tu": WeekdayTuesday,
+	"พุธ": WeekdayWednesday, "พ": WeekdayWednesday, "wednesday": WeekdayWednesday, "wed": WeekdayWednesday, "we": WeekdayWednesday,
+	"พฤหัสบดี": WeekdayThursday, "พฤหัส": WeekdayThursday, "พฤ": WeekdayThursday, "thursday": WeekdayThursday, "thu": WeekdayThursday, "th": WeekdayThursday,
+	"ศุกร์": WeekdayFriday, "ศ": WeekdayFriday, "friday": WeekdayFriday, "fri": WeekdayFriday, "fr": WeekdayFriday,
+	"เสาร์": WeekdaySaturday, "ส": WeekdaySaturday, "saturday": WeekdaySaturday, "sat": WeekdaySaturday, "sa": WeekdaySaturday,
+	"อาทิตย์": WeekdaySunday, "อา": WeekdaySunday, "sunday": WeekdaySunday, "sun": WeekdaySunday, "su": WeekdaySunday,
+}
+
+// scheduleKindAliases maps lower-cased raw type values to canonical schedule kinds.
+var scheduleKindAliases = map[string]ScheduleKind{
+	"c": ScheduleKindLecture, "lecture": ScheduleKindLecture, "lec": ScheduleKindLecture, "บรรยาย": ScheduleKindLecture, "ทฤษฎี": ScheduleKindLecture,
+	"l": ScheduleKindLab, "lab": ScheduleKindLab, "laboratory": ScheduleKindLab, "ปฏิบัติ": ScheduleKindLab, "ปฏิบัติการ": ScheduleKindLab,
+}
+
+// NormalizeWeekday maps a raw day such as "จันทร์", "วันจันทร์", "จ." or "Monday"
+// to its canonical Weekday. Returns WeekdayUnknown when the value is not recognised.
+func NormalizeWeekday(raw string) Weekday {
+	key := strings.ToLower(strings.TrimSpace(raw))
+	key = strings.TrimSuffix(key, ".")
+	if d, ok := weekdayAliases[key]; ok {
+		return d
+	}
+	return weekdayAliases[strings.TrimPrefix(key, "วัน")]
+}
+
+// NormalizeScheduleKind maps a raw type such as "C", "Lecture" or "Lab"
+// to its canonical ScheduleKind. Returns ScheduleKindUnknown when not recognised.
+func NormalizeScheduleKind(raw string) ScheduleKind {
+	key := strings.ToLower(strings.TrimSpace(raw))
+	return scheduleKindAliases[strings.TrimSuffix(key, ".")]
+}
+
+// NewSchedule builds a Schedule from raw upstream values, filling in the
+// canonical Weekday and Kind while keeping the raw Day and Type.
+func NewSchedule(day string, start, end TimeOfDay, room, typ string) Schedule {
+	return Schedule{
+		Day:       day,
+		Weekday:   NormalizeWeekday(day),
+		StartTime: start,
+		EndTime:   end,
+		Room:      room,
+		Type:      typ,
+		Kind:      NormalizeScheduleKind(typ),
+	}
+}
+
+// UnmappedFields returns the raw values of this schedule that could not be
+// normalised, keyed by field name ("day", "type").
+func (s Schedule) UnmappedFields() map[string]string {
+	fields := map[string]string{}
+	if s.Weekday == WeekdayUnknown && strings.TrimSpace(s.Day) != "" {
+		fields["day"] = s.Day
+	}
+	if s.Kind == ScheduleKindUnknown && strings.TrimSpace(s.Type) != "" {
+		fields["type"] = s.Type
+	}
+	return fields
+}
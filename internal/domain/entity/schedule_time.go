@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TimeSlot is one start–end range within a schedule time string.
+type TimeSlot struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// tbaValues are raw schedule times meaning "to be announced".
+var tbaValues = map[string]bool{
+	"tba": true, "tba.": true, "ไม่ระบุ": true, "-": true,
+}
+
+// slotSeparators split a string holding several ranges, e.g. "09:00-11:00, 13:00-15:00".
+var slotSeparators = strings.NewReplacer(",", "|", "/", "|", ";", "|", "และ", "|")
+
+// ParseScheduleTime parses the schedule time formats the registrar emits:
+//
+//	"13:00-15:00", "13:00 - 15:00 น.", "13.00-15.00", "13:00–15:00",
+//	"TBA" / "ไม่ระบุ", and several ranges joined by ",", "/", ";" or "และ".
+//
+// An empty string yields no slots and no error.
+func ParseScheduleTime(s string) (slots []TimeSlot, tba bool, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, false, nil
+	}
+	if tbaValues[strings.ToLower(s)] {
+		return nil, true, nil
+	}
+
+	for _, part := range strings.Split(slotSeparators.Replace(s), "|") {
+		part = strings.TrimSpace(part)
+		part = strings.TrimSuffix(part, "น.")
+		part = strings.TrimSuffix(part, "น")
+		part = strings.NewReplacer("–", "-", "—", "-", "ถึง", "-").Replace(part)
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		start, end, err := ParseTimeRange(part)
+		if err != nil {
+			return nil, false, err
+		}
+		if end.Minutes() <= start.Minutes() {
+			return nil, false, fmt.Errorf("time range %q ends before it starts", strings.TrimSpace(part))
+		}
+		slots = append(slots, TimeSlot{Start: start, End: end})
+	}
+
+	if len(slots) == 0 {
+		return nil, false, fmt.Errorf("invalid time range %q", s)
+	}
+	return slots, false, nil
+}
+
+// NewSchedules builds the schedules for one raw upstream slot. A time string
+// with several ranges expands into one Schedule per range; "TBA" yields a
+// single Schedule with TBA set and no times. An unparseable time string
+// also yields that TBA Schedule, so the slot keeps its day, room and type,
+// together with the error.
+func NewSchedules(day, timeStr, room, typ string) ([]Schedule, error) {
+	slots, tba, err := ParseScheduleTime(timeStr)
+	if err != nil || len(slots) == 0 {
+		sc := NewSchedule(day, TimeOfDay{}, TimeOfDay{}, room, typ)
+		sc.TBA = tba || err != nil
+		return []Schedule{sc}, err
+	}
+
+	schedules := make([]Schedule, len(slots))
+	for i, slot := range slots {
+		schedules[i] = NewSchedule(day, slot.Start, slot.End, room, typ)
+	}
+	return schedules, nil
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Session is a login session backed by a rotating refresh token. Only
+// SHA-256 hashes of refresh tokens are stored.
+type Session struct {
+	BaseEntity
+	UserID            string
+	TokenHash         string     // hash of the current refresh token
+	PreviousTokenHash string     // hash of the token it replaced; presenting it again means reuse
+	ExpiresAt         time.Time  // refresh token expiry
+	RevokedAt         *time.Time // nil = active
+}
+
+// IsActive reports whether the session can still mint access tokens at now.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// AuthTokens is the token pair issued on login and refresh.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration // access token lifetime
+}
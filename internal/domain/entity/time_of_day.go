@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time without a date, e.g. 13:00.
+// Values are always Asia/Bangkok local time; Valid is false when unknown.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Valid  bool
+}
+
+// NewTimeOfDay returns a valid TimeOfDay for the given hour and minute.
+func NewTimeOfDay(hour, minute int) TimeOfDay {
+	return TimeOfDay{Hour: hour, Minute: minute, Valid: true}
+}
+
+// ParseTimeOfDay parses "HH:MM" (also accepting "HH.MM"). An empty string
+// yields an invalid (unknown) TimeOfDay without error.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return TimeOfDay{}, nil
+	}
+
+	t, err := time.Parse("15:04", strings.Replace(s, ".", ":", 1))
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q", s)
+	}
+	return NewTimeOfDay(t.Hour(), t.Minute()), nil
+}
+
+// String formats the time as "HH:MM", or "" when unknown.
+func (t TimeOfDay) String() string {
+	if !t.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// Minutes returns the number of minutes since midnight.
+func (t TimeOfDay) Minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// On anchors the time of day to the calendar date of d, in d's location.
+func (t TimeOfDay) On(d time.Time) time.Time {
+	y, m, day := d.Date()
+	return time.Date(y, m, day, t.Hour, t.Minute, 0, 0, d.Location())
+}
+
+// ParseTimeRange parses "HH:MM-HH:MM" into start and end times.
+// An empty string yields two unknown values without error.
+func ParseTimeRange(s string) (TimeOfDay, TimeOfDay, error) {
+	if strings.TrimSpace(s) == "" {
+		return TimeOfDay{}, TimeOfDay{}, nil
+	}
+
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return TimeOfDay{}, TimeOfDay{}, fmt.Errorf("invalid time range %q", s)
+	}
+
+	start, err := ParseTimeOfDay(parts[0])
+	if err != nil {
+		return TimeOfDay{}, TimeOfDay{}, err
+	}
+	end, err := ParseTimeOfDay(parts[1])
+	if err != nil {
+		return TimeOfDay{}, TimeOfDay{}, err
+	}
+	return start, end, nil
+}
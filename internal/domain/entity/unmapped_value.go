@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// UnmappedValue is a raw upstream value the normaliser could not map to a
+// canonical enum, or could not parse at all (see UnparsedValue). Admins
+// review these to extend the alias tables and parsers.
+type UnmappedValue struct {
+	Field         string // "day" or "type"; "time", "exam_date" or "midterm_date" when unparsed
+	Value         string // raw value as received, e.g. "Mon-Wed"
+	Count         int64  // number of distinct courses it was seen on
+	ExampleCourse string // a course code the value was last seen on
+	FirstSeen     time.Time
+	LastSeen      time.Time
+}
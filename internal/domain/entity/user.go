@@ -3,7 +3,12 @@ package entity
 // User represents a registered user.
 type User struct {
 	BaseEntity
-	Username string
-	Password string // bcrypt-hashed
-	Role     string
+	Username   string
+	Password   string // bcrypt-hashed; empty for users provisioned by single sign-on
+	Role       string
+	Disabled   bool   // disabled users cannot log in and their tokens stop working
+	ExternalID string // "issuer|subject" of the linked OIDC identity, if any
+	// MustChangePassword blocks login until the user sets a new password;
+	// set on the seeded superadmin.
+	MustChangePassword bool
 }
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// Watch subscribes a user to the changes refreshes find in a course, or in
+// one of its sections.
+type Watch struct {
+	ID         string
+	UserID     string
+	CourseID   string
+	SectionID  string // empty watches the whole course
+	CourseCode string // copied from the course for listings
+	Year       int
+	Semester   int
+	Section    string // section number, when SectionID is set
+	CreatedAt  time.Time
+}
+
+// WatchNotificationStatus is the state of a watch notification.
+type WatchNotificationStatus string
+
+const (
+	WatchNotificationPending WatchNotificationStatus = "pending" // waiting for its next attempt
+	WatchNotificationSent    WatchNotificationStatus = "sent"
+	WatchNotificationFailed  WatchNotificationStatus = "failed" // gave up, or the user can no longer be notified
+)
+
+// WatchNotification tells one watcher about the changes a refresh found in
+// a course. It is queued when the change is relayed from the outbox and
+// sent, with retries, by the notification worker.
+type WatchNotification struct {
+	ID            string
+	EventID       string // outbox event it was queued for; one notification per event and user
+	UserID        string
+	CourseID      string
+	Change        CourseChangeEvent // holds only the changes the user's watches cover
+	Status        WatchNotificationStatus
+	Attempts      int
+	NextAttemptAt time.Time // when a pending notification is next tried
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
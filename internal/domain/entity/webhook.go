@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// Webhook pushes the events it subscribes to to an HTTP endpoint.
+type Webhook struct {
+	ID          string
+	URL         string
+	Events      []string // event types, e.g. "course.updated"
+	Secret      string   // signs every delivery with HMAC-SHA256
+	Description string
+	CreatedBy   string // user ID
+	CreatedAt   time.Time
+}
+
+// Subscribes reports whether the webhook receives events of the given type.
+func (w *Webhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is the state of a delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // waiting for its next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // gave up after the last attempt
+)
+
+// WebhookDelivery is one event sent, or to be sent, to one webhook.
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	Event          string
+	Payload        []byte // JSON body, identical on every attempt
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time // when a pending delivery is next tried
+	LastStatusCode int       // HTTP status of the last attempt; 0 if no response
+	LastError      string
+	ReplayOf       string // ID of the delivery this one replays
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
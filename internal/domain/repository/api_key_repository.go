@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// APIKeyRepository defines persistence for service API keys.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	FindByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	GetAll(ctx context.Context) ([]*entity.APIKey, error)
+	// Revoke reports false when no unrevoked key has the given ID.
+	Revoke(ctx context.Context, id string) (bool, error)
+	TouchLastUsed(ctx context.Context, id string, at time.Time) error
+}
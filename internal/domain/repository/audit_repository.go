@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// AuditFilter narrows an audit log query. Empty fields match everything.
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       time.Time // inclusive; zero = unbounded
+	To         time.Time // exclusive; zero = unbounded
+}
+
+// AuditRepository defines persistence for the append-only audit log.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+	// GetPaginated returns matching entries, newest first.
+	GetPaginated(ctx context.Context, filter AuditFilter, page, limit int) ([]*entity.AuditEntry, int64, error)
+}
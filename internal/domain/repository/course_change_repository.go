@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// CourseChangeFilter narrows a course change query. Zero Year or Semester
+// matches every term.
+type CourseChangeFilter struct {
+	CourseCode string
+	Year       int
+	Semester   int
+}
+
+// CourseChangeRepository defines persistence for the append-only course
+// change events.
+type CourseChangeRepository interface {
+	Create(ctx context.Context, event *entity.CourseChangeEvent) error
+	// GetPaginated returns matching events, newest first.
+	GetPaginated(ctx context.Context, filter CourseChangeFilter, page, limit int) ([]*entity.CourseChangeEvent, int64, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 )
@@ -9,5 +10,26 @@ import (
 // CourseExternalAPI abstracts the external course data API.
 type CourseExternalAPI interface {
 	// FetchByCode fetches a course from the external API by its code, academic year, and semester.
+	// Values that cannot be parsed are left empty and listed in the course's
+	// Unparsed values rather than failing the fetch.
 	FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error)
+	// FetchByCodes fetches several courses of one academic year and semester
+	// in as few calls as possible. The result has an entry for every requested
+	// code; a returned error means the whole batch failed.
+	FetchByCodes(ctx context.Context, codes []string, acadyear, semester int) (map[string]CourseFetchResult, error)
 }
+
+// CourseFetchResult is the outcome for one code of a batch fetch. Exactly
+// one of Course and Err is set.
+type CourseFetchResult struct {
+	Course *entity.Course
+	Err    error
+}
+
+// ErrExternalCourseNotFound is returned by FetchByCodes for codes the
+// external API does not know.
+var ErrExternalCourseNotFound = errors.New("course not found in external API")
+
+// ErrMalformedCourse is returned (wrapped) when an upstream answer cannot be
+// read as a course at all, such as a truncated JSON document.
+var ErrMalformedCourse = errors.New("malformed course data")
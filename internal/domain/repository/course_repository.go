@@ -12,6 +12,11 @@ type CourseRepository interface {
 	GetAll(ctx context.Context) ([]*entity.Course, error)
 	GetPaginated(ctx context.Context, page, limit int, includeSections bool) ([]*entity.Course, int64, error)
 	GetByKey(ctx context.Context, code string, year, semester int) (*entity.Course, error)
+	// GetByID returns nil if no course has the ID.
+	GetByID(ctx context.Context, id string) (*entity.Course, error)
+	// Update replaces the stored course, archiving the previous version
+	// (see CourseVersionRepository), and sets course.Version. A course
+	// whose content is unchanged only has its UpdatedAt refreshed.
 	Update(ctx context.Context, course *entity.Course) error
 	SoftDelete(ctx context.Context, code string, year, semester int) error
 }
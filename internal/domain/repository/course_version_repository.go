@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// CourseVersionRepository reads the versions CourseRepository.Update
+// archives before replacing a course.
+type CourseVersionRepository interface {
+	// GetVersions returns the previous versions of a course, newest first.
+	GetVersions(ctx context.Context, courseID string, page, limit int) ([]*entity.CourseVersion, int64, error)
+	// GetAsOf returns the course as it was stored at asOf, or nil if it did
+	// not exist or was deleted at that time.
+	GetAsOf(ctx context.Context, code string, year, semester int, asOf time.Time) (*entity.Course, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// IdentityProvider abstracts an OpenID Connect provider used for single sign-on.
+type IdentityProvider interface {
+	// AuthCodeURL returns the provider URL the browser is sent to, requesting
+	// an authorization code bound to state, nonce and the PKCE S256 codeChallenge.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems code with codeVerifier and returns the identity from
+	// the ID token after verifying its signature, issuer, audience, expiry
+	// and nonce.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*entity.ExternalIdentity, error)
+}
+
+// ErrIdentityRejected is returned (wrapped) when the provider refuses the
+// authorization code or its ID token fails verification.
+var ErrIdentityRejected = errors.New("identity rejected")
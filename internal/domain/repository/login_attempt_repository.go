@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// LoginAttemptRepository stores failed-login counters used to throttle
+// brute-force attempts. Implementations must make RecordFailure atomic so
+// concurrent attempts are all counted.
+type LoginAttemptRepository interface {
+	// Get returns the counter for key, or nil if there is none.
+	Get(ctx context.Context, key string) (*entity.LoginAttempt, error)
+	// RecordFailure increments the failure count for key and returns the
+	// updated counter. The count restarts at 1 when the current window began
+	// more than window before now.
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration, expiresAt time.Time) (*entity.LoginAttempt, error)
+	// Lock locks key until the given time, increments its lockout count and
+	// clears its failures.
+	Lock(ctx context.Context, key string, until, expiresAt time.Time) error
+	// Reset removes the counter for key.
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// OIDCStateRepository stores pending OpenID Connect logins.
+type OIDCStateRepository interface {
+	Create(ctx context.Context, state *entity.OIDCLoginState) error
+	// Consume removes and returns the unexpired login for state, or nil if
+	// there is none. A state can be consumed only once.
+	Consume(ctx context.Context, state string) (*entity.OIDCLoginState, error)
+}
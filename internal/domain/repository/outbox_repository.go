@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// OutboxRepository defines persistence for domain events awaiting relay.
+type OutboxRepository interface {
+	// Append stores a new event. Called with a transaction's context it
+	// commits or rolls back with the rest of the transaction.
+	Append(ctx context.Context, event *entity.DomainEvent) error
+	// ClaimDue returns the oldest unpublished event due at now, or nil if
+	// there is none, and postpones its next attempt to now+lease so no
+	// other relay claims it meanwhile.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*entity.DomainEvent, error)
+	// Update saves the outcome of a relay attempt.
+	Update(ctx context.Context, event *entity.DomainEvent) error
+}
+
+// Transactor runs work in a database transaction. Repository calls made
+// with the context passed to fn take part in it.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventSink receives relayed domain events. Delivery is at least once: an
+// event is handled again if the relay stops before recording success, so
+// Handle must be idempotent, e.g. by keying on the event ID.
+type EventSink interface {
+	// Name identifies the sink in an event's HandledBy list and must not
+	// change between releases.
+	Name() string
+	Handle(ctx context.Context, event *entity.DomainEvent) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// PasswordResetRepository stores outstanding password reset tokens.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *entity.PasswordResetToken) error
+	// FindByHash returns the unexpired token with tokenHash, or nil.
+	FindByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	// Consume removes and returns the unexpired token with tokenHash, or nil
+	// if there is none. A token can be consumed only once.
+	Consume(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	// DeleteForUser removes every token issued for userID.
+	DeleteForUser(ctx context.Context, userID string) error
+}
+
+// PasswordResetNotifier delivers a password reset token to its user, e.g.
+// by e-mail. The token grants access to the account until expiresAt and
+// must not be exposed anywhere else.
+type PasswordResetNotifier interface {
+	SendPasswordReset(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// RolePermissionRepository defines persistence for role→permission bindings.
+type RolePermissionRepository interface {
+	GetByRole(ctx context.Context, role string) (*entity.RolePermissions, error)
+	// Save creates or replaces the binding for rp.Role.
+	Save(ctx context.Context, rp *entity.RolePermissions) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// SessionRepository defines persistence for refresh-token sessions.
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	FindByID(ctx context.Context, id string) (*entity.Session, error)
+	// FindByTokenHash matches either the current or the previous token hash.
+	FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error)
+	// Rotate swaps oldHash for newHash. It reports false when oldHash is no
+	// longer current (e.g. a concurrent refresh won the race).
+	Rotate(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time) (bool, error)
+	Revoke(ctx context.Context, id string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+	RevokeAllForUserExcept(ctx context.Context, userID, keepID string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// UnmappedValueRepository records raw upstream values that could not be normalised or parsed.
+type UnmappedValueRepository interface {
+	// Record upserts the (field, value) pair and adds courseCode to the
+	// courses it was seen on. Recording the same course again changes only
+	// when the value was last seen.
+	Record(ctx context.Context, field, value, courseCode string) error
+	GetAll(ctx context.Context) ([]*entity.UnmappedValue, error)
+}
@@ -10,5 +10,10 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
+	FindByID(ctx context.Context, id string) (*entity.User, error)
+	FindByExternalID(ctx context.Context, externalID string) (*entity.User, error)
+	ExistsByRole(ctx context.Context, role string) (bool, error)
 	GetPaginated(ctx context.Context, page, limit int) ([]*entity.User, int64, error)
+	Update(ctx context.Context, user *entity.User) error
+	Delete(ctx context.Context, id string) error // soft delete
 }
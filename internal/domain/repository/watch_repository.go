@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// WatchRepository defines persistence for course watch subscriptions.
+type WatchRepository interface {
+	Create(ctx context.Context, watch *entity.Watch) error
+	GetByUser(ctx context.Context, userID string) ([]*entity.Watch, error)
+	GetByCourse(ctx context.Context, courseID string) ([]*entity.Watch, error)
+	// Delete reports false when the user has no watch with the given ID.
+	Delete(ctx context.Context, id, userID string) (bool, error)
+}
+
+// WatchNotificationRepository defines persistence for queued watch notifications.
+type WatchNotificationRepository interface {
+	// Create queues a notification. One already queued for the same event
+	// and user is kept instead, so a relayed event can be handled again.
+	Create(ctx context.Context, notification *entity.WatchNotification) error
+	// ClaimDue returns a pending notification whose next attempt is due at
+	// now, or nil if there is none, and postpones its next attempt to
+	// now+lease so no other worker claims it while it is being sent.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*entity.WatchNotification, error)
+	// Update saves the outcome of an attempt.
+	Update(ctx context.Context, notification *entity.WatchNotification) error
+}
+
+// CourseChangeNotifier tells a user about changes found in a course they
+// watch. event holds only the changes the user's watches cover.
+type CourseChangeNotifier interface {
+	NotifyCourseChanges(ctx context.Context, user *entity.User, event *entity.CourseChangeEvent) error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// WebhookRepository defines persistence for webhook subscriptions.
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	GetAll(ctx context.Context) ([]*entity.Webhook, error)
+	// GetByID returns nil if no webhook has the ID.
+	GetByID(ctx context.Context, id string) (*entity.Webhook, error)
+	// GetByEvent returns the webhooks subscribed to an event type.
+	GetByEvent(ctx context.Context, event string) ([]*entity.Webhook, error)
+	// Delete reports false when no webhook has the ID.
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// WebhookDeliveryFilter narrows a delivery query. Empty fields match everything.
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	Event     string
+	Status    entity.WebhookDeliveryStatus
+}
+
+// WebhookDeliveryRepository defines persistence for webhook deliveries.
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// GetByID returns nil if no delivery has the ID.
+	GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	// GetPaginated returns matching deliveries, newest first.
+	GetPaginated(ctx context.Context, filter WebhookDeliveryFilter, page, limit int) ([]*entity.WebhookDelivery, int64, error)
+	// ClaimDue returns a pending delivery whose next attempt is due at now,
+	// or nil if there is none, and postpones its next attempt to now+lease
+	// so no other worker claims it while it is being sent.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*entity.WebhookDelivery, error)
+	// Update saves the outcome of an attempt.
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
+
+// WebhookSender makes one delivery attempt. It returns the HTTP status of
+// the response, or 0 if there was none; non-2xx responses are errors.
+type WebhookSender interface {
+	Send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+)
+
+// apiKeyPrefix marks API keys so they are recognisable in logs and secret scanners.
+const apiKeyPrefix = "cpn_"
+
+// apiKeyDisplayLen is how much of the key is kept in clear for listings.
+const apiKeyDisplayLen = len(apiKeyPrefix) + 8
+
+// lastUsedResolution limits last-used writes to one per key per interval.
+const lastUsedResolution = time.Minute
+
+var generateAPIKey = func() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid API key")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrScopeNotHeld   = errors.New("cannot grant a scope you do not hold")
+	ErrExpiryInPast   = errors.New("expires_at must be in the future")
+)
+
+// APIKeyUsecase defines the business logic for service API keys.
+type APIKeyUsecase interface {
+	// Create issues a key and returns it with its plaintext, which is not
+	// stored and cannot be retrieved again. Callers may only grant scopes
+	// their own role holds.
+	Create(ctx context.Context, name string, scopes []string, expiresAt *time.Time, creatorID, creatorRole string) (*entity.APIKey, string, error)
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	// Authenticate resolves a plaintext key, returning ErrInvalidAPIKey for
+	// unknown, revoked or expired keys.
+	Authenticate(ctx context.Context, key string) (*entity.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	repo  repository.APIKeyRepository
+	perms PermissionChecker
+	now   func() time.Time
+}
+
+// NewAPIKeyUsecase creates a new instance of APIKeyUsecase.
+func NewAPIKeyUsecase(repo repository.APIKeyRepository, perms PermissionChecker) APIKeyUsecase {
+	return &apiKeyUsecase{repo: repo, perms: perms, now: time.Now}
+}
+
+func (u *apiKeyUsecase) Create(ctx context.Context, name string, scopes []string, expiresAt *time.Time, creatorID, creatorRole string) (*entity.APIKey, string, error) {
+	if expiresAt != nil && !expiresAt.After(u.now()) {
+		return nil, "", ErrExpiryInPast
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	unique := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if !constants.ValidPermissions[s] {
+			return nil, "", ErrUnknownPermission
+		}
+		if seen[s] {
+			continue
+		}
+		ok, err := u.perms.HasPermission(ctx, creatorRole, s)
+		if err != nil {
+			return nil, "", err
+		}
+		if !ok {
+			return nil, "", ErrScopeNotHeld
+		}
+		seen[s] = true
+		unique = append(unique, s)
+	}
+
+	plaintext, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &entity.APIKey{
+		Name:      name,
+		Prefix:    plaintext[:apiKeyDisplayLen],
+		KeyHash:   hashToken(plaintext),
+		Scopes:    unique,
+		CreatedBy: creatorID,
+		ExpiresAt: expiresAt,
+	}
+	if err := u.repo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+func (u *apiKeyUsecase) List(ctx context.Context) ([]*entity.APIKey, error) {
+	return u.repo.GetAll(ctx)
+}
+
+func (u *apiKeyUsecase) Revoke(ctx context.Context, id string) error {
+	ok, err := u.repo.Revoke(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (u *apiKeyUsecase) Authenticate(ctx context.Context, key string) (*entity.APIKey, error) {
+	stored, err := u.repo.FindByHash(ctx, hashToken(key))
+	if err != nil {
+		return nil, err
+	}
+	now := u.now()
+	if stored == nil || !stored.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedResolution {
+		// Best effort: a failed timestamp write must not reject the request.
+		if err := u.repo.TouchLastUsed(ctx, stored.ID, now); err != nil {
+			log.Printf("Warning: failed to record API key use for %s: %v", stored.ID, err)
+		} else {
+			stored.LastUsedAt = &now
+		}
+	}
+	return stored, nil
+}
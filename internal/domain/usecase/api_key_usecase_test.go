@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ----- Mock APIKeyRepository -----
+
+type mockAPIKeyRepo struct {
+	mock.Mock
+}
+
+func (m *mockAPIKeyRepo) Create(ctx context.Context, key *entity.APIKey) error {
+	args := m.Called(ctx, key)
+	key.ID = "k1"
+	return args.Error(0)
+}
+
+func (m *mockAPIKeyRepo) FindByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *mockAPIKeyRepo) GetAll(ctx context.Context) ([]*entity.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.APIKey), args.Error(1)
+}
+
+func (m *mockAPIKeyRepo) Revoke(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAPIKeyRepo) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// ----- Tests -----
+
+func TestAPIKeyCreate_StoresHashOnly(t *testing.T) {
+	repo := new(mockAPIKeyRepo)
+	uc := NewAPIKeyUsecase(repo, defaultPermissions{})
+
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).Return(nil)
+
+	key, plaintext, err := uc.Create(context.Background(), "sync", []string{constants.PermCourseWrite, constants.PermCourseWrite}, nil, "u1", constants.RoleAdmin)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plaintext, apiKeyPrefix))
+	assert.Equal(t, hashToken(plaintext), key.KeyHash)
+	assert.NotContains(t, key.KeyHash, plaintext)
+	assert.True(t, strings.HasPrefix(plaintext, key.Prefix))
+	assert.Len(t, key.Prefix, apiKeyDisplayLen)
+	assert.Equal(t, []string{constants.PermCourseWrite}, key.Scopes)
+	assert.Equal(t, "u1", key.CreatedBy)
+}
+
+func TestAPIKeyCreate_Rejects(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		role    string
+		scopes  []string
+		expires *time.Time
+		wantErr error
+	}{
+		{"scope not held", constants.RoleAdmin, []string{constants.PermRoleManage}, nil, ErrScopeNotHeld},
+		{"student grants anything", constants.RoleStudent, []string{constants.PermQueueRead}, nil, ErrScopeNotHeld},
+		{"unknown scope", constants.RoleAdmin, []string{"course:delete"}, nil, ErrUnknownPermission},
+		{"expiry in past", constants.RoleAdmin, []string{constants.PermQueueRead}, &past, ErrExpiryInPast},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mockAPIKeyRepo)
+			uc := NewAPIKeyUsecase(repo, defaultPermissions{})
+
+			_, _, err := uc.Create(context.Background(), "sync", tc.scopes, tc.expires, "u1", tc.role)
+			assert.ErrorIs(t, err, tc.wantErr)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-10 * time.Second)
+	expired := now.Add(-time.Second)
+
+	tests := []struct {
+		name      string
+		stored    *entity.APIKey
+		wantErr   error
+		wantTouch bool
+	}{
+		{"active, first use", &entity.APIKey{BaseEntity: entity.BaseEntity{ID: "k1"}}, nil, true},
+		{"recently used", &entity.APIKey{BaseEntity: entity.BaseEntity{ID: "k1"}, LastUsedAt: &recent}, nil, false},
+		{"unknown", nil, ErrInvalidAPIKey, false},
+		{"revoked", &entity.APIKey{RevokedAt: &recent}, ErrInvalidAPIKey, false},
+		{"expired", &entity.APIKey{ExpiresAt: &expired}, ErrInvalidAPIKey, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mockAPIKeyRepo)
+			uc := NewAPIKeyUsecase(repo, defaultPermissions{}).(*apiKeyUsecase)
+			uc.now = func() time.Time { return now }
+
+			if tc.stored != nil {
+				repo.On("FindByHash", mock.Anything, hashToken("cpn_x")).Return(tc.stored, nil)
+			} else {
+				repo.On("FindByHash", mock.Anything, hashToken("cpn_x")).Return(nil, nil)
+			}
+			repo.On("TouchLastUsed", mock.Anything, "k1", now).Return(nil)
+
+			key, err := uc.Authenticate(context.Background(), "cpn_x")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, key)
+			}
+			if tc.wantTouch {
+				repo.AssertCalled(t, "TouchLastUsed", mock.Anything, "k1", now)
+			} else {
+				repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuthenticate_TouchFailureStillAuthenticates(t *testing.T) {
+	repo := new(mockAPIKeyRepo)
+	uc := NewAPIKeyUsecase(repo, defaultPermissions{})
+
+	repo.On("FindByHash", mock.Anything, mock.Anything).Return(&entity.APIKey{BaseEntity: entity.BaseEntity{ID: "k1"}}, nil)
+	repo.On("TouchLastUsed", mock.Anything, "k1", mock.Anything).Return(errors.New("db error"))
+
+	key, err := uc.Authenticate(context.Background(), "cpn_x")
+	assert.NoError(t, err)
+	assert.Nil(t, key.LastUsedAt)
+}
+
+func TestAPIKeyRevoke(t *testing.T) {
+	repo := new(mockAPIKeyRepo)
+	uc := NewAPIKeyUsecase(repo, defaultPermissions{})
+
+	repo.On("Revoke", mock.Anything, "k1").Return(true, nil)
+	repo.On("Revoke", mock.Anything, "k2").Return(false, nil)
+
+	assert.NoError(t, uc.Revoke(context.Background(), "k1"))
+	assert.ErrorIs(t, uc.Revoke(context.Background(), "k2"), ErrAPIKeyNotFound)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
+)
+
+// redactedFields are snapshot keys never written to the audit log, at any
+// depth, so a snapshot of a response cannot leak a secret.
+var redactedFields = map[string]bool{
+	"password":      true,
+	"key":           true,
+	"key_hash":      true,
+	"access_token":  true,
+	"refresh_token": true,
+	"secret":        true,
+}
+
+var ErrInvalidAuditRange = errors.New("from must be before to")
+
+// AuditUsecase defines the business logic for the audit log.
+type AuditUsecase interface {
+	// Record appends an entry. Sensitive fields are stripped from its snapshots.
+	Record(ctx context.Context, entry *entity.AuditEntry) error
+	List(ctx context.Context, filter repository.AuditFilter, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.AuditEntry], error)
+}
+
+type auditUsecase struct {
+	repo repository.AuditRepository
+}
+
+// NewAuditUsecase creates a new instance of AuditUsecase.
+func NewAuditUsecase(repo repository.AuditRepository) AuditUsecase {
+	return &auditUsecase{repo: repo}
+}
+
+func (u *auditUsecase) Record(ctx context.Context, entry *entity.AuditEntry) error {
+	if entry.Action == "" {
+		return errors.New("audit entry has no action")
+	}
+	entry.Before = redact(entry.Before)
+	entry.After = redact(entry.After)
+	return u.repo.Create(ctx, entry)
+}
+
+func (u *auditUsecase) List(ctx context.Context, filter repository.AuditFilter, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.AuditEntry], error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, ErrInvalidAuditRange
+	}
+	items, total, err := u.repo.GetPaginated(ctx, filter, pq.Page, pq.Limit)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewResult(items, pq.Page, pq.Limit, total)
+	return &result, nil
+}
+
+// redact returns a copy of snapshot without redactedFields.
+func redact(snapshot map[string]interface{}) map[string]interface{} {
+	if snapshot == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(snapshot))
+	for k, v := range snapshot {
+		if redactedFields[strings.ToLower(k)] {
+			continue
+		}
+		out[k] = redactValue(v)
+	}
+	return out
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return redact(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactValue(item)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ----- Mock AuditRepository -----
+
+type mockAuditRepo struct {
+	mock.Mock
+}
+
+func (m *mockAuditRepo) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *mockAuditRepo) GetPaginated(ctx context.Context, filter repository.AuditFilter, page, limit int) ([]*entity.AuditEntry, int64, error) {
+	args := m.Called(ctx, filter, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.AuditEntry), args.Get(1).(int64), args.Error(2)
+}
+
+// ----- Tests -----
+
+func TestAuditRecord_RedactsSecrets(t *testing.T) {
+	repo := new(mockAuditRepo)
+	uc := NewAuditUsecase(repo)
+
+	var stored *entity.AuditEntry
+	repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*entity.AuditEntry)
+	}).Return(nil)
+
+	err := uc.Record(context.Background(), &entity.AuditEntry{
+		Action: "apikey.create",
+		After: map[string]interface{}{
+			"id":   "k1",
+			"key":  "cpn_secret",
+			"name": "sync",
+			"nested": []interface{}{
+				map[string]interface{}{"Password": "hunter2", "role": "admin"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":     "k1",
+		"name":   "sync",
+		"nested": []interface{}{map[string]interface{}{"role": "admin"}},
+	}, stored.After)
+	assert.Nil(t, stored.Before)
+}
+
+func TestAuditRecord_RequiresAction(t *testing.T) {
+	repo := new(mockAuditRepo)
+	uc := NewAuditUsecase(repo)
+
+	assert.Error(t, uc.Record(context.Background(), &entity.AuditEntry{}))
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuditList(t *testing.T) {
+	repo := new(mockAuditRepo)
+	uc := NewAuditUsecase(repo)
+	filter := repository.AuditFilter{Action: "course.delete"}
+	entries := []*entity.AuditEntry{{ID: "a1", Action: "course.delete"}}
+	repo.On("GetPaginated", mock.Anything, filter, 2, 1).Return(entries, int64(3), nil)
+
+	result, err := uc.List(context.Background(), filter, pagination.FromQuery(2, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, entries, result.Items)
+	assert.Equal(t, int64(3), result.GetMeta().Total)
+}
+
+func TestAuditList_InvalidRange(t *testing.T) {
+	repo := new(mockAuditRepo)
+	uc := NewAuditUsecase(repo)
+	now := time.Now()
+
+	_, err := uc.List(context.Background(), repository.AuditFilter{From: now, To: now}, pagination.FromQuery(1, 10))
+	assert.ErrorIs(t, err, ErrInvalidAuditRange)
+	repo.AssertNotCalled(t, "GetPaginated", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
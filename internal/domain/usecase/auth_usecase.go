@@ -2,6 +2,10 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"log"
 	"time"
@@ -9,6 +13,7 @@ import (
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
@@ -19,40 +24,165 @@ var hashPassword = func(password []byte, cost int) ([]byte, error) {
 	return bcrypt.GenerateFromPassword(password, cost)
 }
 
-var signToken = func(token *jwt.Token, secret []byte) (string, error) {
-	return token.SignedString(secret)
+var signToken = func(keys *jwtkeys.KeySet, claims jwt.Claims) (string, error) {
+	return keys.Sign(claims)
+}
+
+var generateRefreshToken = func() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Default token lifetimes, used when NewAuthUsecase is given zero durations.
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// PasswordChangeTokenTTL is how long the one-time token returned with a
+// *PasswordChangeRequiredError stays valid.
+const PasswordChangeTokenTTL = 15 * time.Minute
+
+var (
+	ErrInvalidRefreshToken    = errors.New("invalid refresh token")
+	ErrSessionRevoked         = errors.New("session revoked")
+	ErrUserDisabled           = errors.New("user disabled")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrUsernameExists         = errors.New("username already exists")
+	ErrWrongPassword          = errors.New("current password is incorrect")
+	ErrSuperAdminProtected    = errors.New("superadmin accounts cannot be modified")
+	ErrSuperAdminRole         = errors.New("superadmin role cannot be assigned")
+	ErrSelfModification       = errors.New("cannot change your own role or status")
+	ErrMissingUsername        = errors.New("identity provider did not supply a username")
+	ErrAdminGrantDenied       = errors.New("creating admin users requires the " + constants.PermUserManage + " permission")
+	ErrPasswordChangeRequired = errors.New("password change required")
+)
+
+// PasswordChangeRequiredError is returned by Login, after the password has
+// been verified, for users who must choose a new password first. Token is
+// a one-time password reset token for POST /auth/password-reset; it is
+// empty when the usecase has no reset token store.
+type PasswordChangeRequiredError struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (e *PasswordChangeRequiredError) Error() string {
+	return ErrPasswordChangeRequired.Error()
+}
+
+func (e *PasswordChangeRequiredError) Is(target error) bool {
+	return target == ErrPasswordChangeRequired
 }
 
 // AuthUsecase defines the business logic for authentication.
 type AuthUsecase interface {
+	// Register returns a *PasswordPolicyError if password is too weak.
 	Register(ctx context.Context, username, password string, role string, callerRole *string) (*entity.User, error)
-	Login(ctx context.Context, username, password string) (string, error)
+	// Login returns a *LoginLockedError while username or clientIP is locked
+	// out, and a *PasswordChangeRequiredError instead of tokens for users
+	// flagged MustChangePassword.
+	Login(ctx context.Context, username, password, clientIP string) (*entity.AuthTokens, error)
+	// LoginExternal signs in the user linked to an external identity,
+	// provisioning it on first login and syncing its role on every login.
+	LoginExternal(ctx context.Context, externalID, username, role string) (*entity.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*entity.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	// ValidateSession reports whether an access token's user and session are
+	// still active; it returns ErrUserDisabled or ErrSessionRevoked otherwise.
+	ValidateSession(ctx context.Context, userID, sessionID string) error
 	SeedSuperAdmin(ctx context.Context, username, password string)
 	GetUsersPaginated(ctx context.Context, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.User], error)
+
+	// Self-service
+	GetProfile(ctx context.Context, userID string) (*entity.User, error)
+	UpdateProfile(ctx context.Context, userID, username string) (*entity.User, error)
+	// ChangePassword verifies oldPassword, checks newPassword against the
+	// password policy and revokes every session except keepSessionID.
+	ChangePassword(ctx context.Context, userID, keepSessionID, oldPassword, newPassword string) error
+
+	// Administration; callerID/callerRole identify the acting admin.
+	ChangeRole(ctx context.Context, callerID, callerRole, targetID, role string) (*entity.User, error)
+	SetDisabled(ctx context.Context, callerID, targetID string, disabled bool) (*entity.User, error)
+	DeleteUser(ctx context.Context, callerID, targetID string) error
 }
 
 type authUsecase struct {
-	repo      repository.UserRepository
-	jwtSecret []byte
+	repo       repository.UserRepository
+	sessions   repository.SessionRepository
+	keys       *jwtkeys.KeySet
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	throttle   LoginThrottle
+	perms      PermissionChecker
+	policy     PasswordPolicy
+	resets     repository.PasswordResetRepository
+	outbox     EventOutbox
+}
+
+// AuthOptions holds the optional dependencies of an AuthUsecase. Every
+// field may be left zero.
+type AuthOptions struct {
+	// Throttle limits failed logins; nil disables brute-force protection.
+	Throttle LoginThrottle
+	// Permissions resolves role permissions; nil uses
+	// constants.DefaultRolePermissions.
+	Permissions PermissionChecker
+	// Policy is the password policy; nil uses DefaultPasswordPolicy.
+	Policy *PasswordPolicy
+	// Resets stores the one-time tokens handed to users who must change
+	// their password; when nil those users cannot complete a login.
+	Resets repository.PasswordResetRepository
+	// Outbox records users created, updated or deleted in the same
+	// transaction as the change; nil records nothing. Password changes are
+	// not recorded.
+	Outbox EventOutbox
 }
 
-// NewAuthUsecase creates a new instance of AuthUsecase.
-func NewAuthUsecase(repo repository.UserRepository, jwtSecret string) AuthUsecase {
+// NewAuthUsecase creates a new instance of AuthUsecase. Zero TTLs fall back
+// to DefaultAccessTokenTTL and DefaultRefreshTokenTTL.
+func NewAuthUsecase(repo repository.UserRepository, sessions repository.SessionRepository, keys *jwtkeys.KeySet, accessTTL, refreshTTL time.Duration, opts AuthOptions) AuthUsecase {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
+	if opts.Permissions == nil {
+		opts.Permissions = defaultPermissions{}
+	}
+	if opts.Policy == nil {
+		opts.Policy = &DefaultPasswordPolicy
+	}
 	return &authUsecase{
-		repo:      repo,
-		jwtSecret: []byte(jwtSecret),
+		repo:       repo,
+		sessions:   sessions,
+		keys:       keys,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		throttle:   opts.Throttle,
+		perms:      opts.Permissions,
+		policy:     *opts.Policy,
+		resets:     opts.Resets,
+		outbox:     opts.Outbox,
 	}
 }
 
-// SeedSuperAdmin creates the default superadmin user if one doesn't already exist.
+// SeedSuperAdmin creates the default superadmin user if there is no
+// superadmin yet. It looks for any superadmin rather than the username, so
+// renaming the seeded account does not seed a second one. The seeded
+// password only works once: the first login must replace it.
 func (u *authUsecase) SeedSuperAdmin(ctx context.Context, username, password string) {
-	existing, err := u.repo.FindByUsername(ctx, username)
+	exists, err := u.repo.ExistsByRole(ctx, constants.RoleSuperAdmin)
 	if err != nil {
 		log.Printf("Warning: failed to check for existing superadmin: %v", err)
 		return
 	}
-	if existing != nil {
-		log.Printf("Superadmin user '%s' already exists, skipping seed", username)
+	if exists {
+		log.Printf("A superadmin user already exists, skipping seed of '%s'", username)
 		return
 	}
 
@@ -66,9 +196,11 @@ func (u *authUsecase) SeedSuperAdmin(ctx context.Context, username, password str
 		Username: username,
 		Password: string(hashed),
 		Role:     constants.RoleSuperAdmin,
+
+		MustChangePassword: true,
 	}
 
-	if err := u.repo.Create(ctx, user); err != nil {
+	if err := u.createUser(ctx, user); err != nil {
 		log.Printf("Warning: failed to seed superadmin: %v", err)
 		return
 	}
@@ -79,7 +211,7 @@ func (u *authUsecase) SeedSuperAdmin(ctx context.Context, username, password str
 func (u *authUsecase) Register(ctx context.Context, username, password string, role string, callerRole *string) (*entity.User, error) {
 	// Validate role
 	if !constants.ValidRoles[role] {
-		return nil, errors.New("invalid role")
+		return nil, ErrInvalidRole
 	}
 
 	// Prevent self-registration of superadmin
@@ -87,20 +219,27 @@ func (u *authUsecase) Register(ctx context.Context, username, password string, r
 		return nil, errors.New("superadmin cannot be created via registration")
 	}
 
-	// Creating admin requires caller to be superadmin or admin
+	// Creating admin requires a caller with user:manage
 	if role == constants.RoleAdmin {
-		if callerRole == nil || !constants.PrivilegedRoles[*callerRole] {
-			return nil, errors.New("only superadmin or admin can create admin users")
+		if callerRole == nil {
+			return nil, ErrAdminGrantDenied
+		}
+		if err := u.checkAdminGrant(ctx, *callerRole); err != nil {
+			return nil, err
 		}
 	}
 
+	if err := u.policy.Check(username, password); err != nil {
+		return nil, err
+	}
+
 	// Check if user already exists
 	existing, err := u.repo.FindByUsername(ctx, username)
 	if err != nil {
 		return nil, err
 	}
 	if existing != nil {
-		return nil, errors.New("username already exists")
+		return nil, ErrUsernameExists
 	}
 
 	// Hash password
@@ -115,43 +254,289 @@ func (u *authUsecase) Register(ctx context.Context, username, password string, r
 		Role:     role,
 	}
 
-	if err := u.repo.Create(ctx, user); err != nil {
+	if err := u.createUser(ctx, user); err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func (u *authUsecase) Login(ctx context.Context, username, password string) (string, error) {
+func (u *authUsecase) Login(ctx context.Context, username, password, clientIP string) (*entity.AuthTokens, error) {
+	// Checked before the password so a locked account cannot be confirmed
+	// by guessing correctly during the lockout.
+	if u.throttle != nil {
+		if err := u.throttle.Check(ctx, username, clientIP); err != nil {
+			return nil, err
+		}
+	}
+
 	user, err := u.repo.FindByUsername(ctx, username)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errors.New("invalid credentials")
+		return nil, u.loginFailed(ctx, username, clientIP)
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, u.loginFailed(ctx, username, clientIP)
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+	if user.MustChangePassword {
+		return nil, u.requirePasswordChange(ctx, user)
+	}
+
+	// Only a login that succeeds clears the failures, so a disabled or
+	// flagged account cannot be used to reset its counter.
+	if u.throttle != nil {
+		if err := u.throttle.Success(ctx, username); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.startSession(ctx, user)
+}
+
+// requirePasswordChange issues the one-time token a flagged user redeems,
+// with a new password, at POST /auth/password-reset.
+func (u *authUsecase) requirePasswordChange(ctx context.Context, user *entity.User) error {
+	if u.resets == nil {
+		return &PasswordChangeRequiredError{}
+	}
+	token, err := generateRefreshToken()
+	if err != nil {
+		return err
+	}
+	// Only the newest token is valid.
+	if err := u.resets.DeleteForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	reset := &entity.PasswordResetToken{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		CreatedBy: user.ID,
+		ExpiresAt: time.Now().Add(PasswordChangeTokenTTL),
+	}
+	if err := u.resets.Create(ctx, reset); err != nil {
+		return err
+	}
+	return &PasswordChangeRequiredError{Token: token, ExpiresAt: reset.ExpiresAt}
+}
+
+func (u *authUsecase) LoginExternal(ctx context.Context, externalID, username, role string) (*entity.AuthTokens, error) {
+	if !constants.ValidRoles[role] || role == constants.RoleSuperAdmin {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := u.repo.FindByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		if user, err = u.provisionExternal(ctx, externalID, username, role); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+
+	// The provider is the source of truth for SSO roles. Superadmin is only
+	// ever granted locally, so it is left alone.
+	if user.Role != role && user.Role != constants.RoleSuperAdmin {
+		user.Role = role
+		if err := u.updateUser(ctx, user); err != nil {
+			return nil, err
+		}
+		if err := u.revokeSessions(ctx, user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.startSession(ctx, user)
+}
+
+// provisionExternal creates the local user for a first SSO login. An
+// existing local account with the same username is never linked
+// automatically, since the provider cannot prove it owns that account.
+func (u *authUsecase) provisionExternal(ctx context.Context, externalID, username, role string) (*entity.User, error) {
+	if username == "" {
+		return nil, ErrMissingUsername
+	}
+	existing, err := u.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrUsernameExists
+	}
+
+	user := &entity.User{
+		Username:   username,
+		Role:       role,
+		ExternalID: externalID,
+	}
+	if err := u.createUser(ctx, user); err != nil {
+		return nil, err
+	}
+	log.Printf("[auth] provisioned user %s (%s) for %s", user.ID, username, externalID)
+	return user, nil
+}
+
+// startSession creates a refresh-token session for user and issues its tokens.
+func (u *authUsecase) startSession(ctx context.Context, user *entity.User) (*entity.AuthTokens, error) {
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &entity.Session{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(u.refreshTTL),
+	}
+	if err := u.sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return u.issueTokens(user, session.ID, refreshToken)
+}
+
+// loginFailed records a failed attempt and returns the error Login reports.
+func (u *authUsecase) loginFailed(ctx context.Context, username, clientIP string) error {
+	if u.throttle != nil {
+		if err := u.throttle.Failure(ctx, username, clientIP); err != nil {
+			return err
+		}
+	}
+	return errors.New("invalid credentials")
+}
+
+// Refresh exchanges a refresh token for a new token pair and rotates the
+// refresh token. Presenting an already-rotated token revokes the session,
+// since it means the token was copied.
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*entity.AuthTokens, error) {
+	hash := hashToken(refreshToken)
+	session, err := u.sessions.FindByTokenHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if session.TokenHash != hash {
+		log.Printf("[auth] refresh token reuse detected for session %s (user %s), revoking", session.ID, session.UserID)
+		if err := u.sessions.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if !session.IsActive(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := u.repo.FindByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Disabled {
+		if err := u.sessions.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		if user != nil {
+			return nil, ErrUserDisabled
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	next, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := u.sessions.Rotate(ctx, session.ID, hash, hashToken(next), time.Now().Add(u.refreshTTL))
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, ErrInvalidRefreshToken
 	}
 
-	// Generate JWT
+	return u.issueTokens(user, session.ID, next)
+}
+
+// Logout revokes the session behind refreshToken. Unknown tokens are ignored.
+func (u *authUsecase) Logout(ctx context.Context, refreshToken string) error {
+	session, err := u.sessions.FindByTokenHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return nil
+	}
+	return u.sessions.Revoke(ctx, session.ID)
+}
+
+func (u *authUsecase) ValidateSession(ctx context.Context, userID, sessionID string) error {
+	user, err := u.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrSessionRevoked
+	}
+	if user.Disabled {
+		return ErrUserDisabled
+	}
+
+	// Tokens issued before sessions existed carry no sid.
+	if sessionID == "" {
+		return ErrSessionRevoked
+	}
+	session, err := u.sessions.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID || !session.IsActive(time.Now()) {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// issueTokens signs a short-lived access token bound to sessionID.
+func (u *authUsecase) issueTokens(user *entity.User, sessionID, refreshToken string) (*entity.AuthTokens, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"sub":      user.ID,
+		"sid":      sessionID,
 		"username": user.Username,
 		"role":     user.Role,
-		"exp":      time.Now().Add(24 * time.Hour).Unix(),
-		"iat":      time.Now().Unix(),
+		"exp":      now.Add(u.accessTTL).Unix(),
+		"iat":      now.Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := signToken(token, u.jwtSecret)
+	signed, err := signToken(u.keys, claims)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return signed, nil
+	return &entity.AuthTokens{
+		AccessToken:  signed,
+		RefreshToken: refreshToken,
+		ExpiresIn:    u.accessTTL,
+	}, nil
+}
+
+// hashToken returns the hex SHA-256 of a refresh token. Refresh tokens are
+// 256 random bits, so a fast hash is sufficient.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func (u *authUsecase) GetUsersPaginated(ctx context.Context, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.User], error) {
@@ -162,3 +547,195 @@ func (u *authUsecase) GetUsersPaginated(ctx context.Context, pq pagination.Pagin
 	result := pagination.NewResult(items, pq.Page, pq.Limit, total)
 	return &result, nil
 }
+
+// ---------- Self-service ----------
+
+func (u *authUsecase) GetProfile(ctx context.Context, userID string) (*entity.User, error) {
+	user, err := u.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (u *authUsecase) UpdateProfile(ctx context.Context, userID, username string) (*entity.User, error) {
+	user, err := u.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if username == user.Username {
+		return user, nil
+	}
+
+	existing, err := u.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrUsernameExists
+	}
+
+	user.Username = username
+	if err := u.updateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *authUsecase) ChangePassword(ctx context.Context, userID, keepSessionID, oldPassword, newPassword string) error {
+	user, err := u.GetProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword)); err != nil {
+		return ErrWrongPassword
+	}
+	if err := u.policy.Check(user.Username, newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := hashPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashed)
+	user.MustChangePassword = false
+	if err := u.repo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	// Sign out every other device; the caller's session stays valid.
+	return u.revokeSessions(ctx, userID, keepSessionID)
+}
+
+// ---------- Administration ----------
+
+// loadTarget fetches the user an admin is acting on and applies the rules
+// shared by every admin operation: no acting on yourself, and superadmin
+// accounts are off limits.
+func (u *authUsecase) loadTarget(ctx context.Context, callerID, targetID string) (*entity.User, error) {
+	if callerID == targetID {
+		return nil, ErrSelfModification
+	}
+	user, err := u.GetProfile(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == constants.RoleSuperAdmin {
+		return nil, ErrSuperAdminProtected
+	}
+	return user, nil
+}
+
+func (u *authUsecase) ChangeRole(ctx context.Context, callerID, callerRole, targetID, role string) (*entity.User, error) {
+	// Same rules as Register: superadmin is never assignable and only
+	// callers with user:manage may grant admin.
+	if !constants.ValidRoles[role] {
+		return nil, ErrInvalidRole
+	}
+	if role == constants.RoleSuperAdmin {
+		return nil, ErrSuperAdminRole
+	}
+	if role == constants.RoleAdmin {
+		if err := u.checkAdminGrant(ctx, callerRole); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := u.loadTarget(ctx, callerID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	user.Role = role
+	if err := u.updateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	// Existing access tokens carry the old role; force a fresh login.
+	if err := u.revokeSessions(ctx, user.ID, ""); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *authUsecase) SetDisabled(ctx context.Context, callerID, targetID string, disabled bool) (*entity.User, error) {
+	user, err := u.loadTarget(ctx, callerID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Disabled = disabled
+	if err := u.updateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	if disabled {
+		if err := u.revokeSessions(ctx, user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+func (u *authUsecase) DeleteUser(ctx context.Context, callerID, targetID string) error {
+	user, err := u.loadTarget(ctx, callerID, targetID)
+	if err != nil {
+		return err
+	}
+	err = inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.Delete(ctx, user.ID); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.EventUserDeleted, user.ID, user)
+	})
+	if err != nil {
+		return err
+	}
+	return u.revokeSessions(ctx, user.ID, "")
+}
+
+// createUser stores a new user and records user.created with it.
+func (u *authUsecase) createUser(ctx context.Context, user *entity.User) error {
+	return inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.Create(ctx, user); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.EventUserCreated, user.ID, user)
+	})
+}
+
+// updateUser saves a change to a user's profile, role or disabled flag and
+// records user.updated with it.
+func (u *authUsecase) updateUser(ctx context.Context, user *entity.User) error {
+	return inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.EventUserUpdated, user.ID, user)
+	})
+}
+
+// checkAdminGrant returns ErrAdminGrantDenied unless callerRole holds user:manage.
+func (u *authUsecase) checkAdminGrant(ctx context.Context, callerRole string) error {
+	ok, err := u.perms.HasPermission(ctx, callerRole, constants.PermUserManage)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAdminGrantDenied
+	}
+	return nil
+}
+
+// revokeSessions revokes all of a user's sessions, optionally keeping one.
+func (u *authUsecase) revokeSessions(ctx context.Context, userID, keepSessionID string) error {
+	if keepSessionID == "" {
+		return u.sessions.RevokeAllForUser(ctx, userID)
+	}
+	return u.sessions.RevokeAllForUserExcept(ctx, userID, keepSessionID)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +35,27 @@ func (m *mockUserRepo) FindByUsername(ctx context.Context, username string) (*en
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *mockUserRepo) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *mockUserRepo) FindByExternalID(ctx context.Context, externalID string) (*entity.User, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *mockUserRepo) ExistsByRole(ctx context.Context, role string) (bool, error) {
+	args := m.Called(ctx, role)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *mockUserRepo) GetPaginated(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
 	args := m.Called(ctx, page, limit)
 	if args.Get(0) == nil {
@@ -41,14 +64,74 @@ func (m *mockUserRepo) GetPaginated(ctx context.Context, page, limit int) ([]*en
 	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *mockUserRepo) Update(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *mockUserRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// ----- Mock SessionRepository -----
+
+type mockSessionRepo struct {
+	mock.Mock
+}
+
+func (m *mockSessionRepo) Create(ctx context.Context, session *entity.Session) error {
+	args := m.Called(ctx, session)
+	session.ID = "s1"
+	return args.Error(0)
+}
+
+func (m *mockSessionRepo) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Session), args.Error(1)
+}
+
+func (m *mockSessionRepo) FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Session), args.Error(1)
+}
+
+func (m *mockSessionRepo) Rotate(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, oldHash, newHash, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockSessionRepo) Revoke(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepo) RevokeAllForUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepo) RevokeAllForUserExcept(ctx context.Context, userID, keepID string) error {
+	args := m.Called(ctx, userID, keepID)
+	return args.Error(0)
+}
+
 // ----- Tests -----
 
 func TestSeedSuperAdmin_Success(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	repo.On("FindByUsername", mock.Anything, "admin").Return(nil, nil)
-	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+	repo.On("ExistsByRole", mock.Anything, constants.RoleSuperAdmin).Return(false, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Role == constants.RoleSuperAdmin && u.MustChangePassword
+	})).Return(nil)
 
 	uc.SeedSuperAdmin(context.Background(), "admin", "pass")
 	repo.AssertExpectations(t)
@@ -56,9 +139,9 @@ func TestSeedSuperAdmin_Success(t *testing.T) {
 
 func TestSeedSuperAdmin_AlreadyExists(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	repo.On("FindByUsername", mock.Anything, "admin").Return(&entity.User{}, nil)
+	repo.On("ExistsByRole", mock.Anything, constants.RoleSuperAdmin).Return(true, nil)
 
 	uc.SeedSuperAdmin(context.Background(), "admin", "pass")
 	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
@@ -66,9 +149,9 @@ func TestSeedSuperAdmin_AlreadyExists(t *testing.T) {
 
 func TestSeedSuperAdmin_FindError(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	repo.On("FindByUsername", mock.Anything, "admin").Return(nil, errors.New("db error"))
+	repo.On("ExistsByRole", mock.Anything, constants.RoleSuperAdmin).Return(false, errors.New("db error"))
 
 	uc.SeedSuperAdmin(context.Background(), "admin", "pass")
 	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
@@ -76,9 +159,9 @@ func TestSeedSuperAdmin_FindError(t *testing.T) {
 
 func TestSeedSuperAdmin_CreateError(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	repo.On("FindByUsername", mock.Anything, "admin").Return(nil, nil)
+	repo.On("ExistsByRole", mock.Anything, constants.RoleSuperAdmin).Return(false, nil)
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(errors.New("create error"))
 
 	uc.SeedSuperAdmin(context.Background(), "admin", "pass")
@@ -93,9 +176,9 @@ func TestSeedSuperAdmin_HashError(t *testing.T) {
 	defer func() { hashPassword = orig }()
 
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	repo.On("FindByUsername", mock.Anything, "admin").Return(nil, nil)
+	repo.On("ExistsByRole", mock.Anything, constants.RoleSuperAdmin).Return(false, nil)
 
 	uc.SeedSuperAdmin(context.Background(), "admin", "pass")
 	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
@@ -103,88 +186,97 @@ func TestSeedSuperAdmin_HashError(t *testing.T) {
 
 func TestRegister_Success(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, nil)
 	repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
 		return u.Username == "user1" && u.Role == constants.RoleStudent
 	})).Return(nil)
 
-	user, err := uc.Register(context.Background(), "user1", "pass", constants.RoleStudent, nil)
+	user, err := uc.Register(context.Background(), "user1", "Str0ngPass", constants.RoleStudent, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 }
 
 func TestRegister_InvalidRole(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	_, err := uc.Register(context.Background(), "user1", "pass", "invalid_role", nil)
+	_, err := uc.Register(context.Background(), "user1", "Str0ngPass", "invalid_role", nil)
 	assert.EqualError(t, err, "invalid role")
 }
 
+func TestRegister_WeakPassword(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	_, err := uc.Register(context.Background(), "user1", "pass", constants.RoleStudent, nil)
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestRegister_SuperAdminSelfRegister(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
-	_, err := uc.Register(context.Background(), "super", "pass", "superadmin", nil)
+	_, err := uc.Register(context.Background(), "super", "Str0ngPass", "superadmin", nil)
 	assert.EqualError(t, err, "superadmin cannot be created via registration")
 }
 
 func TestRegister_CreateAdmin_Unauthorized(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	caller := "user"
-	_, err := uc.Register(context.Background(), "newadmin", "pass", "admin", &caller)
-	assert.EqualError(t, err, "only superadmin or admin can create admin users")
+	_, err := uc.Register(context.Background(), "newadmin", "Str0ngPass", "admin", &caller)
+	assert.ErrorIs(t, err, ErrAdminGrantDenied)
 
 	// No caller
-	_, err = uc.Register(context.Background(), "newadmin", "pass", "admin", nil)
-	assert.EqualError(t, err, "only superadmin or admin can create admin users")
+	_, err = uc.Register(context.Background(), "newadmin", "Str0ngPass", "admin", nil)
+	assert.ErrorIs(t, err, ErrAdminGrantDenied)
 }
 
 func TestRegister_CreateAdmin_Authorized(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "newadmin").Return(nil, nil)
 	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
 
 	caller := "superadmin"
-	user, err := uc.Register(context.Background(), "newadmin", "pass", "admin", &caller)
+	user, err := uc.Register(context.Background(), "newadmin", "Str0ngPass", "admin", &caller)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 }
 
 func TestRegister_UserAlreadyExists(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(&entity.User{}, nil)
 
-	_, err := uc.Register(context.Background(), "user1", "pass", constants.RoleStudent, nil)
+	_, err := uc.Register(context.Background(), "user1", "Str0ngPass", constants.RoleStudent, nil)
 	assert.EqualError(t, err, "username already exists")
 }
 
 func TestRegister_FindError(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, errors.New("db error"))
 
-	_, err := uc.Register(context.Background(), "user1", "pass", constants.RoleStudent, nil)
+	_, err := uc.Register(context.Background(), "user1", "Str0ngPass", constants.RoleStudent, nil)
 	assert.EqualError(t, err, "db error")
 }
 
 func TestRegister_CreateError(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, nil)
 	repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
 
-	_, err := uc.Register(context.Background(), "user1", "pass", constants.RoleStudent, nil)
+	_, err := uc.Register(context.Background(), "user1", "Str0ngPass", constants.RoleStudent, nil)
 	assert.EqualError(t, err, "db error")
 }
 
@@ -196,83 +288,215 @@ func TestRegister_HashError(t *testing.T) {
 	defer func() { hashPassword = orig }()
 
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, nil)
 
-	_, err := uc.Register(context.Background(), "user1", "pass", constants.RoleStudent, nil)
+	_, err := uc.Register(context.Background(), "user1", "Str0ngPass", constants.RoleStudent, nil)
 	assert.EqualError(t, err, "hash error")
 }
 
 func TestLogin_Success(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "user1", Password: string(hashed), Role: "user"}
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(user, nil)
+	sessions.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+		return s.UserID == "u1" && s.TokenHash != "" && s.ExpiresAt.After(time.Now().Add(6*24*time.Hour))
+	})).Return(nil)
+
+	tokens, err := uc.Login(context.Background(), "user1", "pass", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, DefaultAccessTokenTTL, tokens.ExpiresIn)
 
-	token, err := uc.Login(context.Background(), "user1", "pass")
+	// Access token is short-lived and bound to the session.
+	parsed, err := jwt.Parse(tokens.AccessToken, func(*jwt.Token) (interface{}, error) { return []byte("secret"), nil })
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
+	claims := parsed.Claims.(jwt.MapClaims)
+	assert.Equal(t, "s1", claims["sid"])
+	exp, _ := claims.GetExpirationTime()
+	assert.WithinDuration(t, time.Now().Add(DefaultAccessTokenTTL), exp.Time, 5*time.Second)
+}
+
+func TestLogin_DisabledUser(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "user1", Password: string(hashed), Disabled: true}
+
+	repo.On("FindByUsername", mock.Anything, "user1").Return(user, nil)
+
+	_, err := uc.Login(context.Background(), "user1", "pass", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrUserDisabled)
+	sessions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLogin_MustChangePassword(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	resets := newFakePasswordResetRepo()
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{Resets: resets})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("superadmin123"), bcrypt.MinCost)
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "admin", Password: string(hashed), Role: constants.RoleSuperAdmin, MustChangePassword: true}
+	repo.On("FindByUsername", mock.Anything, "admin").Return(user, nil)
+	repo.On("FindByID", mock.Anything, "u1").Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u1").Return(nil)
+
+	tokens, err := uc.Login(context.Background(), "admin", "superadmin123", "10.0.0.1")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, ErrPasswordChangeRequired)
+	var changeErr *PasswordChangeRequiredError
+	if !errors.As(err, &changeErr) {
+		t.Fatalf("expected *PasswordChangeRequiredError, got %T", err)
+	}
+	assert.NotEmpty(t, changeErr.Token)
+	assert.WithinDuration(t, time.Now().Add(PasswordChangeTokenTTL), changeErr.ExpiresAt, time.Minute)
+	sessions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// The token is redeemed like an admin-issued reset and clears the flag.
+	reset := NewPasswordResetUsecase(repo, sessions, resets, nil, nil, 0)
+	assert.NoError(t, reset.ResetPassword(context.Background(), changeErr.Token, "N3wPassword!x"))
+	assert.False(t, user.MustChangePassword)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("N3wPassword!x")))
 }
 
 func TestLogin_UserNotFound(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, nil)
 
-	_, err := uc.Login(context.Background(), "user1", "pass")
+	_, err := uc.Login(context.Background(), "user1", "pass", "10.0.0.1")
 	assert.EqualError(t, err, "invalid credentials")
 }
 
 func TestLogin_FindError(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, errors.New("db error"))
 
-	_, err := uc.Login(context.Background(), "user1", "pass")
+	_, err := uc.Login(context.Background(), "user1", "pass", "10.0.0.1")
 	assert.EqualError(t, err, "db error")
 }
 
 func TestLogin_WrongPassword(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "user1", Password: string(hashed), Role: "user"}
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(user, nil)
 
-	_, err := uc.Login(context.Background(), "user1", "wrongpass")
+	_, err := uc.Login(context.Background(), "user1", "wrongpass", "10.0.0.1")
 	assert.EqualError(t, err, "invalid credentials")
 }
 
 func TestLogin_SignTokenError(t *testing.T) {
 	orig := signToken
-	signToken = func(token *jwt.Token, secret []byte) (string, error) {
+	signToken = func(keys *jwtkeys.KeySet, claims jwt.Claims) (string, error) {
 		return "", errors.New("sign error")
 	}
 	defer func() { signToken = orig }()
 
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "user1", Password: string(hashed), Role: "user"}
 
 	repo.On("FindByUsername", mock.Anything, "user1").Return(user, nil)
+	sessions.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-	_, err := uc.Login(context.Background(), "user1", "pass")
+	_, err := uc.Login(context.Background(), "user1", "pass", "10.0.0.1")
 	assert.EqualError(t, err, "sign error")
 }
 
+func TestLoginExternal_ProvisionsUser(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByExternalID", mock.Anything, "https://idp|abc").Return(nil, nil)
+	repo.On("FindByUsername", mock.Anything, "somchai").Return(nil, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Username == "somchai" && u.Role == constants.RoleStudent && u.ExternalID == "https://idp|abc" && u.Password == ""
+	})).Return(nil)
+	sessions.On("Create", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil)
+
+	tokens, err := uc.LoginExternal(context.Background(), "https://idp|abc", "somchai", constants.RoleStudent)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	repo.AssertExpectations(t)
+}
+
+func TestLoginExternal_SyncsRole(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "somchai", Role: constants.RoleStudent, ExternalID: "https://idp|abc"}
+	repo.On("FindByExternalID", mock.Anything, "https://idp|abc").Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u1").Return(nil)
+	sessions.On("Create", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil)
+
+	_, err := uc.LoginExternal(context.Background(), "https://idp|abc", "somchai", constants.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RoleAdmin, user.Role)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	sessions.AssertExpectations(t)
+}
+
+func TestLoginExternal_Rules(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		linked   *entity.User
+		existing *entity.User
+		username string
+		wantErr  error
+	}{
+		{name: "superadmin never mapped", role: constants.RoleSuperAdmin, username: "a", wantErr: ErrInvalidRole},
+		{name: "username collision not linked", role: constants.RoleStudent, username: "a", existing: &entity.User{Username: "a"}, wantErr: ErrUsernameExists},
+		{name: "missing username", role: constants.RoleStudent, wantErr: ErrMissingUsername},
+		{name: "disabled", role: constants.RoleStudent, username: "a", linked: &entity.User{Role: constants.RoleStudent, Disabled: true}, wantErr: ErrUserDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockUserRepo)
+			sessions := new(mockSessionRepo)
+			uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+			repo.On("FindByExternalID", mock.Anything, "ext").Return(tt.linked, nil)
+			repo.On("FindByUsername", mock.Anything, tt.username).Return(tt.existing, nil)
+
+			_, err := uc.LoginExternal(context.Background(), "ext", tt.username, tt.role)
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			sessions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestGetUsersPaginated_Success(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	users := []*entity.User{{Username: "u1"}, {Username: "u2"}}
 	repo.On("GetPaginated", mock.Anything, 1, 10).Return(users, int64(2), nil)
@@ -285,10 +509,411 @@ func TestGetUsersPaginated_Success(t *testing.T) {
 
 func TestGetUsersPaginated_Error(t *testing.T) {
 	repo := new(mockUserRepo)
-	uc := NewAuthUsecase(repo, "secret")
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
 
 	repo.On("GetPaginated", mock.Anything, 1, 10).Return(nil, int64(0), errors.New("db error"))
 
 	_, err := uc.GetUsersPaginated(context.Background(), pagination.PaginationQuery{Page: 1, Limit: 10})
 	assert.EqualError(t, err, "db error")
 }
+
+// ----- Refresh / Logout / ValidateSession -----
+
+func activeSession(hash string) *entity.Session {
+	return &entity.Session{
+		BaseEntity: entity.BaseEntity{ID: "s1"},
+		UserID:     "u1",
+		TokenHash:  hash,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hash := hashToken("old")
+	sessions.On("FindByTokenHash", mock.Anything, hash).Return(activeSession(hash), nil)
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Role: constants.RoleAdmin}, nil)
+	sessions.On("Rotate", mock.Anything, "s1", hash, mock.AnythingOfType("string"), mock.Anything).Return(true, nil)
+
+	tokens, err := uc.Refresh(context.Background(), "old")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "old", tokens.RefreshToken)
+	assert.NotEmpty(t, tokens.AccessToken)
+
+	newHash := sessions.Calls[1].Arguments.String(3)
+	assert.Equal(t, hashToken(tokens.RefreshToken), newHash)
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(new(mockUserRepo), sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	sessions.On("FindByTokenHash", mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := uc.Refresh(context.Background(), "nope")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefresh_ReuseRevokesSession(t *testing.T) {
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(new(mockUserRepo), sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	sess := activeSession(hashToken("current"))
+	sess.PreviousTokenHash = hashToken("stolen")
+	sessions.On("FindByTokenHash", mock.Anything, hashToken("stolen")).Return(sess, nil)
+	sessions.On("Revoke", mock.Anything, "s1").Return(nil)
+
+	_, err := uc.Refresh(context.Background(), "stolen")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	sessions.AssertCalled(t, "Revoke", mock.Anything, "s1")
+}
+
+func TestRefresh_ExpiredOrRevoked(t *testing.T) {
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(new(mockUserRepo), sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hash := hashToken("old")
+	sess := activeSession(hash)
+	sess.ExpiresAt = time.Now().Add(-time.Minute)
+	sessions.On("FindByTokenHash", mock.Anything, hash).Return(sess, nil)
+
+	_, err := uc.Refresh(context.Background(), "old")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefresh_DisabledUser(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hash := hashToken("old")
+	sessions.On("FindByTokenHash", mock.Anything, hash).Return(activeSession(hash), nil)
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{Disabled: true}, nil)
+	sessions.On("Revoke", mock.Anything, "s1").Return(nil)
+
+	_, err := uc.Refresh(context.Background(), "old")
+	assert.ErrorIs(t, err, ErrUserDisabled)
+	sessions.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefresh_LostRotationRace(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hash := hashToken("old")
+	sessions.On("FindByTokenHash", mock.Anything, hash).Return(activeSession(hash), nil)
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{}, nil)
+	sessions.On("Rotate", mock.Anything, "s1", hash, mock.Anything, mock.Anything).Return(false, nil)
+
+	_, err := uc.Refresh(context.Background(), "old")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestLogout(t *testing.T) {
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(new(mockUserRepo), sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hash := hashToken("tok")
+	sessions.On("FindByTokenHash", mock.Anything, hash).Return(activeSession(hash), nil)
+	sessions.On("Revoke", mock.Anything, "s1").Return(nil)
+
+	assert.NoError(t, uc.Logout(context.Background(), "tok"))
+	sessions.AssertCalled(t, "Revoke", mock.Anything, "s1")
+}
+
+func TestLogout_UnknownTokenIsNoop(t *testing.T) {
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(new(mockUserRepo), sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	sessions.On("FindByTokenHash", mock.Anything, mock.Anything).Return(nil, nil)
+
+	assert.NoError(t, uc.Logout(context.Background(), "tok"))
+	sessions.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+}
+
+func TestValidateSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    *entity.User
+		session *entity.Session
+		sid     string
+		wantErr error
+	}{
+		{"active", &entity.User{}, activeSession("h"), "s1", nil},
+		{"disabled user", &entity.User{Disabled: true}, activeSession("h"), "s1", ErrUserDisabled},
+		{"deleted user", nil, activeSession("h"), "s1", ErrSessionRevoked},
+		{"no sid", &entity.User{}, nil, "", ErrSessionRevoked},
+		{"unknown session", &entity.User{}, nil, "s1", ErrSessionRevoked},
+		{"revoked session", &entity.User{}, func() *entity.Session {
+			s := activeSession("h")
+			now := time.Now()
+			s.RevokedAt = &now
+			return s
+		}(), "s1", ErrSessionRevoked},
+		{"other user's session", &entity.User{}, func() *entity.Session {
+			s := activeSession("h")
+			s.UserID = "u2"
+			return s
+		}(), "s1", ErrSessionRevoked},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mockUserRepo)
+			sessions := new(mockSessionRepo)
+			uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+			if tc.user == nil {
+				repo.On("FindByID", mock.Anything, "u1").Return(nil, nil)
+			} else {
+				repo.On("FindByID", mock.Anything, "u1").Return(tc.user, nil)
+			}
+			if tc.session == nil {
+				sessions.On("FindByID", mock.Anything, tc.sid).Return(nil, nil)
+			} else {
+				sessions.On("FindByID", mock.Anything, tc.sid).Return(tc.session, nil)
+			}
+
+			err := uc.ValidateSession(context.Background(), "u1", tc.sid)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// ----- Profile / user management -----
+
+func TestGetProfile_NotFound(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u1").Return(nil, nil)
+
+	_, err := uc.GetProfile(context.Background(), "u1")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestUpdateProfile_Success(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "old"}, nil)
+	repo.On("FindByUsername", mock.Anything, "new").Return(nil, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+
+	user, err := uc.UpdateProfile(context.Background(), "u1", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", user.Username)
+}
+
+func TestUpdateProfile_UsernameTaken(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{Username: "old"}, nil)
+	repo.On("FindByUsername", mock.Anything, "taken").Return(&entity.User{}, nil)
+
+	_, err := uc.UpdateProfile(context.Background(), "u1", "taken")
+	assert.ErrorIs(t, err, ErrUsernameExists)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProfile_RenamedSuperAdminIsNotReseeded(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, nil, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	admin := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "admin", Role: constants.RoleSuperAdmin}
+	repo.On("FindByID", mock.Anything, "u1").Return(admin, nil)
+	repo.On("FindByUsername", mock.Anything, "root").Return(nil, nil)
+	repo.On("FindByUsername", mock.Anything, "admin").Return(nil, nil).Maybe() // renamed away
+	repo.On("Update", mock.Anything, admin).Return(nil)
+	repo.On("ExistsByRole", mock.Anything, constants.RoleSuperAdmin).Return(true, nil)
+
+	_, err := uc.UpdateProfile(context.Background(), "u1", "root")
+	assert.NoError(t, err)
+
+	// The next restart seeds SUPER_ADMIN_USER "admin" again.
+	uc.SeedSuperAdmin(context.Background(), "admin", "pass")
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestChangePassword_Success(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("oldpass"), bcrypt.MinCost)
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Password: string(hashed), MustChangePassword: true}
+	repo.On("FindByID", mock.Anything, "u1").Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+	sessions.On("RevokeAllForUserExcept", mock.Anything, "u1", "s1").Return(nil)
+
+	err := uc.ChangePassword(context.Background(), "u1", "s1", "oldpass", "N3wPassword")
+	assert.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("N3wPassword")))
+	assert.False(t, user.MustChangePassword)
+	sessions.AssertExpectations(t)
+}
+
+func TestChangePassword_WrongOldPassword(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, new(mockSessionRepo), jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("oldpass"), bcrypt.MinCost)
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{Password: string(hashed)}, nil)
+
+	err := uc.ChangePassword(context.Background(), "u1", "s1", "wrong", "N3wPassword")
+	assert.ErrorIs(t, err, ErrWrongPassword)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestChangePassword_WeakPassword(t *testing.T) {
+	repo := new(mockUserRepo)
+	policy := PasswordPolicy{MinLength: 4, Blocklist: []string{"Hunter2"}}
+	uc := NewAuthUsecase(repo, new(mockSessionRepo), jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{Policy: &policy})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("oldpass"), bcrypt.MinCost)
+	repo.On("FindByID", mock.Anything, "u1").Return(&entity.User{Username: "somchai", Password: string(hashed)}, nil)
+
+	err := uc.ChangePassword(context.Background(), "u1", "s1", "oldpass", "hunter2")
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestChangeRole_Success(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Role: constants.RoleStudent}, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u2").Return(nil)
+
+	user, err := uc.ChangeRole(context.Background(), "u1", constants.RoleAdmin, "u2", constants.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RoleAdmin, user.Role)
+	sessions.AssertExpectations(t)
+}
+
+func TestChangeRole_Rules(t *testing.T) {
+	tests := []struct {
+		name       string
+		callerID   string
+		callerRole string
+		target     *entity.User
+		role       string
+		wantErr    error
+	}{
+		{"assign superadmin", "u1", constants.RoleSuperAdmin, nil, constants.RoleSuperAdmin, ErrSuperAdminRole},
+		{"invalid role", "u1", constants.RoleAdmin, nil, "root", ErrInvalidRole},
+		{"non-privileged grants admin", "u1", constants.RoleStudent, nil, constants.RoleAdmin, ErrAdminGrantDenied},
+		{"self", "u2", constants.RoleAdmin, nil, constants.RoleStudent, ErrSelfModification},
+		{"target is superadmin", "u1", constants.RoleAdmin, &entity.User{Role: constants.RoleSuperAdmin}, constants.RoleStudent, ErrSuperAdminProtected},
+		{"target missing", "u1", constants.RoleAdmin, nil, constants.RoleStudent, ErrUserNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mockUserRepo)
+			uc := NewAuthUsecase(repo, new(mockSessionRepo), jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+			if tc.target != nil {
+				repo.On("FindByID", mock.Anything, "u2").Return(tc.target, nil)
+			} else {
+				repo.On("FindByID", mock.Anything, "u2").Return(nil, nil)
+			}
+
+			_, err := uc.ChangeRole(context.Background(), tc.callerID, tc.callerRole, "u2", tc.role)
+			assert.ErrorIs(t, err, tc.wantErr)
+			repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSetDisabled_RevokesSessions(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}}, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u2").Return(nil)
+
+	user, err := uc.SetDisabled(context.Background(), "u1", "u2", true)
+	assert.NoError(t, err)
+	assert.True(t, user.Disabled)
+	sessions.AssertExpectations(t)
+}
+
+func TestSetDisabled_EnableKeepsSessions(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Disabled: true}, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+
+	user, err := uc.SetDisabled(context.Background(), "u1", "u2", false)
+	assert.NoError(t, err)
+	assert.False(t, user.Disabled)
+	sessions.AssertNotCalled(t, "RevokeAllForUser", mock.Anything, mock.Anything)
+}
+
+func TestDeleteUser(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}}, nil)
+	repo.On("Delete", mock.Anything, "u2").Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u2").Return(nil)
+
+	assert.NoError(t, uc.DeleteUser(context.Background(), "u1", "u2"))
+	repo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+}
+
+func TestUserMutations_RecordEvents(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	events := &captureOutbox{}
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{Outbox: events})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("oldpass"), bcrypt.MinCost)
+	target := &entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Username: "user2", Password: string(hashed), Role: constants.RoleStudent}
+	repo.On("FindByUsername", mock.Anything, "user1").Return(nil, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+	repo.On("FindByID", mock.Anything, "u2").Return(target, nil)
+	repo.On("Update", mock.Anything, target).Return(nil)
+	repo.On("Delete", mock.Anything, "u2").Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u2").Return(nil)
+	sessions.On("RevokeAllForUserExcept", mock.Anything, "u2", "s1").Return(nil)
+
+	_, err := uc.Register(context.Background(), "user1", "Str0ngPass", constants.RoleStudent, nil)
+	assert.NoError(t, err)
+	_, err = uc.SetDisabled(context.Background(), "u1", "u2", true)
+	assert.NoError(t, err)
+	assert.NoError(t, uc.ChangePassword(context.Background(), "u2", "s1", "oldpass", "N3wPassword"))
+	assert.NoError(t, uc.DeleteUser(context.Background(), "u1", "u2"))
+
+	assert.Equal(t, []string{constants.EventUserCreated, constants.EventUserUpdated, constants.EventUserDeleted}, events.events,
+		"password changes are not recorded")
+	assert.Equal(t, target, events.data[1])
+}
+
+func TestDeleteUser_Superadmin(t *testing.T) {
+	repo := new(mockUserRepo)
+	uc := NewAuthUsecase(repo, new(mockSessionRepo), jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+
+	repo.On("FindByID", mock.Anything, "u2").Return(&entity.User{Role: constants.RoleSuperAdmin}, nil)
+
+	err := uc.DeleteUser(context.Background(), "u1", "u2")
+	assert.ErrorIs(t, err, ErrSuperAdminProtected)
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
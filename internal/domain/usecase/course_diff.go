@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+)
+
+// closedNote is the section note the registrar uses for a closed section.
+const closedNote = "closed"
+
+// diffCourses lists what changed from old to updated: course information
+// first, then each section in updated's order, then removed sections.
+// Sections are matched by number, campus and program, as refreshes do when
+// preserving section IDs. A field updated holds no value for because the
+// upstream value was unparseable is not reported as changed.
+func diffCourses(old, updated *entity.Course) []entity.CourseChange {
+	unparsed := map[string]map[string]bool{} // section number -> fields
+	for _, v := range updated.Unparsed {
+		if unparsed[v.Section] == nil {
+			unparsed[v.Section] = map[string]bool{}
+		}
+		unparsed[v.Section][v.Field] = true
+	}
+
+	var changes []entity.CourseChange
+	for _, f := range []struct{ name, old, new string }{
+		{"name_en", old.NameEN, updated.NameEN},
+		{"name_th", old.NameTH, updated.NameTH},
+		{"credits", old.Credits, updated.Credits},
+		{"prerequisite", old.Prerequisite, updated.Prerequisite},
+	} {
+		if f.old != f.new {
+			changes = append(changes, entity.CourseChange{Kind: entity.CourseChangeInfo, Detail: f.name, Old: f.old, New: f.new})
+		}
+	}
+
+	matched := make([]bool, len(old.Sections))
+	for _, sec := range updated.Sections {
+		i := findSection(old.Sections, matched, sec)
+		if i < 0 {
+			changes = append(changes, entity.CourseChange{
+				Kind: entity.CourseChangeSectionAdded, SectionID: sec.ID, Section: sec.Number, New: sectionSummary(sec),
+			})
+			continue
+		}
+		matched[i] = true
+		changes = append(changes, diffSection(old.Sections[i], sec, unparsed[sec.Number])...)
+	}
+	for i, sec := range old.Sections {
+		if !matched[i] {
+			changes = append(changes, entity.CourseChange{
+				Kind: entity.CourseChangeSectionRemoved, SectionID: sec.ID, Section: sec.Number, Old: sectionSummary(sec),
+			})
+		}
+	}
+	return changes
+}
+
+func findSection(sections []entity.Section, taken []bool, sec entity.Section) int {
+	for i, s := range sections {
+		if !taken[i] && s.Number == sec.Number && s.Campus == sec.Campus && s.Program == sec.Program {
+			return i
+		}
+	}
+	return -1
+}
+
+// diffSection compares two versions of a section, skipping the fields
+// listed in unparsed.
+func diffSection(old, updated entity.Section, unparsed map[string]bool) []entity.CourseChange {
+	var changes []entity.CourseChange
+	add := func(kind entity.CourseChangeKind, detail, o, n string) {
+		changes = append(changes, entity.CourseChange{
+			Kind: kind, SectionID: updated.ID, Section: updated.Number, Detail: detail, Old: o, New: n,
+		})
+	}
+
+	oldMeetings, newMeetings := meetings(old.Schedules), meetings(updated.Schedules)
+	switch {
+	case unparsed["time"]:
+	case strings.Join(oldMeetings, "; ") != strings.Join(newMeetings, "; "):
+		add(entity.CourseChangeSchedule, "", formatSchedules(old.Schedules), formatSchedules(updated.Schedules))
+	default:
+		// Same meetings in the same order: compare rooms one by one.
+		oldSorted, newSorted := sortedSchedules(old.Schedules), sortedSchedules(updated.Schedules)
+		for i := range newSorted {
+			if oldSorted[i].Room != newSorted[i].Room {
+				add(entity.CourseChangeRoom, meeting(newSorted[i]), oldSorted[i].Room, newSorted[i].Room)
+			}
+		}
+	}
+
+	if o, n := formatInstructors(old.Instructor), formatInstructors(updated.Instructor); o != n {
+		add(entity.CourseChangeInstructor, "", o, n)
+	}
+	if old.Seats != updated.Seats {
+		add(entity.CourseChangeSeats, "", strconv.Itoa(old.Seats), strconv.Itoa(updated.Seats))
+	}
+	if o, n := formatExam(old.ExamStart, old.ExamEnd), formatExam(updated.ExamStart, updated.ExamEnd); o != n && !unparsed["exam_date"] {
+		add(entity.CourseChangeExam, "", o, n)
+	}
+	if o, n := formatExam(old.MidtermStart, old.MidtermEnd), formatExam(updated.MidtermStart, updated.MidtermEnd); o != n && !unparsed["midterm_date"] {
+		add(entity.CourseChangeMidterm, "", o, n)
+	}
+	if old.Note != updated.Note {
+		kind := entity.CourseChangeNote
+		if isClosed(updated.Note) && !isClosed(old.Note) {
+			kind = entity.CourseChangeSectionClosed
+		}
+		add(kind, "", old.Note, updated.Note)
+	}
+	return changes
+}
+
+func isClosed(note string) bool {
+	return strings.EqualFold(strings.TrimSpace(note), closedNote)
+}
+
+// sortedSchedules orders meetings by day and time so a reordered upstream
+// list does not count as a change.
+func sortedSchedules(schedules []entity.Schedule) []entity.Schedule {
+	sorted := append([]entity.Schedule(nil), schedules...)
+	sort.SliceStable(sorted, func(i, j int) bool { return meeting(sorted[i]) < meeting(sorted[j]) })
+	return sorted
+}
+
+// meetings returns the sorted meetings of schedules, without rooms.
+func meetings(schedules []entity.Schedule) []string {
+	out := make([]string, len(schedules))
+	for i, sc := range sortedSchedules(schedules) {
+		out[i] = meeting(sc)
+	}
+	return out
+}
+
+// meeting formats a slot's day, time and type, e.g. "MON 13:00-15:00 (C)".
+func meeting(sc entity.Schedule) string {
+	day := string(sc.Weekday)
+	if day == "" {
+		day = sc.Day
+	}
+	when := sc.StartTime.String() + "-" + sc.EndTime.String()
+	if sc.TBA {
+		when = "TBA"
+	}
+	return fmt.Sprintf("%s %s (%s)", day, when, sc.Type)
+}
+
+func formatSchedules(schedules []entity.Schedule) string {
+	parts := make([]string, len(schedules))
+	for i, sc := range sortedSchedules(schedules) {
+		parts[i] = meeting(sc)
+		if sc.Room != "" {
+			parts[i] += " " + sc.Room
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatInstructors(instructors []string) string {
+	sorted := append([]string(nil), instructors...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// formatExam formats an exam period as "31 มี.ค. 2569 13:00-16:00"; no exam
+// is "".
+func formatExam(start, end time.Time) string {
+	if start.IsZero() {
+		return ""
+	}
+	start, end = start.In(thaicalendar.Location), end.In(thaicalendar.Location)
+	return fmt.Sprintf("%s %s-%s", thaicalendar.FormatShort(start), start.Format("15:04"), end.Format("15:04"))
+}
+
+func sectionSummary(sec entity.Section) string {
+	parts := []string{"section " + sec.Number}
+	if s := formatSchedules(sec.Schedules); s != "" {
+		parts = append(parts, s)
+	}
+	if sec.Campus != "" {
+		parts = append(parts, sec.Campus)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+)
+
+func diffTestSchedule(day, start, end, room string) entity.Schedule {
+	s, _ := entity.ParseTimeOfDay(start)
+	e, _ := entity.ParseTimeOfDay(end)
+	return entity.NewSchedule(day, s, e, room, "C")
+}
+
+func diffTestCourse() *entity.Course {
+	exam := time.Date(2026, 3, 31, 13, 0, 0, 0, thaicalendar.Location)
+	return &entity.Course{
+		Code: "CP353004", Year: 2568, Semester: 1, NameEN: "Software Engineering", Credits: "3 (2-2-5)",
+		Sections: []entity.Section{
+			{
+				ID: "s1", Number: "01", Seats: 40, Instructor: []string{"B", "A"},
+				Schedules: []entity.Schedule{
+					diffTestSchedule("จันทร์", "13:00", "15:00", "CP9127"),
+					diffTestSchedule("พุธ", "09:00", "11:00", "CP9127"),
+				},
+				ExamStart: exam, ExamEnd: exam.Add(3 * time.Hour),
+			},
+			{ID: "s2", Number: "02", Seats: 40, Schedules: []entity.Schedule{diffTestSchedule("อังคาร", "13:00", "15:00", "CP9128")}},
+		},
+	}
+}
+
+func TestDiffCourses_NoChanges(t *testing.T) {
+	old, updated := diffTestCourse(), diffTestCourse()
+	// Reordered meetings and instructors are not changes.
+	updated.Sections[0].Schedules[0], updated.Sections[0].Schedules[1] = updated.Sections[0].Schedules[1], updated.Sections[0].Schedules[0]
+	updated.Sections[0].Instructor = []string{"A", "B"}
+
+	if changes := diffCourses(old, updated); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestDiffCourses_SectionChanges(t *testing.T) {
+	old, updated := diffTestCourse(), diffTestCourse()
+	updated.Credits = "3 (3-0-6)"
+	s1 := &updated.Sections[0]
+	s1.Schedules[1].Room = "SC2101"
+	s1.Instructor = []string{"A", "C"}
+	s1.Seats = 45
+	s1.ExamStart = s1.ExamStart.Add(24 * time.Hour)
+	s1.ExamEnd = s1.ExamEnd.Add(24 * time.Hour)
+	s1.Note = "Closed"
+	updated.Sections[1] = entity.Section{ID: "s3", Number: "03", Schedules: []entity.Schedule{diffTestSchedule("ศุกร์", "13:00", "15:00", "CP9128")}}
+
+	changes := diffCourses(old, updated)
+	want := []struct {
+		kind         entity.CourseChangeKind
+		section      string
+		detail, o, n string
+	}{
+		{entity.CourseChangeInfo, "", "credits", "3 (2-2-5)", "3 (3-0-6)"},
+		{entity.CourseChangeRoom, "01", "WED 09:00-11:00 (C)", "CP9127", "SC2101"},
+		{entity.CourseChangeInstructor, "01", "", "A, B", "A, C"},
+		{entity.CourseChangeSeats, "01", "", "40", "45"},
+		{entity.CourseChangeExam, "01", "", "31 มี.ค. 2569 13:00-16:00", "1 เม.ย. 2569 13:00-16:00"},
+		{entity.CourseChangeSectionClosed, "01", "", "", "Closed"},
+		{entity.CourseChangeSectionAdded, "03", "", "", "section 03, FRI 13:00-15:00 (C) CP9128"},
+		{entity.CourseChangeSectionRemoved, "02", "", "section 02, TUE 13:00-15:00 (C) CP9128", ""},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(want), len(changes), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Kind != w.kind || c.Section != w.section || c.Detail != w.detail || c.Old != w.o || c.New != w.n {
+			t.Errorf("change %d: expected %+v, got %+v", i, w, c)
+		}
+	}
+	if changes[1].SectionID != "s1" || changes[6].SectionID != "s3" || changes[7].SectionID != "s2" {
+		t.Errorf("expected section IDs on section changes, got %+v", changes)
+	}
+}
+
+func TestDiffCourses_ScheduleChange(t *testing.T) {
+	old, updated := diffTestCourse(), diffTestCourse()
+	updated.Sections[1].Schedules[0] = diffTestSchedule("อังคาร", "15:00", "17:00", "CP9130")
+	updated.Sections[1].Note = "ย้ายเวลา"
+
+	changes := diffCourses(old, updated)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if c := changes[0]; c.Kind != entity.CourseChangeSchedule || c.Old != "TUE 13:00-15:00 (C) CP9128" || c.New != "TUE 15:00-17:00 (C) CP9130" {
+		t.Errorf("unexpected schedule change: %+v", c)
+	}
+	if c := changes[1]; c.Kind != entity.CourseChangeNote || c.New != "ย้ายเวลา" {
+		t.Errorf("expected a plain note change, got %+v", c)
+	}
+}
+
+func TestDiffCourses_UnparsedValuesAreNotChanges(t *testing.T) {
+	old, updated := diffTestCourse(), diffTestCourse()
+	// The upstream changed its formats: section 01's Monday time and exam
+	// date no longer parse.
+	monday, err := entity.NewSchedules("จันทร์", "บ่ายโมง", "CP9127", "C")
+	if err == nil {
+		t.Fatal("expected the time not to parse")
+	}
+	s1 := &updated.Sections[0]
+	s1.Schedules[0] = monday[0]
+	s1.ExamStart, s1.ExamEnd = time.Time{}, time.Time{}
+	s1.Seats = 45
+	updated.Unparsed = []entity.UnparsedValue{
+		{Section: "01", Field: "time", Value: "บ่ายโมง", Err: err},
+		{Section: "01", Field: "exam_date", Value: "31 มี.ค. 69", Err: err},
+	}
+
+	changes := diffCourses(old, updated)
+	if len(changes) != 1 || changes[0].Kind != entity.CourseChangeSeats {
+		t.Errorf("expected only the seat change, got %+v", changes)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/queue"
 )
@@ -26,19 +27,44 @@ type CourseUsecase interface {
 	GetAllCourses(ctx context.Context) ([]*entity.Course, error)
 	GetCoursesPaginated(ctx context.Context, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.Course], error)
 	GetCourseByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error)
+	// FindCourse returns the stored course, or nil if there is none, without
+	// fetching or refreshing it from the external API.
+	FindCourse(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error)
 	DeleteCourse(ctx context.Context, code string, year, semester int) error
+	GetUnmappedValues(ctx context.Context) ([]*entity.UnmappedValue, error)
+	// GetCourseChanges lists the changes refreshes found in a course, newest first.
+	GetCourseChanges(ctx context.Context, filter repository.CourseChangeFilter, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.CourseChangeEvent], error)
+	// GetCourseVersions lists the versions a stored course has replaced,
+	// newest first.
+	GetCourseVersions(ctx context.Context, code string, acadyear, semester int, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.CourseVersion], error)
+	// GetCourseAsOf returns the course as it was stored at asOf, without
+	// fetching it from the external API.
+	GetCourseAsOf(ctx context.Context, code string, acadyear, semester int, asOf time.Time) (*entity.Course, error)
 	ProcessRefreshJob(job queue.RefreshJob)
+	// ProcessRefreshBatch processes jobs of one acadyear/semester with a
+	// single batch call to the external API.
+	ProcessRefreshBatch(jobs []queue.RefreshJob)
 }
 
 type courseUsecase struct {
 	repo         repository.CourseRepository
 	externalAPI  repository.CourseExternalAPI
 	refreshQueue *queue.RefreshQueue
+	unmapped     repository.UnmappedValueRepository
+	changes      repository.CourseChangeRepository
+	versions     repository.CourseVersionRepository
+	outbox       EventOutbox
 }
 
 // NewCourseUsecase creates a new instance of CourseUsecase.
-func NewCourseUsecase(repo repository.CourseRepository, extAPI repository.CourseExternalAPI, q *queue.RefreshQueue) CourseUsecase {
-	return &courseUsecase{repo: repo, externalAPI: extAPI, refreshQueue: q}
+// unmapped may be nil, in which case unrecognised and unparsed values are only logged.
+// changes may be nil, in which case no change history is kept.
+// versions may be nil, in which case no previous versions are listed.
+// outbox may be nil; otherwise courses created, changed by a refresh or
+// deleted are recorded in it in the same transaction as the change, and so
+// are the changes a refresh finds, which watchers are notified of.
+func NewCourseUsecase(repo repository.CourseRepository, extAPI repository.CourseExternalAPI, q *queue.RefreshQueue, unmapped repository.UnmappedValueRepository, changes repository.CourseChangeRepository, versions repository.CourseVersionRepository, outbox EventOutbox) CourseUsecase {
+	return &courseUsecase{repo: repo, externalAPI: extAPI, refreshQueue: q, unmapped: unmapped, changes: changes, versions: versions, outbox: outbox}
 }
 
 func (u *courseUsecase) CreateCourse(ctx context.Context, course *entity.Course) error {
@@ -49,7 +75,17 @@ func (u *courseUsecase) CreateCourse(ctx context.Context, course *entity.Course)
 	if existing != nil {
 		return errors.New("course already exists for this code/year/semester")
 	}
-	return u.repo.Create(ctx, course)
+	err = inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.Create(ctx, course); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.WebhookCourseCreated, course.ID, course)
+	})
+	if err != nil {
+		return err
+	}
+	u.reportUnmapped(ctx, course)
+	return nil
 }
 
 func (u *courseUsecase) GetAllCourses(ctx context.Context) ([]*entity.Course, error) {
@@ -111,14 +147,54 @@ func (u *courseUsecase) GetCourseByCode(ctx context.Context, code string, acadye
 	return course, nil
 }
 
+func (u *courseUsecase) FindCourse(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	return u.repo.GetByKey(ctx, strings.ToUpper(code), acadyear, semester)
+}
+
 // ProcessRefreshJob is called by worker pool goroutines to fetch and save course data.
 func (u *courseUsecase) ProcessRefreshJob(job queue.RefreshJob) {
-	defer u.refreshQueue.MarkDone(job.Key())
-
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
 	fetched, err := u.externalAPI.FetchByCode(ctx, job.Code, job.Acadyear, job.Semester)
+	u.completeRefresh(ctx, job, fetched, err)
+}
+
+func (u *courseUsecase) ProcessRefreshBatch(jobs []queue.RefreshJob) {
+	if len(jobs) == 1 {
+		u.ProcessRefreshJob(jobs[0])
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	codes := make([]string, len(jobs))
+	for i, job := range jobs {
+		codes[i] = job.Code
+	}
+	results, err := u.externalAPI.FetchByCodes(ctx, codes, jobs[0].Acadyear, jobs[0].Semester)
+	if err != nil {
+		log.Printf("[worker] batch fetch of %d courses for %d/%d failed: %v", len(jobs), jobs[0].Acadyear, jobs[0].Semester, err)
+	}
+	for _, job := range jobs {
+		if err != nil {
+			u.completeRefresh(ctx, job, nil, err)
+			continue
+		}
+		res, ok := results[job.Code]
+		if !ok {
+			res.Err = repository.ErrExternalCourseNotFound
+		}
+		u.completeRefresh(ctx, job, res.Course, res.Err)
+	}
+}
+
+// completeRefresh saves the outcome of fetching job's course, reports it to
+// a waiting caller and releases the job's queue slot.
+func (u *courseUsecase) completeRefresh(ctx context.Context, job queue.RefreshJob, fetched *entity.Course, err error) {
+	defer u.refreshQueue.MarkDone(job.Key())
+
 	if err != nil {
 		log.Printf("[worker] fetch failed for %s: %v", job.Key(), err)
 		if job.Result != nil {
@@ -126,11 +202,18 @@ func (u *courseUsecase) ProcessRefreshJob(job queue.RefreshJob) {
 		}
 		return
 	}
+	u.reportUnmapped(ctx, fetched)
 
 	if job.IsNew {
 		// First fetch — create new record.
 		fetched.CreatedAt = time.Now()
-		if saveErr := u.repo.Create(context.Background(), fetched); saveErr != nil {
+		saveErr := inTransaction(context.Background(), u.outbox, func(ctx context.Context) error {
+			if err := u.repo.Create(ctx, fetched); err != nil {
+				return err
+			}
+			return recordEvent(ctx, u.outbox, constants.WebhookCourseCreated, fetched.ID, fetched)
+		})
+		if saveErr != nil {
 			log.Printf("[worker] failed to save new course %s: %v", job.Key(), saveErr)
 			if job.Result != nil {
 				job.Result <- queue.JobResult{Err: saveErr}
@@ -143,6 +226,12 @@ func (u *courseUsecase) ProcessRefreshJob(job queue.RefreshJob) {
 		existing, getErr := u.repo.GetByKey(ctx, job.Code, job.Acadyear, job.Semester)
 		if getErr != nil || existing == nil {
 			log.Printf("[worker] could not find existing course %s for refresh: %v", job.Key(), getErr)
+			if job.Result != nil {
+				if getErr == nil {
+					getErr = ErrCourseNotFound
+				}
+				job.Result <- queue.JobResult{Err: getErr}
+			}
 			return
 		}
 		fetched.ID = existing.ID
@@ -161,11 +250,27 @@ func (u *courseUsecase) ProcessRefreshJob(job queue.RefreshJob) {
 			}
 		}
 
-		if saveErr := u.repo.Update(context.Background(), fetched); saveErr != nil {
+		changes := diffCourses(existing, fetched)
+		saveErr := inTransaction(context.Background(), u.outbox, func(ctx context.Context) error {
+			if err := u.repo.Update(ctx, fetched); err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				return nil
+			}
+			if err := recordEvent(ctx, u.outbox, constants.WebhookCourseUpdated, fetched.ID, fetched); err != nil {
+				return err
+			}
+			return u.recordChanges(ctx, fetched, changes)
+		})
+		if saveErr != nil {
 			log.Printf("[worker] failed to update course %s: %v", job.Key(), saveErr)
+			if job.Result != nil {
+				job.Result <- queue.JobResult{Err: saveErr}
+			}
 			return
 		}
-		log.Printf("[worker] course %s refreshed and saved", job.Key())
+		log.Printf("[worker] course %s refreshed and saved (%d changes)", job.Key(), len(changes))
 	}
 
 	// Send result back to caller if they're waiting.
@@ -174,6 +279,111 @@ func (u *courseUsecase) ProcessRefreshJob(job queue.RefreshJob) {
 	}
 }
 
+// reportUnmapped records schedule day/type values the normaliser could not map,
+// so admins can extend the alias tables, and upstream values that could not be
+// parsed at all. Failures are logged, never returned.
+func (u *courseUsecase) reportUnmapped(ctx context.Context, course *entity.Course) {
+	for _, v := range course.Unparsed {
+		log.Printf("[course] %s section %s: unparsed %s %q: %v", course.Code, v.Section, v.Field, v.Value, v.Err)
+	}
+	if u.unmapped == nil {
+		return
+	}
+	for _, v := range course.Unparsed {
+		if err := u.unmapped.Record(ctx, v.Field, v.Value, course.Code); err != nil {
+			log.Printf("[course] failed to record unparsed %s %q: %v", v.Field, v.Value, err)
+		}
+	}
+	for _, sec := range course.Sections {
+		for _, sc := range sec.Schedules {
+			for field, value := range sc.UnmappedFields() {
+				if err := u.unmapped.Record(ctx, field, value, course.Code); err != nil {
+					log.Printf("[course] failed to record unmapped %s %q: %v", field, value, err)
+				}
+			}
+		}
+	}
+}
+
+// recordChanges stores what a refresh changed in a course and records a
+// course.changed event carrying it, for the watchers to be notified. Pass
+// the refresh's transaction context so both are saved with the course.
+func (u *courseUsecase) recordChanges(ctx context.Context, fetched *entity.Course, changes []entity.CourseChange) error {
+	event := &entity.CourseChangeEvent{
+		CourseCode: fetched.Code,
+		Year:       fetched.Year,
+		Semester:   fetched.Semester,
+		Source:     fetched.Source,
+		Changes:    changes,
+	}
+	if u.changes != nil {
+		if err := u.changes.Create(ctx, event); err != nil {
+			return err
+		}
+	}
+	return recordEvent(ctx, u.outbox, constants.EventCourseChanged, fetched.ID, event)
+}
+
+func (u *courseUsecase) GetCourseChanges(ctx context.Context, filter repository.CourseChangeFilter, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.CourseChangeEvent], error) {
+	filter.CourseCode = strings.ToUpper(filter.CourseCode)
+	var (
+		items []*entity.CourseChangeEvent
+		total int64
+	)
+	if u.changes != nil {
+		var err error
+		items, total, err = u.changes.GetPaginated(ctx, filter, pq.Page, pq.Limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := pagination.NewResult(items, pq.Page, pq.Limit, total)
+	return &result, nil
+}
+
+func (u *courseUsecase) GetCourseVersions(ctx context.Context, code string, acadyear, semester int, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.CourseVersion], error) {
+	course, err := u.repo.GetByKey(ctx, strings.ToUpper(code), acadyear, semester)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	var (
+		items []*entity.CourseVersion
+		total int64
+	)
+	if u.versions != nil {
+		items, total, err = u.versions.GetVersions(ctx, course.ID, pq.Page, pq.Limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := pagination.NewResult(items, pq.Page, pq.Limit, total)
+	return &result, nil
+}
+
+func (u *courseUsecase) GetCourseAsOf(ctx context.Context, code string, acadyear, semester int, asOf time.Time) (*entity.Course, error) {
+	if u.versions == nil {
+		return nil, ErrCourseNotFound
+	}
+	course, err := u.versions.GetAsOf(ctx, strings.ToUpper(code), acadyear, semester, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	return course, nil
+}
+
+func (u *courseUsecase) GetUnmappedValues(ctx context.Context) ([]*entity.UnmappedValue, error) {
+	if u.unmapped == nil {
+		return []*entity.UnmappedValue{}, nil
+	}
+	return u.unmapped.GetAll(ctx)
+}
+
 // isToday checks whether t falls on the current calendar day (local time).
 func isToday(t time.Time) bool {
 	now := time.Now()
@@ -190,5 +400,10 @@ func (u *courseUsecase) DeleteCourse(ctx context.Context, code string, year, sem
 	if existing == nil {
 		return errors.New("course not found")
 	}
-	return u.repo.SoftDelete(ctx, code, year, semester)
+	return inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.SoftDelete(ctx, code, year, semester); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.WebhookCourseDeleted, existing.ID, existing)
+	})
 }
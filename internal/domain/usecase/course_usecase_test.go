@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/queue"
 )
@@ -28,7 +30,8 @@ type mockCourseRepo struct {
 // ----- mock CourseExternalAPI -----
 
 type mockExternalAPI struct {
-	fetchByCodeFunc func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error)
+	fetchByCodeFunc  func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error)
+	fetchByCodesFunc func(ctx context.Context, codes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error)
 }
 
 func (m *mockExternalAPI) FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
@@ -38,6 +41,13 @@ func (m *mockExternalAPI) FetchByCode(ctx context.Context, code string, acadyear
 	return nil, errors.New("mock external api not implemented")
 }
 
+func (m *mockExternalAPI) FetchByCodes(ctx context.Context, codes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	if m.fetchByCodesFunc != nil {
+		return m.fetchByCodesFunc(ctx, codes, acadyear, semester)
+	}
+	return nil, errors.New("mock external api not implemented")
+}
+
 func newMockCourseRepo() *mockCourseRepo {
 	return &mockCourseRepo{courses: make(map[string]*entity.Course)}
 }
@@ -79,6 +89,18 @@ func (m *mockCourseRepo) GetByKey(_ context.Context, code string, year, semester
 	return c, nil
 }
 
+func (m *mockCourseRepo) GetByID(_ context.Context, id string) (*entity.Course, error) {
+	if m.getByErr != nil {
+		return nil, m.getByErr
+	}
+	for _, c := range m.courses {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *mockCourseRepo) SoftDelete(_ context.Context, code string, year, semester int) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
@@ -95,11 +117,72 @@ func (m *mockCourseRepo) Update(_ context.Context, c *entity.Course) error {
 	return nil
 }
 
+// ----- mock CourseChangeRepository -----
+
+type mockCourseChangeRepo struct {
+	events    []*entity.CourseChangeEvent
+	createErr error
+	filter    repository.CourseChangeFilter
+}
+
+func (m *mockCourseChangeRepo) Create(_ context.Context, e *entity.CourseChangeEvent) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	m.events = append(m.events, e)
+	return nil
+}
+
+func (m *mockCourseChangeRepo) GetPaginated(_ context.Context, filter repository.CourseChangeFilter, page, limit int) ([]*entity.CourseChangeEvent, int64, error) {
+	m.filter = filter
+	return m.events, int64(len(m.events)), nil
+}
+
+// captureOutbox records events in memory. Its transactions just run fn;
+// err, when set, fails every Record.
+type captureOutbox struct {
+	events       []string
+	data         []interface{}
+	transactions int
+	err          error
+}
+
+func (o *captureOutbox) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	o.transactions++
+	return fn(ctx)
+}
+
+func (o *captureOutbox) Record(_ context.Context, eventType, _ string, data interface{}) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.events = append(o.events, eventType)
+	o.data = append(o.data, data)
+	return nil
+}
+
+type mockCourseVersionRepo struct {
+	versions []*entity.CourseVersion
+	asOf     *entity.Course
+	courseID string
+	asOfKey  string
+}
+
+func (m *mockCourseVersionRepo) GetVersions(_ context.Context, courseID string, page, limit int) ([]*entity.CourseVersion, int64, error) {
+	m.courseID = courseID
+	return m.versions, int64(len(m.versions)), nil
+}
+
+func (m *mockCourseVersionRepo) GetAsOf(_ context.Context, code string, year, semester int, asOf time.Time) (*entity.Course, error) {
+	m.asOfKey = mockKey(code, year, semester)
+	return m.asOf, nil
+}
+
 // ----- CreateCourse tests -----
 
 func TestCreateCourse_Success(t *testing.T) {
 	repo := newMockCourseRepo()
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	course := &entity.Course{Code: "CS101", Year: 2568, Semester: 1}
 	err := uc.CreateCourse(context.Background(), course)
@@ -111,11 +194,37 @@ func TestCreateCourse_Success(t *testing.T) {
 	}
 }
 
+func TestCreateCourse_RecordsEvent(t *testing.T) {
+	events := &captureOutbox{}
+	uc := NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, nil, nil, events)
+
+	course := &entity.Course{Code: "CS101", Year: 2568, Semester: 1}
+	if err := uc.CreateCourse(context.Background(), course); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(events.events) != 1 || events.events[0] != constants.WebhookCourseCreated || events.data[0] != course {
+		t.Errorf("expected course.created for the course, got %v", events.events)
+	}
+	if events.transactions != 1 {
+		t.Errorf("expected the course and its event in one transaction, got %d", events.transactions)
+	}
+}
+
+func TestCreateCourse_RecordFailure(t *testing.T) {
+	events := &captureOutbox{err: errors.New("outbox unavailable")}
+	uc := NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, nil, nil, events)
+
+	err := uc.CreateCourse(context.Background(), &entity.Course{Code: "CS101", Year: 2568, Semester: 1})
+	if err == nil || err.Error() != "outbox unavailable" {
+		t.Errorf("expected the outbox error so the transaction rolls back, got %v", err)
+	}
+}
+
 func TestCreateCourse_AlreadyExists(t *testing.T) {
 	repo := newMockCourseRepo()
 	c := &entity.Course{Code: "CS101", Year: 2568, Semester: 1}
 	repo.courses[c.Key()] = c
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	err := uc.CreateCourse(context.Background(), &entity.Course{Code: "CS101", Year: 2568, Semester: 1})
 	if err == nil {
@@ -126,7 +235,7 @@ func TestCreateCourse_AlreadyExists(t *testing.T) {
 func TestCreateCourse_RepoGetByKeyError(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.getByErr = errors.New("db error")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	err := uc.CreateCourse(context.Background(), &entity.Course{Code: "CS101", Year: 2568, Semester: 1})
 	if err == nil || err.Error() != "db error" {
@@ -137,7 +246,7 @@ func TestCreateCourse_RepoGetByKeyError(t *testing.T) {
 func TestCreateCourse_RepoCreateError(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.createErr = errors.New("insert failed")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	err := uc.CreateCourse(context.Background(), &entity.Course{Code: "CS101", Year: 2568, Semester: 1})
 	if err == nil || err.Error() != "insert failed" {
@@ -150,7 +259,7 @@ func TestCreateCourse_RepoCreateError(t *testing.T) {
 func TestGetAllCourses_Success(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.allCourses = []*entity.Course{{Code: "CS101"}, {Code: "CS102"}}
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	courses, err := uc.GetAllCourses(context.Background())
 	if err != nil {
@@ -164,7 +273,7 @@ func TestGetAllCourses_Success(t *testing.T) {
 func TestGetAllCourses_Error(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.getAllErr = errors.New("find failed")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	_, err := uc.GetAllCourses(context.Background())
 	if err == nil {
@@ -177,7 +286,7 @@ func TestGetAllCourses_Error(t *testing.T) {
 func TestGetCoursesPaginated_Success(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.allCourses = []*entity.Course{{Code: "CS101"}, {Code: "CS102"}, {Code: "CS103"}}
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	pq := pagination.PaginationQuery{Page: 1, Limit: 10}
 	result, err := uc.GetCoursesPaginated(context.Background(), pq)
@@ -198,7 +307,7 @@ func TestGetCoursesPaginated_Success(t *testing.T) {
 func TestGetCoursesPaginated_LimitZero(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.allCourses = []*entity.Course{{Code: "CS101"}}
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	pq := pagination.PaginationQuery{Page: 1, Limit: 0}
 	result, err := uc.GetCoursesPaginated(context.Background(), pq)
@@ -213,7 +322,7 @@ func TestGetCoursesPaginated_LimitZero(t *testing.T) {
 func TestGetCoursesPaginated_Error(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.pagErr = errors.New("paginate failed")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	pq := pagination.PaginationQuery{Page: 1, Limit: 10}
 	_, err := uc.GetCoursesPaginated(context.Background(), pq)
@@ -222,13 +331,35 @@ func TestGetCoursesPaginated_Error(t *testing.T) {
 	}
 }
 
+// ----- FindCourse tests -----
+
+func TestFindCourse(t *testing.T) {
+	repo := newMockCourseRepo()
+	c := &entity.Course{Code: "CS101", Year: 2568, Semester: 1, NameEN: "Intro CS"}
+	repo.courses[c.Key()] = c
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
+
+	course, err := uc.FindCourse(context.Background(), "cs101", 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course != c {
+		t.Error("expected the stored course")
+	}
+
+	course, err = uc.FindCourse(context.Background(), "NOPE", 2568, 1)
+	if err != nil || course != nil {
+		t.Errorf("expected nil, nil for a missing course, got %v, %v", course, err)
+	}
+}
+
 // ----- GetCourseByCode tests -----
 
 func TestGetCourseByCode_Found(t *testing.T) {
 	repo := newMockCourseRepo()
 	c := &entity.Course{Code: "CS101", Year: 2568, Semester: 1, NameEN: "Intro CS", BaseEntity: entity.BaseEntity{UpdatedAt: time.Now()}}
 	repo.courses[c.Key()] = c
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	course, err := uc.GetCourseByCode(context.Background(), "CS101", 2568, 1)
 	if err != nil {
@@ -241,7 +372,7 @@ func TestGetCourseByCode_Found(t *testing.T) {
 
 func TestGetCourseByCode_NotFound_NoExternal(t *testing.T) {
 	repo := newMockCourseRepo()
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	course, err := uc.GetCourseByCode(context.Background(), "NOPE", 2568, 1)
 	if !errors.Is(err, ErrCourseNotFound) {
@@ -255,7 +386,7 @@ func TestGetCourseByCode_NotFound_NoExternal(t *testing.T) {
 func TestGetCourseByCode_Error(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.getByErr = errors.New("db error")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	_, err := uc.GetCourseByCode(context.Background(), "CS101", 2568, 1)
 	if err == nil {
@@ -271,7 +402,7 @@ func TestGetCourseByCode_FetchNew_Success(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	// Start a worker that simulates success
 	q.Start(func(job queue.RefreshJob) {
@@ -292,7 +423,7 @@ func TestGetCourseByCode_FetchNew_AlreadyInFlight(t *testing.T) {
 	repo := newMockCourseRepo()
 	extAPI := &mockExternalAPI{}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	// Manually enqueue to block the key
 	q.Enqueue(queue.RefreshJob{Code: "BUSY", Acadyear: 2568, Semester: 1})
@@ -308,7 +439,7 @@ func TestGetCourseByCode_FetchNew_Error(t *testing.T) {
 	repo := newMockCourseRepo()
 	extAPI := &mockExternalAPI{}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	q.Start(func(job queue.RefreshJob) {
 		job.Result <- queue.JobResult{Err: errors.New("fetch failed")}
@@ -325,7 +456,7 @@ func TestGetCourseByCode_FetchNew_InvalidData(t *testing.T) {
 	repo := newMockCourseRepo()
 	extAPI := &mockExternalAPI{}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	q.Start(func(job queue.RefreshJob) {
 		// Return unexpected type
@@ -346,7 +477,7 @@ func TestGetCourseByCode_FetchNew_Timeout(t *testing.T) {
 	repo := newMockCourseRepo()
 	extAPI := &mockExternalAPI{}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	// Worker sleeps longer than 3s
 	q.Start(func(job queue.RefreshJob) {
@@ -372,7 +503,7 @@ func TestGetCourseByCode_RefreshesStale(t *testing.T) {
 
 	extAPI := &mockExternalAPI{}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	// Use a channel to detect if refresh was enqueued
 	refreshed := make(chan bool, 1)
@@ -407,7 +538,7 @@ func TestGetCourseByCode_Stale_NoRefreshConfigured(t *testing.T) {
 	repo.courses[c.Key()] = c
 
 	// No external API or Queue
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	course, err := uc.GetCourseByCode(context.Background(), "STALE", 2568, 1)
 	if err != nil {
@@ -429,7 +560,7 @@ func TestProcessRefreshJob_New_Success(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	resultCh := make(chan queue.JobResult, 1)
 	job := queue.RefreshJob{Code: "NEW", Acadyear: 2568, Semester: 1, IsNew: true, Result: resultCh}
@@ -461,7 +592,7 @@ func TestProcessRefreshJob_FetchError(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	resultCh := make(chan queue.JobResult, 1)
 	job := queue.RefreshJob{Code: "ERR", Acadyear: 2568, Semester: 1, IsNew: true, Result: resultCh}
@@ -484,7 +615,7 @@ func TestProcessRefreshJob_SaveError(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	resultCh := make(chan queue.JobResult, 1)
 	job := queue.RefreshJob{Code: "SAVE_ERR", Acadyear: 2568, Semester: 1, IsNew: true, Result: resultCh}
@@ -509,7 +640,7 @@ func TestProcessRefreshJob_Update_Success(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	job := queue.RefreshJob{Code: "EXIST", Acadyear: 2568, Semester: 1, IsNew: false}
 	q.Enqueue(job)
@@ -521,6 +652,218 @@ func TestProcessRefreshJob_Update_Success(t *testing.T) {
 	}
 }
 
+func TestProcessRefreshJob_Update_RecordsChanges(t *testing.T) {
+	repo := newMockCourseRepo()
+	existing := &entity.Course{Code: "EXIST", Year: 2568, Semester: 1, Sections: []entity.Section{{ID: "s1", Number: "01", Seats: 40}}}
+	repo.courses[existing.Key()] = existing
+	changes := &mockCourseChangeRepo{}
+
+	seats := 40
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{Code: code, Year: acadyear, Semester: semester, Source: "grpc", Sections: []entity.Section{{Number: "01", Seats: seats}}}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	events := &captureOutbox{}
+	uc := NewCourseUsecase(repo, extAPI, q, nil, changes, nil, events)
+
+	job := queue.RefreshJob{Code: "EXIST", Acadyear: 2568, Semester: 1}
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+	if len(changes.events) != 0 || len(events.events) != 0 {
+		t.Fatalf("expected no event for an unchanged course, got %+v", changes.events)
+	}
+
+	seats = 45
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+	if len(changes.events) != 1 {
+		t.Fatalf("expected 1 change event, got %d", len(changes.events))
+	}
+	e := changes.events[0]
+	if e.CourseCode != "EXIST" || e.Year != 2568 || e.Semester != 1 || e.Source != "grpc" || len(e.Changes) != 1 {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	if c := e.Changes[0]; c.Kind != entity.CourseChangeSeats || c.SectionID != "s1" || c.Old != "40" || c.New != "45" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+	if len(events.events) != 2 || events.events[1] != constants.EventCourseChanged || events.data[1] != e {
+		t.Errorf("expected course.changed to carry the recorded event, got %v", events.events)
+	}
+	if events.transactions != 2 {
+		t.Errorf("expected the changes to be recorded in the update's transaction, got %d transactions", events.transactions)
+	}
+}
+
+func TestProcessRefreshJob_Update_ChangeHistoryErrorFailsRefresh(t *testing.T) {
+	repo := newMockCourseRepo()
+	existing := &entity.Course{Code: "EXIST", Year: 2568, Semester: 1, Sections: []entity.Section{{ID: "s1", Number: "01", Seats: 40}}}
+	repo.courses[existing.Key()] = existing
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{Code: code, Year: acadyear, Semester: semester, Sections: []entity.Section{{Number: "01", Seats: 45}}}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	changes := &mockCourseChangeRepo{createErr: errors.New("db down")}
+	events := &captureOutbox{}
+	uc := NewCourseUsecase(repo, extAPI, q, nil, changes, nil, events)
+
+	resultCh := make(chan queue.JobResult, 1)
+	job := queue.RefreshJob{Code: "EXIST", Acadyear: 2568, Semester: 1, Result: resultCh}
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+
+	if res := <-resultCh; res.Err == nil {
+		t.Error("expected the refresh to fail with its change history")
+	}
+	if len(events.events) != 1 || events.events[0] != constants.WebhookCourseUpdated {
+		t.Errorf("expected no course.changed without its change history, got %v", events.events)
+	}
+}
+
+func TestProcessRefreshJob_RecordsEvents(t *testing.T) {
+	repo := newMockCourseRepo()
+	existing := &entity.Course{Code: "EXIST", Year: 2568, Semester: 1, Sections: []entity.Section{{ID: "s1", Number: "01", Seats: 40}}}
+	repo.courses[existing.Key()] = existing
+
+	seats := 40
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{Code: code, Year: acadyear, Semester: semester, Sections: []entity.Section{{Number: "01", Seats: seats}}}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	events := &captureOutbox{}
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, events)
+
+	job := queue.RefreshJob{Code: "EXIST", Acadyear: 2568, Semester: 1}
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+	if len(events.events) != 0 {
+		t.Fatalf("expected no event for an unchanged course, got %v", events.events)
+	}
+
+	seats = 45
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+	if len(events.events) != 2 || events.events[0] != constants.WebhookCourseUpdated || events.events[1] != constants.EventCourseChanged {
+		t.Fatalf("expected course.updated and course.changed, got %v", events.events)
+	}
+
+	newJob := queue.RefreshJob{Code: "NEW", Acadyear: 2568, Semester: 1, IsNew: true}
+	q.Enqueue(newJob)
+	uc.ProcessRefreshJob(newJob)
+	if len(events.events) != 3 || events.events[2] != constants.WebhookCourseCreated {
+		t.Errorf("expected course.created for a first fetch, got %v", events.events)
+	}
+}
+
+func TestProcessRefreshJob_Update_ReportsMissingCourse(t *testing.T) {
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{Code: code, Year: acadyear, Semester: semester}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	uc := NewCourseUsecase(newMockCourseRepo(), extAPI, q, nil, nil, nil, nil)
+
+	result := make(chan queue.JobResult, 1)
+	job := queue.RefreshJob{Code: "GONE", Acadyear: 2568, Semester: 1, Result: result}
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+
+	select {
+	case res := <-result:
+		if !errors.Is(res.Err, ErrCourseNotFound) {
+			t.Errorf("expected ErrCourseNotFound, got %v", res.Err)
+		}
+	default:
+		t.Fatal("expected a result for the waiting caller")
+	}
+}
+
+func TestProcessRefreshJob_New_RecordsNoChanges(t *testing.T) {
+	changes := &mockCourseChangeRepo{}
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{Code: code, Year: acadyear, Semester: semester}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	uc := NewCourseUsecase(newMockCourseRepo(), extAPI, q, nil, changes, nil, nil)
+
+	job := queue.RefreshJob{Code: "NEW", Acadyear: 2568, Semester: 1, IsNew: true}
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+	if len(changes.events) != 0 {
+		t.Errorf("expected a first fetch not to be diffed, got %+v", changes.events)
+	}
+}
+
+func TestGetCourseChanges(t *testing.T) {
+	changes := &mockCourseChangeRepo{events: []*entity.CourseChangeEvent{{CourseCode: "CP353004"}}}
+	uc := NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, changes, nil, nil)
+
+	result, err := uc.GetCourseChanges(context.Background(), repository.CourseChangeFilter{CourseCode: "cp353004", Year: 2568}, pagination.FromQuery(1, 10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Items) != 1 || result.Total != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if changes.filter.CourseCode != "CP353004" || changes.filter.Year != 2568 {
+		t.Errorf("expected an upper-cased code filter, got %+v", changes.filter)
+	}
+
+	result, err = NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, nil, nil, nil).GetCourseChanges(context.Background(), repository.CourseChangeFilter{CourseCode: "CP353004"}, pagination.FromQuery(1, 10))
+	if err != nil || len(result.Items) != 0 {
+		t.Errorf("expected an empty result without a change repository, got %+v, %v", result, err)
+	}
+}
+
+func TestGetCourseVersions(t *testing.T) {
+	repo := newMockCourseRepo()
+	repo.courses[mockKey("CP353004", 2568, 1)] = &entity.Course{BaseEntity: entity.BaseEntity{ID: "c1"}, Code: "CP353004", Year: 2568, Semester: 1, Version: 3}
+	versions := &mockCourseVersionRepo{versions: []*entity.CourseVersion{{CourseID: "c1", Version: 2}, {CourseID: "c1", Version: 1}}}
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, versions, nil)
+
+	result, err := uc.GetCourseVersions(context.Background(), "cp353004", 2568, 1, pagination.FromQuery(1, 10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Items) != 2 || result.Total != 2 || versions.courseID != "c1" {
+		t.Errorf("expected the versions of c1, got %+v (course %q)", result, versions.courseID)
+	}
+
+	if _, err := uc.GetCourseVersions(context.Background(), "CP999999", 2568, 1, pagination.FromQuery(1, 10)); !errors.Is(err, ErrCourseNotFound) {
+		t.Errorf("expected ErrCourseNotFound for an unknown course, got %v", err)
+	}
+}
+
+func TestGetCourseAsOf(t *testing.T) {
+	asOf := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	versions := &mockCourseVersionRepo{asOf: &entity.Course{Code: "CP353004", Version: 2}}
+	uc := NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, nil, versions, nil)
+
+	course, err := uc.GetCourseAsOf(context.Background(), "cp353004", 2568, 1, asOf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course.Version != 2 || versions.asOfKey != mockKey("CP353004", 2568, 1) {
+		t.Errorf("expected version 2 looked up by upper-cased code, got %+v (key %q)", course, versions.asOfKey)
+	}
+
+	versions.asOf = nil
+	if _, err := uc.GetCourseAsOf(context.Background(), "CP353004", 2568, 1, asOf); !errors.Is(err, ErrCourseNotFound) {
+		t.Errorf("expected ErrCourseNotFound when the course did not exist, got %v", err)
+	}
+	if _, err := NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, nil, nil, nil).GetCourseAsOf(context.Background(), "CP353004", 2568, 1, asOf); !errors.Is(err, ErrCourseNotFound) {
+		t.Errorf("expected ErrCourseNotFound without a version repository, got %v", err)
+	}
+}
+
 func TestProcessRefreshJob_Update_NotFound(t *testing.T) {
 	repo := newMockCourseRepo()
 	// Course NOT in repo
@@ -531,7 +874,7 @@ func TestProcessRefreshJob_Update_NotFound(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	job := queue.RefreshJob{Code: "MISSING", Acadyear: 2568, Semester: 1, IsNew: false}
 	q.Enqueue(job)
@@ -551,7 +894,7 @@ func TestProcessRefreshJob_Update_SaveError(t *testing.T) {
 		},
 	}
 	q := queue.New(10, 1)
-	uc := NewCourseUsecase(repo, extAPI, q)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
 
 	job := queue.RefreshJob{Code: "EXIST", Acadyear: 2568, Semester: 1, IsNew: false}
 	q.Enqueue(job)
@@ -559,13 +902,114 @@ func TestProcessRefreshJob_Update_SaveError(t *testing.T) {
 	// Expect log "failed to update course"
 }
 
+// ----- ProcessRefreshBatch Tests -----
+
+func TestProcessRefreshBatch_OneCallForAllJobs(t *testing.T) {
+	repo := newMockCourseRepo()
+	existing := &entity.Course{Code: "EXIST", Year: 2568, Semester: 1, NameEN: "Old Name"}
+	repo.courses[existing.Key()] = existing
+
+	calls := 0
+	extAPI := &mockExternalAPI{
+		fetchByCodesFunc: func(ctx context.Context, codes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+			calls++
+			return map[string]repository.CourseFetchResult{
+				"NEW":   {Course: &entity.Course{Code: "NEW", Year: acadyear, Semester: semester}},
+				"EXIST": {Course: &entity.Course{Code: "EXIST", Year: acadyear, Semester: semester, NameEN: "New Name"}},
+				"GONE":  {Err: repository.ErrExternalCourseNotFound},
+			}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	uc := NewCourseUsecase(repo, extAPI, q, nil, nil, nil, nil)
+
+	newCh := make(chan queue.JobResult, 1)
+	goneCh := make(chan queue.JobResult, 1)
+	jobs := []queue.RefreshJob{
+		{Code: "NEW", Acadyear: 2568, Semester: 1, IsNew: true, Result: newCh},
+		{Code: "EXIST", Acadyear: 2568, Semester: 1},
+		{Code: "GONE", Acadyear: 2568, Semester: 1, IsNew: true, Result: goneCh},
+	}
+	for _, job := range jobs {
+		q.Enqueue(job)
+	}
+	uc.ProcessRefreshBatch(jobs)
+
+	if calls != 1 {
+		t.Errorf("expected 1 batch call, got %d", calls)
+	}
+	if res := <-newCh; res.Err != nil || res.Data.(*entity.Course).Code != "NEW" {
+		t.Errorf("unexpected result for NEW: %+v", res)
+	}
+	if res := <-goneCh; !errors.Is(res.Err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("expected ErrExternalCourseNotFound for GONE, got %v", res.Err)
+	}
+	if updated, _ := repo.GetByKey(context.Background(), "EXIST", 2568, 1); updated.NameEN != "New Name" {
+		t.Errorf("expected EXIST to be refreshed, got %q", updated.NameEN)
+	}
+	if st := q.Status(); st.Processing != 0 || st.Processed != 3 {
+		t.Errorf("expected all jobs marked done, got %+v", st)
+	}
+}
+
+func TestProcessRefreshBatch_CallErrorFailsEveryJob(t *testing.T) {
+	extAPI := &mockExternalAPI{
+		fetchByCodesFunc: func(ctx context.Context, codes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+			return nil, errors.New("upstream down")
+		},
+	}
+	q := queue.New(10, 1)
+	uc := NewCourseUsecase(newMockCourseRepo(), extAPI, q, nil, nil, nil, nil)
+
+	chA := make(chan queue.JobResult, 1)
+	chB := make(chan queue.JobResult, 1)
+	jobs := []queue.RefreshJob{
+		{Code: "A", Acadyear: 2568, Semester: 1, IsNew: true, Result: chA},
+		{Code: "B", Acadyear: 2568, Semester: 1, IsNew: true, Result: chB},
+	}
+	for _, job := range jobs {
+		q.Enqueue(job)
+	}
+	uc.ProcessRefreshBatch(jobs)
+
+	if res := <-chA; res.Err == nil {
+		t.Error("expected error for A")
+	}
+	if res := <-chB; res.Err == nil {
+		t.Error("expected error for B")
+	}
+	if st := q.Status(); st.Processing != 0 {
+		t.Errorf("expected all jobs marked done, got %+v", st)
+	}
+}
+
+func TestProcessRefreshBatch_SingleJobUsesFetchByCode(t *testing.T) {
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{Code: code, Year: acadyear, Semester: semester}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	uc := NewCourseUsecase(newMockCourseRepo(), extAPI, q, nil, nil, nil, nil)
+
+	ch := make(chan queue.JobResult, 1)
+	job := queue.RefreshJob{Code: "ONE", Acadyear: 2568, Semester: 1, IsNew: true, Result: ch}
+	q.Enqueue(job)
+	uc.ProcessRefreshBatch([]queue.RefreshJob{job})
+
+	if res := <-ch; res.Err != nil {
+		t.Errorf("expected success, got %v", res.Err)
+	}
+}
+
 // ----- DeleteCourse tests -----
 
 func TestDeleteCourse_Success(t *testing.T) {
 	repo := newMockCourseRepo()
 	c := &entity.Course{Code: "CS101", Year: 2568, Semester: 1}
 	repo.courses[c.Key()] = c
-	uc := NewCourseUsecase(repo, nil, nil)
+	events := &captureOutbox{}
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, events)
 
 	err := uc.DeleteCourse(context.Background(), "CS101", 2568, 1)
 	if err != nil {
@@ -574,11 +1018,14 @@ func TestDeleteCourse_Success(t *testing.T) {
 	if _, ok := repo.courses[c.Key()]; ok {
 		t.Error("expected course to be deleted from repo")
 	}
+	if len(events.events) != 1 || events.events[0] != constants.WebhookCourseDeleted || events.data[0] != c {
+		t.Errorf("expected course.deleted for the deleted course, got %v", events.events)
+	}
 }
 
 func TestDeleteCourse_NotFound(t *testing.T) {
 	repo := newMockCourseRepo()
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	err := uc.DeleteCourse(context.Background(), "NOPE", 2568, 1)
 	if err == nil {
@@ -594,7 +1041,7 @@ func TestDeleteCourse_RepoError(t *testing.T) {
 	c := &entity.Course{Code: "CS101", Year: 2568, Semester: 1}
 	repo.courses[c.Key()] = c
 	repo.deleteErr = errors.New("delete failed")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	err := uc.DeleteCourse(context.Background(), "CS101", 2568, 1)
 	if err == nil || err.Error() != "delete failed" {
@@ -605,10 +1052,102 @@ func TestDeleteCourse_RepoError(t *testing.T) {
 func TestDeleteCourse_GetError(t *testing.T) {
 	repo := newMockCourseRepo()
 	repo.getByErr = errors.New("db error")
-	uc := NewCourseUsecase(repo, nil, nil)
+	uc := NewCourseUsecase(repo, nil, nil, nil, nil, nil, nil)
 
 	err := uc.DeleteCourse(context.Background(), "CS101", 2568, 1)
 	if err == nil || err.Error() != "db error" {
 		t.Errorf("expected 'db error', got %v", err)
 	}
 }
+
+// ----- unmapped value reporting tests -----
+
+type mockUnmappedRepo struct {
+	recorded map[string]string // field → value
+	getErr   error
+}
+
+func (m *mockUnmappedRepo) Record(_ context.Context, field, value, _ string) error {
+	if m.recorded == nil {
+		m.recorded = make(map[string]string)
+	}
+	m.recorded[field] = value
+	return nil
+}
+
+func (m *mockUnmappedRepo) GetAll(_ context.Context) ([]*entity.UnmappedValue, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	values := make([]*entity.UnmappedValue, 0, len(m.recorded))
+	for f, v := range m.recorded {
+		values = append(values, &entity.UnmappedValue{Field: f, Value: v, Count: 1})
+	}
+	return values, nil
+}
+
+func TestCreateCourse_ReportsUnmappedValues(t *testing.T) {
+	repo := newMockCourseRepo()
+	unmapped := &mockUnmappedRepo{}
+	uc := NewCourseUsecase(repo, nil, nil, unmapped, nil, nil, nil)
+
+	course := &entity.Course{
+		Code: "CS101", Year: 2568, Semester: 1,
+		Sections: []entity.Section{{
+			Schedules: []entity.Schedule{
+				entity.NewSchedule("จันทร์", entity.TimeOfDay{}, entity.TimeOfDay{}, "R1", "C"),
+				entity.NewSchedule("Mon-Wed", entity.TimeOfDay{}, entity.TimeOfDay{}, "R1", "Studio"),
+			},
+		}},
+	}
+	if err := uc.CreateCourse(context.Background(), course); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if unmapped.recorded["day"] != "Mon-Wed" {
+		t.Errorf("expected unmapped day 'Mon-Wed', got %q", unmapped.recorded["day"])
+	}
+	if unmapped.recorded["type"] != "Studio" {
+		t.Errorf("expected unmapped type 'Studio', got %q", unmapped.recorded["type"])
+	}
+
+	values, err := uc.GetUnmappedValues(context.Background())
+	if err != nil || len(values) != 2 {
+		t.Errorf("expected 2 unmapped values, got %d (err=%v)", len(values), err)
+	}
+}
+
+func TestGetUnmappedValues_NoRepo(t *testing.T) {
+	uc := NewCourseUsecase(newMockCourseRepo(), nil, nil, nil, nil, nil, nil)
+	values, err := uc.GetUnmappedValues(context.Background())
+	if err != nil || len(values) != 0 {
+		t.Errorf("expected empty result, got %v (err=%v)", values, err)
+	}
+}
+
+func TestProcessRefreshJob_ReportsUnparsedValues(t *testing.T) {
+	repo := newMockCourseRepo()
+	unmapped := &mockUnmappedRepo{}
+	extAPI := &mockExternalAPI{
+		fetchByCodeFunc: func(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+			return &entity.Course{
+				Code: code, Year: acadyear, Semester: semester,
+				Sections: []entity.Section{{Number: "01"}},
+				Unparsed: []entity.UnparsedValue{{Section: "01", Field: "exam_date", Value: "bad date string", Err: errors.New("invalid format")}},
+			}, nil
+		},
+	}
+	q := queue.New(10, 1)
+	uc := NewCourseUsecase(repo, extAPI, q, unmapped, nil, nil, nil)
+
+	job := queue.RefreshJob{Code: "CS101", Acadyear: 2568, Semester: 1, IsNew: true}
+	q.Enqueue(job)
+	uc.ProcessRefreshJob(job)
+
+	if c, _ := repo.GetByKey(context.Background(), "CS101", 2568, 1); c == nil || len(c.Sections) != 1 {
+		t.Fatalf("expected the course to be saved despite the unparsed value, got %+v", c)
+	}
+	if unmapped.recorded["exam_date"] != "bad date string" {
+		t.Errorf("expected the exam date to be reported, got %v", unmapped.recorded)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/scheduler"
 )
 
@@ -30,11 +31,14 @@ type CronJobUsecase interface {
 type cronJobUsecase struct {
 	repo      repository.CronJobRepository
 	scheduler CronScheduler
+	outbox    EventOutbox
 }
 
 // NewCronJobUsecase creates a new instance of CronJobUsecase.
-func NewCronJobUsecase(repo repository.CronJobRepository, sched CronScheduler) CronJobUsecase {
-	return &cronJobUsecase{repo: repo, scheduler: sched}
+// outbox may be nil; otherwise jobs created, updated or deleted are recorded
+// in it in the same transaction as the change.
+func NewCronJobUsecase(repo repository.CronJobRepository, sched CronScheduler, outbox EventOutbox) CronJobUsecase {
+	return &cronJobUsecase{repo: repo, scheduler: sched, outbox: outbox}
 }
 
 func (u *cronJobUsecase) CreateCronJob(ctx context.Context, job *entity.CronJob) error {
@@ -43,7 +47,13 @@ func (u *cronJobUsecase) CreateCronJob(ctx context.Context, job *entity.CronJob)
 		return errors.New("invalid cron expression: " + err.Error())
 	}
 
-	if err := u.repo.Create(ctx, job); err != nil {
+	err := inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.Create(ctx, job); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.EventCronJobCreated, job.ID, job)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -71,7 +81,13 @@ func (u *cronJobUsecase) UpdateCronJob(ctx context.Context, job *entity.CronJob)
 		return errors.New("invalid cron expression: " + err.Error())
 	}
 
-	if err := u.repo.Update(ctx, job); err != nil {
+	err := inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, job); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.EventCronJobUpdated, job.ID, job)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -84,7 +100,20 @@ func (u *cronJobUsecase) UpdateCronJob(ctx context.Context, job *entity.CronJob)
 }
 
 func (u *cronJobUsecase) DeleteCronJob(ctx context.Context, id string) error {
-	if err := u.repo.Delete(ctx, id); err != nil {
+	err := inTransaction(ctx, u.outbox, func(ctx context.Context) error {
+		existing, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return errors.New("cron job not found")
+		}
+		if err := u.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordEvent(ctx, u.outbox, constants.EventCronJobDeleted, id, existing)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -79,7 +80,7 @@ func (m *mockScheduler) TriggerJob(job *entity.CronJob) {
 func TestCreateCronJob_Success(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{
 		Name:       "Job1",
@@ -100,7 +101,7 @@ func TestCreateCronJob_Success(t *testing.T) {
 func TestCreateCronJob_InvalidCron(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "invalid"}
 
@@ -112,7 +113,7 @@ func TestCreateCronJob_InvalidCron(t *testing.T) {
 func TestCreateCronJob_RepoError(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "* * * * *"}
 	repo.On("Create", mock.Anything, job).Return(errors.New("db error"))
@@ -124,7 +125,7 @@ func TestCreateCronJob_RepoError(t *testing.T) {
 func TestCreateCronJob_SchedulerError(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "* * * * *", Enabled: true}
 	repo.On("Create", mock.Anything, job).Return(nil)
@@ -138,7 +139,7 @@ func TestCreateCronJob_SchedulerError(t *testing.T) {
 func TestGetAllCronJobs(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	repo.On("GetAll", mock.Anything).Return([]*entity.CronJob{}, nil)
 
@@ -149,7 +150,7 @@ func TestGetAllCronJobs(t *testing.T) {
 func TestGetCronJobByID(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	repo.On("GetByID", mock.Anything, "j1").Return(&entity.CronJob{}, nil)
 
@@ -160,7 +161,7 @@ func TestGetCronJobByID(t *testing.T) {
 func TestUpdateCronJob_Success(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "* * * * *"}
 	repo.On("Update", mock.Anything, job).Return(nil)
@@ -173,7 +174,7 @@ func TestUpdateCronJob_Success(t *testing.T) {
 func TestUpdateCronJob_InvalidCron(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "invalid"}
 
@@ -184,7 +185,7 @@ func TestUpdateCronJob_InvalidCron(t *testing.T) {
 func TestUpdateCronJob_RepoError(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "* * * * *"}
 	repo.On("Update", mock.Anything, job).Return(errors.New("db error"))
@@ -196,7 +197,7 @@ func TestUpdateCronJob_RepoError(t *testing.T) {
 func TestUpdateCronJob_SchedulerError(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{CronExpr: "* * * * *"}
 	repo.On("Update", mock.Anything, job).Return(nil)
@@ -209,8 +210,9 @@ func TestUpdateCronJob_SchedulerError(t *testing.T) {
 func TestDeleteCronJob(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
+	repo.On("GetByID", mock.Anything, "j1").Return(&entity.CronJob{BaseEntity: entity.BaseEntity{ID: "j1"}}, nil)
 	repo.On("Delete", mock.Anything, "j1").Return(nil)
 	sched.On("RemoveJob", "j1").Return()
 
@@ -218,11 +220,59 @@ func TestDeleteCronJob(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDeleteCronJob_NotFound(t *testing.T) {
+	repo := new(mockCronJobRepo)
+	sched := new(mockScheduler)
+	uc := NewCronJobUsecase(repo, sched, nil)
+
+	repo.On("GetByID", mock.Anything, "j1").Return(nil, nil)
+
+	err := uc.DeleteCronJob(context.Background(), "j1")
+	assert.EqualError(t, err, "cron job not found")
+	repo.AssertNotCalled(t, "Delete", mock.Anything, "j1")
+}
+
+func TestCronJobMutations_RecordEvents(t *testing.T) {
+	repo := new(mockCronJobRepo)
+	sched := new(mockScheduler)
+	events := &captureOutbox{}
+	uc := NewCronJobUsecase(repo, sched, events)
+
+	job := &entity.CronJob{BaseEntity: entity.BaseEntity{ID: "j1"}, CronExpr: "0 2 * * *"}
+	repo.On("Create", mock.Anything, job).Return(nil)
+	repo.On("Update", mock.Anything, job).Return(nil)
+	repo.On("GetByID", mock.Anything, "j1").Return(job, nil)
+	repo.On("Delete", mock.Anything, "j1").Return(nil)
+	sched.On("AddJob", job).Return(nil)
+	sched.On("RemoveJob", "j1").Return()
+
+	assert.NoError(t, uc.CreateCronJob(context.Background(), job))
+	assert.NoError(t, uc.UpdateCronJob(context.Background(), job))
+	assert.NoError(t, uc.DeleteCronJob(context.Background(), "j1"))
+
+	assert.Equal(t, []string{constants.EventCronJobCreated, constants.EventCronJobUpdated, constants.EventCronJobDeleted}, events.events)
+	assert.Equal(t, 3, events.transactions)
+}
+
+func TestUpdateCronJob_RecordFailure(t *testing.T) {
+	repo := new(mockCronJobRepo)
+	sched := new(mockScheduler)
+	uc := NewCronJobUsecase(repo, sched, &captureOutbox{err: errors.New("outbox unavailable")})
+
+	job := &entity.CronJob{BaseEntity: entity.BaseEntity{ID: "j1"}, CronExpr: "0 2 * * *"}
+	repo.On("Update", mock.Anything, job).Return(nil)
+
+	err := uc.UpdateCronJob(context.Background(), job)
+	assert.EqualError(t, err, "outbox unavailable")
+	sched.AssertNotCalled(t, "AddJob", job)
+}
+
 func TestDeleteCronJob_RepoError(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
+	repo.On("GetByID", mock.Anything, "j1").Return(&entity.CronJob{BaseEntity: entity.BaseEntity{ID: "j1"}}, nil)
 	repo.On("Delete", mock.Anything, "j1").Return(errors.New("db error"))
 
 	err := uc.DeleteCronJob(context.Background(), "j1")
@@ -232,7 +282,7 @@ func TestDeleteCronJob_RepoError(t *testing.T) {
 func TestTriggerCronJob_Success(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	job := &entity.CronJob{BaseEntity: entity.BaseEntity{ID: "j1"}}
 	repo.On("GetByID", mock.Anything, "j1").Return(job, nil)
@@ -245,7 +295,7 @@ func TestTriggerCronJob_Success(t *testing.T) {
 func TestTriggerCronJob_NotFound(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	repo.On("GetByID", mock.Anything, "j1").Return(nil, nil)
 
@@ -256,7 +306,7 @@ func TestTriggerCronJob_NotFound(t *testing.T) {
 func TestTriggerCronJob_RepoError(t *testing.T) {
 	repo := new(mockCronJobRepo)
 	sched := new(mockScheduler)
-	uc := NewCronJobUsecase(repo, sched)
+	uc := NewCronJobUsecase(repo, sched, nil)
 
 	repo.On("GetByID", mock.Anything, "j1").Return(nil, errors.New("db error"))
 
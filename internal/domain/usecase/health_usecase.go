@@ -8,19 +8,38 @@ import (
 
 // HealthUsecase defines the interface for health-related business logic.
 type HealthUsecase interface {
+	// GetStatus reports "ok", or "degraded" while the course API circuit
+	// breaker is not closed or its gRPC connection is failing. The service
+	// is not ready while the breaker is open or the connection is failing.
 	GetStatus() entity.Health
 }
 
-type healthUsecase struct{}
+type healthUsecase struct {
+	courseAPI func() entity.UpstreamHealth
+}
 
 // NewHealthUsecase creates a new HealthUsecase instance.
-func NewHealthUsecase() HealthUsecase {
-	return &healthUsecase{}
+// courseAPI may be nil, in which case the course API is not reported.
+func NewHealthUsecase(courseAPI func() entity.UpstreamHealth) HealthUsecase {
+	return &healthUsecase{courseAPI: courseAPI}
 }
 
 func (u *healthUsecase) GetStatus() entity.Health {
-	return entity.Health{
+	h := entity.Health{
 		Status:    "ok",
+		Ready:     true,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}
+	if u.courseAPI != nil {
+		upstream := u.courseAPI()
+		h.CourseAPI = &upstream
+		connFailing := upstream.Connection == "TRANSIENT_FAILURE" || upstream.Connection == "SHUTDOWN"
+		if upstream.Breaker != "closed" || connFailing {
+			h.Status = "degraded"
+		}
+		if upstream.Breaker == "open" || connFailing {
+			h.Ready = false
+		}
+	}
+	return h
 }
@@ -4,16 +4,18 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 )
 
 func TestHealthUsecase_GetStatus(t *testing.T) {
-	uc := NewHealthUsecase()
+	uc := NewHealthUsecase(nil)
 	before := time.Now().UTC()
 
 	status := uc.GetStatus()
 
-	if status.Status != "ok" {
-		t.Errorf("expected status='ok', got %q", status.Status)
+	if status.Status != "ok" || !status.Ready {
+		t.Errorf("expected status='ok' and ready, got %+v", status)
 	}
 
 	// Verify timestamp is valid RFC3339 and recent
@@ -26,6 +28,44 @@ func TestHealthUsecase_GetStatus(t *testing.T) {
 	}
 }
 
+func TestHealthUsecase_GetStatus_CourseAPI(t *testing.T) {
+	upstream := entity.UpstreamHealth{Breaker: "closed", MaxConcurrent: 5}
+	uc := NewHealthUsecase(func() entity.UpstreamHealth { return upstream })
+
+	status := uc.GetStatus()
+	if status.Status != "ok" || status.CourseAPI == nil || status.CourseAPI.MaxConcurrent != 5 {
+		t.Errorf("unexpected status with a closed breaker: %+v", status)
+	}
+
+	upstream.Breaker = "open"
+	if status := uc.GetStatus(); status.Status != "degraded" || status.Ready {
+		t.Errorf("expected degraded and not ready with an open breaker, got %+v", status)
+	}
+
+	upstream.Breaker = "half-open"
+	if status := uc.GetStatus(); status.Status != "degraded" || !status.Ready {
+		t.Errorf("expected degraded but ready with a half-open breaker, got %+v", status)
+	}
+}
+
+func TestHealthUsecase_GetStatus_GRPCConnection(t *testing.T) {
+	upstream := entity.UpstreamHealth{Breaker: "closed"}
+	uc := NewHealthUsecase(func() entity.UpstreamHealth { return upstream })
+
+	for _, state := range []string{"IDLE", "CONNECTING", "READY"} {
+		upstream.Connection = state
+		if status := uc.GetStatus(); status.Status != "ok" || !status.Ready {
+			t.Errorf("%s: expected ok and ready, got %+v", state, status)
+		}
+	}
+	for _, state := range []string{"TRANSIENT_FAILURE", "SHUTDOWN"} {
+		upstream.Connection = state
+		if status := uc.GetStatus(); status.Status != "degraded" || status.Ready {
+			t.Errorf("%s: expected degraded and not ready, got %+v", state, status)
+		}
+	}
+}
+
 func TestVersionUsecase_GetVersion(t *testing.T) {
 	uc := NewVersionUsecase("my-app", "1.2.3", "production")
 
@@ -53,7 +93,7 @@ func TestVersionUsecase_EmptyValues(t *testing.T) {
 }
 
 func TestHealthUsecase_TimestampFormat(t *testing.T) {
-	uc := NewHealthUsecase()
+	uc := NewHealthUsecase(nil)
 	status := uc.GetStatus()
 
 	// Should contain "T" separator (RFC3339)
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+// Lockout scopes reported by LoginLockedError.
+const (
+	LockScopeAccount = "account"
+	LockScopeClient  = "client"
+)
+
+// LoginLockedError is returned by Login while the username or client IP is
+// locked out. It deliberately says nothing about whether the account exists:
+// unknown usernames are counted and locked exactly like real ones.
+type LoginLockedError struct {
+	Scope      string // LockScopeAccount or LockScopeClient
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts; retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// LoginThrottleConfig controls brute-force protection on login. Each time a
+// key reaches its failure limit within Window it is locked for BaseLockout,
+// doubling on every further lockout up to MaxLockout.
+type LoginThrottleConfig struct {
+	MaxFailures      int // per username; 0 disables the check
+	MaxFailuresPerIP int // per client IP; 0 disables the check
+	Window           time.Duration
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+}
+
+// LoginThrottle tracks failed logins per username and per client IP.
+type LoginThrottle interface {
+	// Check returns a *LoginLockedError if either key is currently locked.
+	Check(ctx context.Context, username, clientIP string) error
+	// Failure records a failed attempt, locking keys that reach their limit.
+	Failure(ctx context.Context, username, clientIP string) error
+	// Success clears the username's counter. The IP counter is kept so one
+	// valid account cannot be used to reset guessing against others.
+	Success(ctx context.Context, username string) error
+}
+
+type loginThrottle struct {
+	store repository.LoginAttemptRepository
+	cfg   LoginThrottleConfig
+	now   func() time.Time
+}
+
+// NewLoginThrottle creates a LoginThrottle backed by store.
+func NewLoginThrottle(store repository.LoginAttemptRepository, cfg LoginThrottleConfig) LoginThrottle {
+	return &loginThrottle{store: store, cfg: cfg, now: time.Now}
+}
+
+type throttleKey struct {
+	key   string
+	scope string
+	limit int
+}
+
+func (t *loginThrottle) keys(username, clientIP string) []throttleKey {
+	var keys []throttleKey
+	if t.cfg.MaxFailures > 0 && username != "" {
+		keys = append(keys, throttleKey{"user:" + username, LockScopeAccount, t.cfg.MaxFailures})
+	}
+	if t.cfg.MaxFailuresPerIP > 0 && clientIP != "" {
+		keys = append(keys, throttleKey{"ip:" + clientIP, LockScopeClient, t.cfg.MaxFailuresPerIP})
+	}
+	return keys
+}
+
+func (t *loginThrottle) Check(ctx context.Context, username, clientIP string) error {
+	now := t.now()
+	for _, k := range t.keys(username, clientIP) {
+		a, err := t.store.Get(ctx, k.key)
+		if err != nil {
+			return err
+		}
+		if a != nil && a.IsLocked(now) {
+			return &LoginLockedError{Scope: k.scope, RetryAfter: a.LockedUntil.Sub(now)}
+		}
+	}
+	return nil
+}
+
+func (t *loginThrottle) Failure(ctx context.Context, username, clientIP string) error {
+	now := t.now()
+	for _, k := range t.keys(username, clientIP) {
+		a, err := t.store.RecordFailure(ctx, k.key, now, t.cfg.Window, t.expiry(now))
+		if err != nil {
+			return err
+		}
+		if a.Failures < k.limit {
+			continue
+		}
+		until := now.Add(t.lockoutFor(a.Lockouts))
+		if err := t.store.Lock(ctx, k.key, until, t.expiry(until)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *loginThrottle) Success(ctx context.Context, username string) error {
+	if username == "" {
+		return nil
+	}
+	return t.store.Reset(ctx, "user:"+username)
+}
+
+// lockoutFor returns the lockout applied after the given number of earlier
+// lockouts: BaseLockout * 2^n, capped at MaxLockout.
+func (t *loginThrottle) lockoutFor(previous int) time.Duration {
+	d := t.cfg.BaseLockout
+	for i := 0; i < previous && d < t.cfg.MaxLockout; i++ {
+		d *= 2
+	}
+	if t.cfg.MaxLockout > 0 && d > t.cfg.MaxLockout {
+		d = t.cfg.MaxLockout
+	}
+	return d
+}
+
+// expiry keeps a counter long enough that a key that keeps failing after
+// its lockout ends is still escalated rather than starting over.
+func (t *loginThrottle) expiry(from time.Time) time.Time {
+	return from.Add(t.cfg.Window + t.cfg.MaxLockout)
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/repository/memory"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestThrottle(now *time.Time) *loginThrottle {
+	t := NewLoginThrottle(memory.NewLoginAttemptRepository(), LoginThrottleConfig{
+		MaxFailures:      3,
+		MaxFailuresPerIP: 5,
+		Window:           10 * time.Minute,
+		BaseLockout:      time.Minute,
+		MaxLockout:       4 * time.Minute,
+	}).(*loginThrottle)
+	t.now = func() time.Time { return *now }
+	return t
+}
+
+func lockedErr(t *testing.T, err error) *LoginLockedError {
+	t.Helper()
+	var locked *LoginLockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("expected *LoginLockedError, got %v", err)
+	}
+	return locked
+}
+
+func TestLoginThrottle_LocksAccountAtThreshold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	th := newTestThrottle(&now)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, th.Failure(ctx, "alice", "1.1.1.1"))
+	}
+	assert.NoError(t, th.Check(ctx, "alice", "1.1.1.1"))
+
+	assert.NoError(t, th.Failure(ctx, "alice", "1.1.1.1"))
+	locked := lockedErr(t, th.Check(ctx, "alice", "2.2.2.2"))
+	assert.Equal(t, LockScopeAccount, locked.Scope)
+	assert.Equal(t, time.Minute, locked.RetryAfter)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, th.Check(ctx, "alice", "2.2.2.2"))
+}
+
+func TestLoginThrottle_ExponentialBackoff(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	th := newTestThrottle(&now)
+
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, th.Failure(ctx, "alice", ""))
+		}
+		assert.Equal(t, want, lockedErr(t, th.Check(ctx, "alice", "")).RetryAfter)
+		now = now.Add(want)
+	}
+}
+
+func TestLoginThrottle_WindowResetsFailures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	th := newTestThrottle(&now)
+
+	assert.NoError(t, th.Failure(ctx, "alice", ""))
+	assert.NoError(t, th.Failure(ctx, "alice", ""))
+	now = now.Add(11 * time.Minute)
+	assert.NoError(t, th.Failure(ctx, "alice", ""))
+	assert.NoError(t, th.Check(ctx, "alice", ""))
+}
+
+func TestLoginThrottle_LocksClientAcrossUsernames(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	th := newTestThrottle(&now)
+
+	for _, u := range []string{"a", "b", "c", "d", "e"} {
+		assert.NoError(t, th.Failure(ctx, u, "1.1.1.1"))
+	}
+	locked := lockedErr(t, th.Check(ctx, "f", "1.1.1.1"))
+	assert.Equal(t, LockScopeClient, locked.Scope)
+	assert.NoError(t, th.Check(ctx, "f", "2.2.2.2"))
+}
+
+func TestLoginThrottle_SuccessClearsAccountOnly(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	th := newTestThrottle(&now)
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, th.Failure(ctx, "alice", "1.1.1.1"))
+		if i == 1 {
+			assert.NoError(t, th.Success(ctx, "alice"))
+		}
+	}
+	// Two failures after the reset: account open, IP has four of five.
+	assert.NoError(t, th.Check(ctx, "alice", "1.1.1.1"))
+	assert.NoError(t, th.Failure(ctx, "bob", "1.1.1.1"))
+	assert.Equal(t, LockScopeClient, lockedErr(t, th.Check(ctx, "carol", "1.1.1.1")).Scope)
+}
+
+func TestLogin_LockedOut(t *testing.T) {
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	now := time.Now()
+	th := newTestThrottle(&now)
+	uc := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{Throttle: th})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.MinCost)
+	repo.On("FindByUsername", mock.Anything, "user1").Return(&entity.User{Password: string(hashed)}, nil)
+	repo.On("FindByUsername", mock.Anything, "ghost").Return(nil, nil)
+
+	for i := 0; i < 3; i++ {
+		_, err := uc.Login(context.Background(), "user1", "wrong", "1.1.1.1")
+		assert.EqualError(t, err, "invalid credentials")
+		_, err = uc.Login(context.Background(), "ghost", "wrong", "2.2.2.2")
+		assert.EqualError(t, err, "invalid credentials")
+	}
+
+	// The correct password is refused during the lockout, and unknown
+	// usernames lock the same way.
+	_, err := uc.Login(context.Background(), "user1", "pass", "1.1.1.1")
+	assert.Equal(t, LockScopeAccount, lockedErr(t, err).Scope)
+	_, err = uc.Login(context.Background(), "ghost", "pass", "2.2.2.2")
+	assert.Equal(t, LockScopeAccount, lockedErr(t, err).Scope)
+	sessions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLogin_DisabledAccountKeepsFailures(t *testing.T) {
+	repo := new(mockUserRepo)
+	now := time.Now()
+	th := newTestThrottle(&now)
+	uc := NewAuthUsecase(repo, new(mockSessionRepo), jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{Throttle: th})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.MinCost)
+	repo.On("FindByUsername", mock.Anything, "user1").Return(&entity.User{Password: string(hashed), Disabled: true}, nil)
+
+	for i := 0; i < 2; i++ {
+		_, err := uc.Login(context.Background(), "user1", "wrong", "1.1.1.1")
+		assert.EqualError(t, err, "invalid credentials")
+	}
+	_, err := uc.Login(context.Background(), "user1", "pass", "1.1.1.1")
+	assert.ErrorIs(t, err, ErrUserDisabled)
+
+	// The correct password did not clear the two failures.
+	_, err = uc.Login(context.Background(), "user1", "wrong", "1.1.1.1")
+	assert.EqualError(t, err, "invalid credentials")
+	_, err = uc.Login(context.Background(), "user1", "pass", "1.1.1.1")
+	assert.Equal(t, LockScopeAccount, lockedErr(t, err).Scope)
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+)
+
+// DefaultOIDCStateTTL is how long a user has to finish signing in at the
+// provider, used when OIDCConfig.StateTTL is zero.
+const DefaultOIDCStateTTL = 10 * time.Minute
+
+var (
+	ErrInvalidOIDCState = errors.New("invalid or expired login state")
+	ErrOIDCRoleDenied   = errors.New("no role is mapped for this identity")
+)
+
+// generateOIDCSecret returns the random state, nonce and PKCE verifier of a
+// login. 256 bits encode to a 43-character verifier, within RFC 7636 limits.
+var generateOIDCSecret = generateRefreshToken
+
+// OIDCConfig maps ID token claims onto local users.
+type OIDCConfig struct {
+	UsernameClaim string            // claim holding the username, e.g. "preferred_username"
+	RoleClaim     string            // claim holding a string or list of provider roles; "" skips mapping
+	RoleMap       map[string]string // provider role -> local role
+	DefaultRole   string            // role when nothing maps; "" rejects the login
+	StateTTL      time.Duration
+}
+
+// OIDCUsecase implements the OpenID Connect authorization-code flow with
+// PKCE. After the provider vouches for the user, our own tokens are issued
+// exactly as for a password login.
+type OIDCUsecase interface {
+	// BeginLogin records a pending login and returns the provider URL to
+	// redirect to, plus the state the callback must present.
+	BeginLogin(ctx context.Context) (authURL, state string, err error)
+	// CompleteLogin redeems the authorization code for the pending login
+	// identified by state.
+	CompleteLogin(ctx context.Context, state, code string) (*entity.AuthTokens, error)
+}
+
+type oidcUsecase struct {
+	provider repository.IdentityProvider
+	states   repository.OIDCStateRepository
+	auth     AuthUsecase
+	cfg      OIDCConfig
+}
+
+// NewOIDCUsecase creates a new instance of OIDCUsecase.
+func NewOIDCUsecase(provider repository.IdentityProvider, states repository.OIDCStateRepository, auth AuthUsecase, cfg OIDCConfig) OIDCUsecase {
+	if cfg.StateTTL <= 0 {
+		cfg.StateTTL = DefaultOIDCStateTTL
+	}
+	return &oidcUsecase{provider: provider, states: states, auth: auth, cfg: cfg}
+}
+
+func (u *oidcUsecase) BeginLogin(ctx context.Context) (string, string, error) {
+	pending := &entity.OIDCLoginState{ExpiresAt: time.Now().Add(u.cfg.StateTTL)}
+	for _, field := range []*string{&pending.State, &pending.Nonce, &pending.CodeVerifier} {
+		v, err := generateOIDCSecret()
+		if err != nil {
+			return "", "", err
+		}
+		*field = v
+	}
+
+	if err := u.states.Create(ctx, pending); err != nil {
+		return "", "", err
+	}
+
+	authURL, err := u.provider.AuthCodeURL(ctx, pending.State, pending.Nonce, codeChallenge(pending.CodeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, pending.State, nil
+}
+
+func (u *oidcUsecase) CompleteLogin(ctx context.Context, state, code string) (*entity.AuthTokens, error) {
+	pending, err := u.states.Consume(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, ErrInvalidOIDCState
+	}
+
+	identity, err := u.provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := u.mapRole(identity.Claims)
+	if err != nil {
+		return nil, err
+	}
+	username, _ := identity.Claims[u.cfg.UsernameClaim].(string)
+
+	return u.auth.LoginExternal(ctx, identity.ID(), username, role)
+}
+
+// mapRole returns the most privileged local role mapped from the role claim,
+// falling back to DefaultRole. Superadmin is never mapped.
+func (u *oidcUsecase) mapRole(claims map[string]interface{}) (string, error) {
+	best, bestRank := "", len(constants.Roles)
+	for _, v := range claimStrings(claims[u.cfg.RoleClaim]) {
+		role, ok := u.cfg.RoleMap[v]
+		if !ok || role == constants.RoleSuperAdmin {
+			continue
+		}
+		if rank := roleRank(role); rank < bestRank {
+			best, bestRank = role, rank
+		}
+	}
+	if best == "" {
+		best = u.cfg.DefaultRole
+	}
+	if best == "" {
+		return "", ErrOIDCRoleDenied
+	}
+	return best, nil
+}
+
+// roleRank orders roles by privilege, most privileged first, following
+// constants.Roles. Unknown roles rank last.
+func roleRank(role string) int {
+	for i, r := range constants.Roles {
+		if r == role {
+			return i
+		}
+	}
+	return len(constants.Roles)
+}
+
+// claimStrings reads a claim that may be a single string or a list.
+func claimStrings(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// codeChallenge derives the PKCE S256 challenge for verifier (RFC 7636).
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/jwtkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ----- Mock IdentityProvider -----
+
+type mockIdentityProvider struct {
+	mock.Mock
+}
+
+func (m *mockIdentityProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	args := m.Called(ctx, state, nonce, codeChallenge)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockIdentityProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*entity.ExternalIdentity, error) {
+	args := m.Called(ctx, code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ExternalIdentity), args.Error(1)
+}
+
+// ----- Mock OIDCStateRepository -----
+
+type mockOIDCStateRepo struct {
+	mock.Mock
+}
+
+func (m *mockOIDCStateRepo) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *mockOIDCStateRepo) Consume(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	args := m.Called(ctx, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OIDCLoginState), args.Error(1)
+}
+
+// ----- Tests -----
+
+var testOIDCConfig = OIDCConfig{
+	UsernameClaim: "preferred_username",
+	RoleClaim:     "groups",
+	RoleMap:       map[string]string{"staff": constants.RoleAdmin, "students": constants.RoleStudent, "root": constants.RoleSuperAdmin},
+	DefaultRole:   constants.RoleStudent,
+}
+
+func TestOIDCBeginLogin(t *testing.T) {
+	provider := new(mockIdentityProvider)
+	states := new(mockOIDCStateRepo)
+	uc := NewOIDCUsecase(provider, states, nil, testOIDCConfig)
+
+	var saved *entity.OIDCLoginState
+	states.On("Create", mock.Anything, mock.AnythingOfType("*entity.OIDCLoginState")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.OIDCLoginState) }).
+		Return(nil)
+	provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("https://idp/authorize?x", nil)
+
+	url, state, err := uc.BeginLogin(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://idp/authorize?x", url)
+	assert.Equal(t, saved.State, state)
+	assert.NotEqual(t, saved.State, saved.Nonce)
+	assert.Len(t, saved.CodeVerifier, 43)
+
+	// Only the S256 challenge leaves the server, never the verifier.
+	provider.AssertCalled(t, "AuthCodeURL", mock.Anything, saved.State, saved.Nonce, codeChallenge(saved.CodeVerifier))
+}
+
+func TestOIDCCompleteLogin_UnknownState(t *testing.T) {
+	provider := new(mockIdentityProvider)
+	states := new(mockOIDCStateRepo)
+	uc := NewOIDCUsecase(provider, states, nil, testOIDCConfig)
+
+	states.On("Consume", mock.Anything, "s").Return(nil, nil)
+
+	_, err := uc.CompleteLogin(context.Background(), "s", "code")
+	assert.ErrorIs(t, err, ErrInvalidOIDCState)
+	provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOIDCCompleteLogin_ProvisionsMappedRole(t *testing.T) {
+	provider := new(mockIdentityProvider)
+	states := new(mockOIDCStateRepo)
+	repo := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	auth := NewAuthUsecase(repo, sessions, jwtkeys.NewHMAC("secret"), 0, 0, AuthOptions{})
+	uc := NewOIDCUsecase(provider, states, auth, testOIDCConfig)
+
+	states.On("Consume", mock.Anything, "s").Return(&entity.OIDCLoginState{State: "s", Nonce: "n", CodeVerifier: "v"}, nil)
+	provider.On("Exchange", mock.Anything, "code", "v", "n").Return(&entity.ExternalIdentity{
+		Issuer:  "https://idp",
+		Subject: "abc",
+		Claims:  map[string]interface{}{"preferred_username": "somchai", "groups": []interface{}{"students", "staff"}},
+	}, nil)
+	repo.On("FindByExternalID", mock.Anything, "https://idp|abc").Return(nil, nil)
+	repo.On("FindByUsername", mock.Anything, "somchai").Return(nil, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Role == constants.RoleAdmin
+	})).Return(nil)
+	sessions.On("Create", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil)
+
+	tokens, err := uc.CompleteLogin(context.Background(), "s", "code")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	repo.AssertExpectations(t)
+}
+
+func TestOIDCMapRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		claim       interface{}
+		defaultRole string
+		want        string
+		wantErr     error
+	}{
+		{name: "single string", claim: "staff", defaultRole: constants.RoleStudent, want: constants.RoleAdmin},
+		{name: "highest wins", claim: []interface{}{"students", "staff"}, defaultRole: constants.RoleStudent, want: constants.RoleAdmin},
+		{name: "superadmin ignored", claim: []interface{}{"root"}, defaultRole: constants.RoleStudent, want: constants.RoleStudent},
+		{name: "unmapped uses default", claim: "alumni", defaultRole: constants.RoleStudent, want: constants.RoleStudent},
+		{name: "unmapped without default", claim: "alumni", wantErr: ErrOIDCRoleDenied},
+		{name: "missing claim", defaultRole: constants.RoleStudent, want: constants.RoleStudent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testOIDCConfig
+			cfg.DefaultRole = tt.defaultRole
+			uc := NewOIDCUsecase(nil, nil, nil, cfg).(*oidcUsecase)
+
+			claims := map[string]interface{}{}
+			if tt.claim != nil {
+				claims["groups"] = tt.claim
+			}
+			got, err := uc.mapRole(claims)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+// EventDataMapper turns the domain data of an event into the value
+// serialised as its JSON data, e.g. a response DTO.
+type EventDataMapper func(data interface{}) interface{}
+
+// EventOutbox records domain events together with the changes that raise
+// them, so an event is stored if and only if its change is.
+type EventOutbox interface {
+	// Transaction runs fn in a database transaction. Writes made with the
+	// context passed to fn, recorded events included, commit together.
+	// fn may run more than once.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// Record appends an event to the outbox. Pass the transaction's context
+	// to record it with the change that raised it.
+	Record(ctx context.Context, eventType, aggregateID string, data interface{}) error
+}
+
+type eventOutbox struct {
+	repo    repository.OutboxRepository
+	tx      repository.Transactor
+	mapData EventDataMapper
+	now     func() time.Time
+}
+
+// NewEventOutbox creates a new instance of EventOutbox.
+// mapData may be nil, in which case event data is serialised as given.
+func NewEventOutbox(repo repository.OutboxRepository, tx repository.Transactor, mapData EventDataMapper) EventOutbox {
+	if mapData == nil {
+		mapData = func(data interface{}) interface{} { return data }
+	}
+	return &eventOutbox{repo: repo, tx: tx, mapData: mapData, now: time.Now}
+}
+
+func (o *eventOutbox) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return o.tx.WithinTransaction(ctx, fn)
+}
+
+func (o *eventOutbox) Record(ctx context.Context, eventType, aggregateID string, data interface{}) error {
+	encoded, err := json.Marshal(o.mapData(data))
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+	now := o.now()
+	return o.repo.Append(ctx, &entity.DomainEvent{
+		Type:          eventType,
+		AggregateID:   aggregateID,
+		Data:          encoded,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	})
+}
+
+// inTransaction runs fn in the outbox's transaction, or directly when there
+// is no outbox.
+func inTransaction(ctx context.Context, outbox EventOutbox, fn func(ctx context.Context) error) error {
+	if outbox == nil {
+		return fn(ctx)
+	}
+	return outbox.Transaction(ctx, fn)
+}
+
+// recordEvent records an event in the outbox, if there is one.
+func recordEvent(ctx context.Context, outbox EventOutbox, eventType, aggregateID string, data interface{}) error {
+	if outbox == nil {
+		return nil
+	}
+	return outbox.Record(ctx, eventType, aggregateID, data)
+}
+
+// OutboxRetryPolicy controls how events a sink failed to handle are retried.
+// Events are retried until every sink handles them. Zero fields take the
+// defaults below.
+type OutboxRetryPolicy struct {
+	Backoff    time.Duration // wait after the first failure, doubled after each one
+	MaxBackoff time.Duration // cap on the wait between attempts
+	Lease      time.Duration // how long a claimed event is hidden from other relays
+}
+
+// Default outbox retry policy.
+const (
+	DefaultOutboxBackoff    = 10 * time.Second
+	DefaultOutboxMaxBackoff = 10 * time.Minute
+	DefaultOutboxLease      = time.Minute
+)
+
+// OutboxRelay publishes outbox events to the event sinks.
+type OutboxRelay interface {
+	// RelayDue hands every due event to the sinks that have not handled it
+	// yet and returns how many events were relayed.
+	RelayDue(ctx context.Context) int
+}
+
+type outboxRelay struct {
+	repo  repository.OutboxRepository
+	sinks []repository.EventSink
+	retry OutboxRetryPolicy
+	now   func() time.Time
+}
+
+// NewOutboxRelay creates a new instance of OutboxRelay.
+func NewOutboxRelay(repo repository.OutboxRepository, sinks []repository.EventSink, retry OutboxRetryPolicy) OutboxRelay {
+	if retry.Backoff <= 0 {
+		retry.Backoff = DefaultOutboxBackoff
+	}
+	if retry.MaxBackoff <= 0 {
+		retry.MaxBackoff = DefaultOutboxMaxBackoff
+	}
+	if retry.Lease <= 0 {
+		retry.Lease = DefaultOutboxLease
+	}
+	return &outboxRelay{repo: repo, sinks: sinks, retry: retry, now: time.Now}
+}
+
+func (r *outboxRelay) RelayDue(ctx context.Context) int {
+	relayed := 0
+	for ctx.Err() == nil {
+		event, err := r.repo.ClaimDue(ctx, r.now(), r.retry.Lease)
+		if err != nil {
+			log.Printf("[outbox] failed to claim due events: %v", err)
+			break
+		}
+		if event == nil {
+			break
+		}
+		r.relay(ctx, event)
+		relayed++
+	}
+	return relayed
+}
+
+// relay hands a claimed event to the sinks that have not handled it and
+// saves the outcome. The event is published once every sink has handled
+// it; otherwise it is retried with exponential backoff.
+func (r *outboxRelay) relay(ctx context.Context, event *entity.DomainEvent) {
+	var failures []string
+	for _, sink := range r.sinks {
+		if event.WasHandledBy(sink.Name()) {
+			continue
+		}
+		if err := sink.Handle(ctx, event); err != nil {
+			failures = append(failures, sink.Name()+": "+err.Error())
+			continue
+		}
+		event.HandledBy = append(event.HandledBy, sink.Name())
+	}
+	if ctx.Err() != nil {
+		return // shutting down; the lease expires and it is relayed again
+	}
+
+	now := r.now()
+	if len(failures) == 0 {
+		event.LastError = ""
+		event.PublishedAt = &now
+	} else {
+		event.Attempts++
+		event.LastError = strings.Join(failures, "; ")
+		event.NextAttemptAt = now.Add(backoff(r.retry.Backoff, r.retry.MaxBackoff, event.Attempts))
+		log.Printf("[outbox] event %s (%s) not relayed, attempt %d: %s", event.ID, event.Type, event.Attempts, event.LastError)
+	}
+	if err := r.repo.Update(ctx, event); err != nil {
+		log.Printf("[outbox] failed to save event %s: %v", event.ID, err)
+	}
+}
+
+// NewOutboxRelayWorker creates a worker that relays due events every
+// interval. Stopping it abandons any event in progress, which is relayed
+// again once its lease expires.
+func NewOutboxRelayWorker(relay OutboxRelay, interval time.Duration) *Worker {
+	return NewWorker("outbox", interval, func(ctx context.Context) {
+		relay.RelayDue(ctx)
+	})
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// ----- In-memory outbox -----
+
+type fakeOutboxRepo struct {
+	events []*entity.DomainEvent
+}
+
+func (r *fakeOutboxRepo) Append(_ context.Context, e *entity.DomainEvent) error {
+	e.ID = fmt.Sprintf("e%d", len(r.events)+1)
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *fakeOutboxRepo) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*entity.DomainEvent, error) {
+	for _, e := range r.events {
+		if e.PublishedAt == nil && !e.NextAttemptAt.After(now) {
+			e.NextAttemptAt = now.Add(lease)
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOutboxRepo) Update(_ context.Context, _ *entity.DomainEvent) error {
+	return nil // events are shared with the caller
+}
+
+type fakeTransactor struct {
+	calls int
+}
+
+func (t *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.calls++
+	return fn(ctx)
+}
+
+// fakeSink records the events it handles and fails while failures > 0.
+type fakeSink struct {
+	name     string
+	handled  []string
+	failures int
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Handle(_ context.Context, e *entity.DomainEvent) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink down")
+	}
+	s.handled = append(s.handled, e.ID)
+	return nil
+}
+
+// ----- EventOutbox -----
+
+func TestEventOutbox_Record(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	tx := &fakeTransactor{}
+	outbox := NewEventOutbox(repo, tx, func(data interface{}) interface{} {
+		return map[string]string{"code": data.(*entity.Course).Code}
+	}).(*eventOutbox)
+	now := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	outbox.now = func() time.Time { return now }
+
+	err := outbox.Transaction(context.Background(), func(ctx context.Context) error {
+		return outbox.Record(ctx, "course.created", "c1", &entity.Course{Code: "CP353004"})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tx.calls)
+	if assert.Len(t, repo.events, 1) {
+		e := repo.events[0]
+		assert.Equal(t, "course.created", e.Type)
+		assert.Equal(t, "c1", e.AggregateID)
+		assert.JSONEq(t, `{"code":"CP353004"}`, string(e.Data))
+		assert.Equal(t, now, e.OccurredAt)
+		assert.Equal(t, now, e.NextAttemptAt, "new events are due at once")
+		assert.Nil(t, e.PublishedAt)
+	}
+}
+
+// ----- OutboxRelay -----
+
+func newTestOutboxRelay(repo *fakeOutboxRepo, retry OutboxRetryPolicy, sinks ...*fakeSink) (*outboxRelay, *time.Time) {
+	list := make([]repository.EventSink, len(sinks))
+	for i, s := range sinks {
+		list[i] = s
+	}
+	relay := NewOutboxRelay(repo, list, retry).(*outboxRelay)
+	now := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	relay.now = func() time.Time { return now }
+	return relay, &now
+}
+
+func TestOutboxRelay_PublishesToEverySink(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	webhook, logSink := &fakeSink{name: "webhook"}, &fakeSink{name: "log"}
+	relay, now := newTestOutboxRelay(repo, OutboxRetryPolicy{}, webhook, logSink)
+	_ = repo.Append(context.Background(), &entity.DomainEvent{Type: "course.created", NextAttemptAt: *now})
+	_ = repo.Append(context.Background(), &entity.DomainEvent{Type: "course.deleted", NextAttemptAt: *now})
+
+	assert.Equal(t, 2, relay.RelayDue(context.Background()))
+	assert.Equal(t, []string{"e1", "e2"}, webhook.handled)
+	assert.Equal(t, []string{"e1", "e2"}, logSink.handled)
+	for _, e := range repo.events {
+		if assert.NotNil(t, e.PublishedAt) {
+			assert.Equal(t, *now, *e.PublishedAt)
+		}
+		assert.Equal(t, []string{"webhook", "log"}, e.HandledBy)
+	}
+	assert.Equal(t, 0, relay.RelayDue(context.Background()), "published events are not relayed again")
+}
+
+func TestOutboxRelay_RetriesOnlyFailedSinks(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	webhook, logSink := &fakeSink{name: "webhook", failures: 3}, &fakeSink{name: "log"}
+	relay, now := newTestOutboxRelay(repo, OutboxRetryPolicy{Backoff: time.Minute, MaxBackoff: 3 * time.Minute}, webhook, logSink)
+	_ = repo.Append(context.Background(), &entity.DomainEvent{Type: "user.updated", NextAttemptAt: *now})
+	e := repo.events[0]
+
+	for attempt, wait := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		assert.Equal(t, 1, relay.RelayDue(context.Background()))
+		assert.Nil(t, e.PublishedAt)
+		assert.Equal(t, attempt+1, e.Attempts)
+		assert.Equal(t, "webhook: sink down", e.LastError)
+		assert.Equal(t, now.Add(wait), e.NextAttemptAt)
+		assert.Equal(t, 0, relay.RelayDue(context.Background()), "not due before its backoff")
+		*now = e.NextAttemptAt
+	}
+
+	assert.Equal(t, 1, relay.RelayDue(context.Background()))
+	assert.NotNil(t, e.PublishedAt)
+	assert.Empty(t, e.LastError)
+	assert.Equal(t, []string{"e1"}, webhook.handled)
+	assert.Equal(t, []string{"e1"}, logSink.handled, "a sink that handled the event is not called again")
+	assert.Equal(t, []string{"log", "webhook"}, e.HandledBy)
+}
+
+func TestOutboxRelay_LeavesEventOnShutdown(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	sink := &fakeSink{name: "webhook", failures: 1}
+	relay, now := newTestOutboxRelay(repo, OutboxRetryPolicy{}, sink)
+	_ = repo.Append(context.Background(), &entity.DomainEvent{Type: "course.created", NextAttemptAt: *now})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	relay.relay(ctx, repo.events[0])
+	assert.Equal(t, 0, repo.events[0].Attempts, "an interrupted attempt is not counted")
+	assert.Nil(t, repo.events[0].PublishedAt)
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrWeakPassword is matched (via errors.Is) by every *PasswordPolicyError.
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+
+// PasswordPolicyError lists every rule a rejected password breaks.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrWeakPassword.Error() + ": " + strings.Join(e.Violations, "; ")
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
+
+// maxPasswordBytes is bcrypt's input limit; longer passwords are rejected
+// rather than silently truncated.
+const maxPasswordBytes = 72
+
+// PasswordPolicy is applied to every password a user chooses. The zero
+// value only enforces the bcrypt length limit.
+type PasswordPolicy struct {
+	MinLength     int // in characters
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// Blocklist holds extra rejected passwords on top of commonPasswords.
+	// Entries are compared case-insensitively.
+	Blocklist []string
+}
+
+// DefaultPasswordPolicy is used when NewAuthUsecase is given a nil policy.
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:    8,
+	RequireUpper: true,
+	RequireLower: true,
+	RequireDigit: true,
+}
+
+// Check returns a *PasswordPolicyError if password breaks the policy.
+// A password equal to or containing the username is always rejected.
+func (p PasswordPolicy) Check(username, password string) error {
+	var violations []string
+	if n := len([]rune(password)); n < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes", maxPasswordBytes))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	if username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		violations = append(violations, "must not contain the username")
+	}
+	if p.blocked(lowered) {
+		violations = append(violations, "is too common")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (p PasswordPolicy) blocked(lowered string) bool {
+	if commonPasswords[lowered] {
+		return true
+	}
+	for _, b := range p.Blocklist {
+		if strings.ToLower(b) == lowered {
+			return true
+		}
+	}
+	return false
+}
+
+// commonPasswords are rejected by every policy: the most frequent passwords
+// in public breach corpora plus a few obvious picks for this service.
+var commonPasswords = toSet(
+	"123456", "123456789", "12345678", "1234567890", "12345", "1234567",
+	"111111", "000000", "123123", "654321", "666666", "121212", "112233",
+	"password", "password1", "password12", "password123", "passw0rd", "p@ssw0rd", "p@ssword",
+	"qwerty", "qwerty123", "qwertyuiop", "1q2w3e4r", "1q2w3e4r5t", "zaq12wsx", "asdfghjkl",
+	"abc123", "abcd1234", "a1b2c3d4", "iloveyou", "letmein", "welcome", "welcome1", "welcome123",
+	"admin", "admin123", "administrator", "root", "toor", "changeme", "change-me",
+	"superadmin", "superadmin123", "secret", "secret123", "default",
+	"monkey", "dragon", "master", "sunshine", "princess", "football", "baseball",
+	"shadow", "trustno1", "starwars", "whatever", "freedom", "login", "hello123",
+	"student", "student123", "teacher", "university", "calendar", "register",
+	"thailand", "thailand123", "bangkok",
+	"Password1!", "Qwerty123!", "Welcome1!", "Admin@123", "P@ssw0rd1",
+)
+
+func toSet(values ...string) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[strings.ToLower(v)] = true
+	}
+	return m
+}
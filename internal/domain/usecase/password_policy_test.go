@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 10, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+
+	tests := []struct {
+		name       string
+		policy     PasswordPolicy
+		username   string
+		password   string
+		violations []string
+	}{
+		{"strong", strict, "somchai", "Kh0n-Kaen-Reg", nil},
+		{"too short", DefaultPasswordPolicy, "somchai", "Ab1", []string{"must be at least 8 characters"}},
+		{"length counts characters", PasswordPolicy{MinLength: 4}, "", "ไทยไ", nil},
+		{"missing classes", strict, "somchai", "alllowercase", []string{
+			"must contain an uppercase letter", "must contain a digit", "must contain a symbol",
+		}},
+		{"contains username", DefaultPasswordPolicy, "Somchai", "xSOMCHAI99", []string{"must not contain the username"}},
+		{"common", DefaultPasswordPolicy, "somchai", "Password123", []string{"is too common"}},
+		{"configured blocklist", PasswordPolicy{Blocklist: []string{"Semester-One"}}, "", "semester-one", []string{"is too common"}},
+		{"over bcrypt limit", PasswordPolicy{}, "", strings.Repeat("a", 73), []string{"must be at most 72 bytes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.username, tt.password)
+			if tt.violations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var perr *PasswordPolicyError
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected *PasswordPolicyError, got %v", err)
+			}
+			assert.Equal(t, tt.violations, perr.Violations)
+			assert.ErrorIs(t, err, ErrWeakPassword)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultPasswordResetTTL is used when NewPasswordResetUsecase is given a zero TTL.
+const DefaultPasswordResetTTL = time.Hour
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	ErrExternalAccount   = errors.New("account signs in through single sign-on and has no password")
+)
+
+// PasswordResetUsecase implements admin-initiated password resets. Tokens
+// issued by Login to users who must change their password are redeemed
+// the same way.
+type PasswordResetUsecase interface {
+	// IssueReset replaces any outstanding reset token for targetID with a
+	// new one and hands it to the notifier. It returns the expiry time.
+	IssueReset(ctx context.Context, callerID, targetID string) (time.Time, error)
+	// ResetPassword consumes token, sets newPassword and signs the user out
+	// everywhere. A token is rejected after one successful use; a password
+	// rejected by the policy leaves it usable.
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type passwordResetUsecase struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	tokens   repository.PasswordResetRepository
+	notifier repository.PasswordResetNotifier
+	policy   PasswordPolicy
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewPasswordResetUsecase creates a new instance of PasswordResetUsecase.
+// A nil policy uses DefaultPasswordPolicy and a zero ttl DefaultPasswordResetTTL.
+func NewPasswordResetUsecase(users repository.UserRepository, sessions repository.SessionRepository, tokens repository.PasswordResetRepository, notifier repository.PasswordResetNotifier, policy *PasswordPolicy, ttl time.Duration) PasswordResetUsecase {
+	if policy == nil {
+		policy = &DefaultPasswordPolicy
+	}
+	if ttl <= 0 {
+		ttl = DefaultPasswordResetTTL
+	}
+	return &passwordResetUsecase{
+		users:    users,
+		sessions: sessions,
+		tokens:   tokens,
+		notifier: notifier,
+		policy:   *policy,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+func (u *passwordResetUsecase) IssueReset(ctx context.Context, callerID, targetID string) (time.Time, error) {
+	// Same rules as the other admin operations; admins change their own
+	// password with ChangePassword.
+	if callerID == targetID {
+		return time.Time{}, ErrSelfModification
+	}
+	user, err := u.users.FindByID(ctx, targetID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if user == nil {
+		return time.Time{}, ErrUserNotFound
+	}
+	if user.Role == constants.RoleSuperAdmin {
+		return time.Time{}, ErrSuperAdminProtected
+	}
+	if user.ExternalID != "" {
+		return time.Time{}, ErrExternalAccount
+	}
+
+	token, err := generateRefreshToken()
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Only the newest token is valid.
+	if err := u.tokens.DeleteForUser(ctx, user.ID); err != nil {
+		return time.Time{}, err
+	}
+	reset := &entity.PasswordResetToken{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		CreatedBy: callerID,
+		ExpiresAt: u.now().Add(u.ttl),
+	}
+	if err := u.tokens.Create(ctx, reset); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := u.notifier.SendPasswordReset(ctx, user, token, reset.ExpiresAt); err != nil {
+		// An undelivered token is useless; don't leave it redeemable.
+		_ = u.tokens.DeleteForUser(ctx, user.ID)
+		return time.Time{}, err
+	}
+	return reset.ExpiresAt, nil
+}
+
+func (u *passwordResetUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	hash := hashToken(token)
+	reset, err := u.tokens.FindByHash(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if reset == nil {
+		return ErrInvalidResetToken
+	}
+	user, err := u.users.FindByID(ctx, reset.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidResetToken
+	}
+	// Checked before consuming so a rejected password doesn't spend the token.
+	if err := u.policy.Check(user.Username, newPassword); err != nil {
+		return err
+	}
+
+	// Consume is atomic: of two concurrent requests only one gets the token.
+	if reset, err = u.tokens.Consume(ctx, hash); err != nil {
+		return err
+	}
+	if reset == nil {
+		return ErrInvalidResetToken
+	}
+	hashed, err := hashPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashed)
+	user.MustChangePassword = false
+	if err := u.users.Update(ctx, user); err != nil {
+		return err
+	}
+	return u.sessions.RevokeAllForUser(ctx, user.ID)
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ----- In-memory PasswordResetRepository -----
+
+type fakePasswordResetRepo struct {
+	tokens map[string]*entity.PasswordResetToken
+	now    func() time.Time
+}
+
+func newFakePasswordResetRepo() *fakePasswordResetRepo {
+	return &fakePasswordResetRepo{tokens: map[string]*entity.PasswordResetToken{}, now: time.Now}
+}
+
+func (r *fakePasswordResetRepo) Create(_ context.Context, token *entity.PasswordResetToken) error {
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r *fakePasswordResetRepo) FindByHash(_ context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	t, ok := r.tokens[tokenHash]
+	if !ok || !t.ExpiresAt.After(r.now()) {
+		return nil, nil
+	}
+	return t, nil
+}
+
+func (r *fakePasswordResetRepo) Consume(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	t, _ := r.FindByHash(ctx, tokenHash)
+	delete(r.tokens, tokenHash)
+	return t, nil
+}
+
+func (r *fakePasswordResetRepo) DeleteForUser(_ context.Context, userID string) error {
+	for hash, t := range r.tokens {
+		if t.UserID == userID {
+			delete(r.tokens, hash)
+		}
+	}
+	return nil
+}
+
+// ----- Capturing notifier -----
+
+type captureNotifier struct {
+	token string
+	err   error
+}
+
+func (n *captureNotifier) SendPasswordReset(_ context.Context, _ *entity.User, token string, _ time.Time) error {
+	n.token = token
+	return n.err
+}
+
+// ----- Tests -----
+
+func newResetFixture() (*mockUserRepo, *mockSessionRepo, *fakePasswordResetRepo, *captureNotifier, PasswordResetUsecase) {
+	users := new(mockUserRepo)
+	sessions := new(mockSessionRepo)
+	tokens := newFakePasswordResetRepo()
+	notifier := &captureNotifier{}
+	uc := NewPasswordResetUsecase(users, sessions, tokens, notifier, nil, time.Hour)
+	return users, sessions, tokens, notifier, uc
+}
+
+func TestPasswordReset_IssueAndRedeem(t *testing.T) {
+	users, sessions, tokens, notifier, uc := newResetFixture()
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Username: "somchai", Role: constants.RoleStudent}
+	users.On("FindByID", mock.Anything, "u2").Return(user, nil)
+	users.On("Update", mock.Anything, user).Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u2").Return(nil)
+
+	expiresAt, err := uc.IssueReset(context.Background(), "admin1", "u2")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+	assert.NotEmpty(t, notifier.token)
+	assert.NotContains(t, tokens.tokens, notifier.token, "only the hash may be stored")
+
+	assert.NoError(t, uc.ResetPassword(context.Background(), notifier.token, "N3wPassword"))
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("N3wPassword")))
+	sessions.AssertExpectations(t)
+
+	// Single use.
+	assert.ErrorIs(t, uc.ResetPassword(context.Background(), notifier.token, "An0therPassword"), ErrInvalidResetToken)
+}
+
+func TestPasswordReset_NewTokenReplacesOld(t *testing.T) {
+	users, _, _, notifier, uc := newResetFixture()
+	users.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Username: "somchai"}, nil)
+
+	_, err := uc.IssueReset(context.Background(), "admin1", "u2")
+	assert.NoError(t, err)
+	first := notifier.token
+	_, err = uc.IssueReset(context.Background(), "admin1", "u2")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, uc.ResetPassword(context.Background(), first, "N3wPassword"), ErrInvalidResetToken)
+}
+
+func TestPasswordReset_WeakPasswordKeepsToken(t *testing.T) {
+	users, sessions, _, notifier, uc := newResetFixture()
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Username: "somchai"}
+	users.On("FindByID", mock.Anything, "u2").Return(user, nil)
+	users.On("Update", mock.Anything, user).Return(nil)
+	sessions.On("RevokeAllForUser", mock.Anything, "u2").Return(nil)
+
+	_, err := uc.IssueReset(context.Background(), "admin1", "u2")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, uc.ResetPassword(context.Background(), notifier.token, "Somchai2025"), ErrWeakPassword)
+	assert.NoError(t, uc.ResetPassword(context.Background(), notifier.token, "N3wPassword"))
+}
+
+func TestPasswordReset_Expired(t *testing.T) {
+	users, _, tokens, notifier, uc := newResetFixture()
+	users.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Username: "somchai"}, nil)
+
+	_, err := uc.IssueReset(context.Background(), "admin1", "u2")
+	assert.NoError(t, err)
+	tokens.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	assert.ErrorIs(t, uc.ResetPassword(context.Background(), notifier.token, "N3wPassword"), ErrInvalidResetToken)
+}
+
+func TestPasswordReset_IssueRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  *entity.User
+		caller  string
+		wantErr error
+	}{
+		{"self", &entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}}, "u2", ErrSelfModification},
+		{"unknown user", nil, "admin1", ErrUserNotFound},
+		{"superadmin", &entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, Role: constants.RoleSuperAdmin}, "admin1", ErrSuperAdminProtected},
+		{"single sign-on account", &entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}, ExternalID: "https://idp|abc"}, "admin1", ErrExternalAccount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, _, tokens, notifier, uc := newResetFixture()
+			if tt.target == nil {
+				users.On("FindByID", mock.Anything, "u2").Return(nil, nil)
+			} else {
+				users.On("FindByID", mock.Anything, "u2").Return(tt.target, nil)
+			}
+
+			_, err := uc.IssueReset(context.Background(), tt.caller, "u2")
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, notifier.token)
+			assert.Empty(t, tokens.tokens)
+		})
+	}
+}
+
+func TestPasswordReset_NotifierFailureRevokesToken(t *testing.T) {
+	users, _, tokens, notifier, uc := newResetFixture()
+	users.On("FindByID", mock.Anything, "u2").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u2"}}, nil)
+	notifier.err = errors.New("smtp down")
+
+	_, err := uc.IssueReset(context.Background(), "admin1", "u2")
+	assert.EqualError(t, err, "smtp down")
+	assert.Empty(t, tokens.tokens)
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+)
+
+// permissionCacheTTL bounds how long another replica may keep serving a
+// binding after it is edited. Edits made through this instance apply at once.
+const permissionCacheTTL = 30 * time.Second
+
+var (
+	ErrInvalidRole           = errors.New("invalid role")
+	ErrUnknownPermission     = errors.New("unknown permission")
+	ErrSuperAdminPermissions = errors.New("superadmin permissions cannot be changed")
+)
+
+// PermissionChecker resolves whether a role grants a permission.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role, perm string) (bool, error)
+}
+
+// defaultPermissions resolves permissions from constants.DefaultRolePermissions.
+type defaultPermissions struct{}
+
+func (defaultPermissions) HasPermission(_ context.Context, role, perm string) (bool, error) {
+	for _, p := range constants.DefaultRolePermissions[role] {
+		if p == perm {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PermissionUsecase defines the business logic for role→permission bindings.
+type PermissionUsecase interface {
+	PermissionChecker
+	// SeedDefaults stores constants.DefaultRolePermissions for roles with no binding.
+	SeedDefaults(ctx context.Context)
+	// GetRolePermissions returns the effective binding of every role.
+	GetRolePermissions(ctx context.Context) ([]*entity.RolePermissions, error)
+	// SetRolePermissions replaces a role's permissions. Superadmin cannot be edited.
+	SetRolePermissions(ctx context.Context, role string, perms []string, updatedBy string) (*entity.RolePermissions, error)
+}
+
+type cachedPermissions struct {
+	perms    map[string]bool
+	loadedAt time.Time
+}
+
+type permissionUsecase struct {
+	repo repository.RolePermissionRepository
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cachedPermissions
+}
+
+// NewPermissionUsecase creates a new instance of PermissionUsecase.
+func NewPermissionUsecase(repo repository.RolePermissionRepository) PermissionUsecase {
+	return &permissionUsecase{
+		repo:  repo,
+		ttl:   permissionCacheTTL,
+		now:   time.Now,
+		cache: map[string]cachedPermissions{},
+	}
+}
+
+func (u *permissionUsecase) SeedDefaults(ctx context.Context) {
+	for _, role := range constants.Roles {
+		if role == constants.RoleSuperAdmin {
+			continue // implicit; never read from storage
+		}
+		existing, err := u.repo.GetByRole(ctx, role)
+		if err != nil {
+			log.Printf("Warning: failed to check permissions for role '%s': %v", role, err)
+			continue
+		}
+		if existing != nil {
+			continue
+		}
+		rp := &entity.RolePermissions{Role: role, Permissions: constants.DefaultRolePermissions[role]}
+		if err := u.repo.Save(ctx, rp); err != nil {
+			log.Printf("Warning: failed to seed permissions for role '%s': %v", role, err)
+			continue
+		}
+		log.Printf("Seeded default permissions for role '%s'", role)
+	}
+}
+
+func (u *permissionUsecase) HasPermission(ctx context.Context, role, perm string) (bool, error) {
+	if role == constants.RoleSuperAdmin {
+		return true, nil
+	}
+
+	u.mu.RLock()
+	entry, ok := u.cache[role]
+	u.mu.RUnlock()
+	if ok && u.now().Sub(entry.loadedAt) < u.ttl {
+		return entry.perms[perm], nil
+	}
+
+	rp, err := u.load(ctx, role)
+	if err != nil {
+		return false, err
+	}
+
+	perms := make(map[string]bool, len(rp.Permissions))
+	for _, p := range rp.Permissions {
+		perms[p] = true
+	}
+	u.mu.Lock()
+	u.cache[role] = cachedPermissions{perms: perms, loadedAt: u.now()}
+	u.mu.Unlock()
+
+	return perms[perm], nil
+}
+
+func (u *permissionUsecase) GetRolePermissions(ctx context.Context) ([]*entity.RolePermissions, error) {
+	result := make([]*entity.RolePermissions, 0, len(constants.Roles))
+	for _, role := range constants.Roles {
+		rp, err := u.load(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rp)
+	}
+	return result, nil
+}
+
+func (u *permissionUsecase) SetRolePermissions(ctx context.Context, role string, perms []string, updatedBy string) (*entity.RolePermissions, error) {
+	if !constants.ValidRoles[role] {
+		return nil, ErrInvalidRole
+	}
+	if role == constants.RoleSuperAdmin {
+		return nil, ErrSuperAdminPermissions
+	}
+
+	seen := make(map[string]bool, len(perms))
+	unique := make([]string, 0, len(perms))
+	for _, p := range perms {
+		if !constants.ValidPermissions[p] {
+			return nil, ErrUnknownPermission
+		}
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+
+	rp := &entity.RolePermissions{Role: role, Permissions: unique, UpdatedBy: updatedBy}
+	if err := u.repo.Save(ctx, rp); err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	delete(u.cache, role)
+	u.mu.Unlock()
+
+	return rp, nil
+}
+
+// load returns the stored binding for role, falling back to the defaults
+// when none is stored. Superadmin always resolves to every permission.
+func (u *permissionUsecase) load(ctx context.Context, role string) (*entity.RolePermissions, error) {
+	if role == constants.RoleSuperAdmin {
+		return &entity.RolePermissions{Role: role, Permissions: constants.AllPermissions}, nil
+	}
+
+	rp, err := u.repo.GetByRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if rp == nil {
+		rp = &entity.RolePermissions{Role: role, Permissions: constants.DefaultRolePermissions[role]}
+	}
+	return rp, nil
+}
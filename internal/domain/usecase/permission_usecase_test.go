@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ----- Mock RolePermissionRepository -----
+
+type mockRolePermissionRepo struct {
+	mock.Mock
+}
+
+func (m *mockRolePermissionRepo) GetByRole(ctx context.Context, role string) (*entity.RolePermissions, error) {
+	args := m.Called(ctx, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RolePermissions), args.Error(1)
+}
+
+func (m *mockRolePermissionRepo) Save(ctx context.Context, rp *entity.RolePermissions) error {
+	args := m.Called(ctx, rp)
+	return args.Error(0)
+}
+
+// ----- Tests -----
+
+func TestHasPermission_SuperAdminAlwaysAllowed(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo)
+
+	ok, err := uc.HasPermission(context.Background(), constants.RoleSuperAdmin, constants.PermRoleManage)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	repo.AssertNotCalled(t, "GetByRole", mock.Anything, mock.Anything)
+}
+
+func TestHasPermission_StoredBindingIsCached(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo).(*permissionUsecase)
+	now := time.Now()
+	uc.now = func() time.Time { return now }
+
+	repo.On("GetByRole", mock.Anything, constants.RoleStudent).
+		Return(&entity.RolePermissions{Role: constants.RoleStudent, Permissions: []string{constants.PermQueueRead}}, nil)
+
+	for i := 0; i < 3; i++ {
+		ok, err := uc.HasPermission(context.Background(), constants.RoleStudent, constants.PermQueueRead)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, _ := uc.HasPermission(context.Background(), constants.RoleStudent, constants.PermCourseWrite)
+	assert.False(t, ok)
+	repo.AssertNumberOfCalls(t, "GetByRole", 1)
+
+	now = now.Add(permissionCacheTTL)
+	_, _ = uc.HasPermission(context.Background(), constants.RoleStudent, constants.PermQueueRead)
+	repo.AssertNumberOfCalls(t, "GetByRole", 2)
+}
+
+func TestHasPermission_FallsBackToDefaults(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo)
+
+	repo.On("GetByRole", mock.Anything, constants.RoleAdmin).Return(nil, nil)
+
+	ok, err := uc.HasPermission(context.Background(), constants.RoleAdmin, constants.PermUserManage)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, _ = uc.HasPermission(context.Background(), constants.RoleAdmin, constants.PermRoleManage)
+	assert.False(t, ok)
+}
+
+func TestHasPermission_RepoError(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo)
+
+	repo.On("GetByRole", mock.Anything, constants.RoleAdmin).Return(nil, errors.New("db error"))
+
+	_, err := uc.HasPermission(context.Background(), constants.RoleAdmin, constants.PermUserManage)
+	assert.EqualError(t, err, "db error")
+}
+
+func TestSetRolePermissions_InvalidatesCache(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo)
+
+	repo.On("GetByRole", mock.Anything, constants.RoleStudent).Return(nil, nil).Once()
+	ok, _ := uc.HasPermission(context.Background(), constants.RoleStudent, constants.PermQueueRead)
+	assert.False(t, ok)
+
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*entity.RolePermissions")).Return(nil)
+	rp, err := uc.SetRolePermissions(context.Background(), constants.RoleStudent,
+		[]string{constants.PermQueueRead, constants.PermQueueRead}, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{constants.PermQueueRead}, rp.Permissions)
+	assert.Equal(t, "u1", rp.UpdatedBy)
+
+	repo.On("GetByRole", mock.Anything, constants.RoleStudent).Return(rp, nil)
+	ok, _ = uc.HasPermission(context.Background(), constants.RoleStudent, constants.PermQueueRead)
+	assert.True(t, ok)
+}
+
+func TestSetRolePermissions_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		perms   []string
+		wantErr error
+	}{
+		{"unknown role", "guest", nil, ErrInvalidRole},
+		{"superadmin", constants.RoleSuperAdmin, nil, ErrSuperAdminPermissions},
+		{"unknown permission", constants.RoleAdmin, []string{"course:delete"}, ErrUnknownPermission},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mockRolePermissionRepo)
+			uc := NewPermissionUsecase(repo)
+
+			_, err := uc.SetRolePermissions(context.Background(), tc.role, tc.perms, "u1")
+			assert.ErrorIs(t, err, tc.wantErr)
+			repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetRolePermissions(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo)
+
+	repo.On("GetByRole", mock.Anything, constants.RoleAdmin).
+		Return(&entity.RolePermissions{Role: constants.RoleAdmin, Permissions: []string{constants.PermCourseWrite}}, nil)
+	repo.On("GetByRole", mock.Anything, constants.RoleStudent).Return(nil, nil)
+
+	roles, err := uc.GetRolePermissions(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, roles, 3)
+	assert.Equal(t, constants.AllPermissions, roles[0].Permissions)
+	assert.Equal(t, []string{constants.PermCourseWrite}, roles[1].Permissions)
+	assert.Empty(t, roles[2].Permissions)
+}
+
+func TestSeedDefaults_OnlyMissingRoles(t *testing.T) {
+	repo := new(mockRolePermissionRepo)
+	uc := NewPermissionUsecase(repo)
+
+	repo.On("GetByRole", mock.Anything, constants.RoleAdmin).Return(&entity.RolePermissions{Role: constants.RoleAdmin}, nil)
+	repo.On("GetByRole", mock.Anything, constants.RoleStudent).Return(nil, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(rp *entity.RolePermissions) bool {
+		return rp.Role == constants.RoleStudent
+	})).Return(nil)
+
+	uc.SeedDefaults(context.Background())
+	repo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestRegister_CreateAdmin_UsesPermissionChecker(t *testing.T) {
+	perms := new(mockRolePermissionRepo)
+	perms.On("GetByRole", mock.Anything, constants.RoleStudent).
+		Return(&entity.RolePermissions{Role: constants.RoleStudent, Permissions: []string{constants.PermUserManage}}, nil)
+
+	repo := new(mockUserRepo)
+	repo.On("FindByUsername", mock.Anything, "newadmin").Return(nil, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+	uc := NewAuthUsecase(repo, nil, nil, 0, 0, AuthOptions{Permissions: NewPermissionUsecase(perms)})
+
+	caller := constants.RoleStudent
+	user, err := uc.Register(context.Background(), "newadmin", "Str0ngPass", constants.RoleAdmin, &caller)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RoleAdmin, user.Role)
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+)
+
+// maxWatchesPerUser bounds how many courses and sections one user can watch.
+const maxWatchesPerUser = 50
+
+var (
+	ErrWatchNotFound   = errors.New("watch not found")
+	ErrWatchExists     = errors.New("already watching this course or section")
+	ErrWatchLimit      = errors.New("watch limit reached")
+	ErrSectionNotFound = errors.New("section not found")
+)
+
+// NotificationRetryPolicy controls how failed watch notifications are
+// retried. Zero fields take the defaults below.
+type NotificationRetryPolicy struct {
+	MaxAttempts int           // attempts before a notification is marked failed
+	Backoff     time.Duration // wait after the first failure, doubled after each one
+	MaxBackoff  time.Duration // cap on the wait between attempts
+	Lease       time.Duration // how long a claimed notification is hidden from other workers
+}
+
+// Default watch notification retry policy. The lease outlasts the SMTP and
+// webhook notifier timeouts, so a slow send is not claimed twice.
+const (
+	DefaultNotificationMaxAttempts = 5
+	DefaultNotificationBackoff     = time.Minute
+	DefaultNotificationMaxBackoff  = time.Hour
+	DefaultNotificationLease       = 2 * time.Minute
+)
+
+// WatchUsecase manages users' course watches and notifies them of changes.
+type WatchUsecase interface {
+	// Create watches a course, or one of its sections when sectionID is set.
+	Create(ctx context.Context, userID, courseID, sectionID string) (*entity.Watch, error)
+	List(ctx context.Context, userID string) ([]*entity.Watch, error)
+	Delete(ctx context.Context, userID, id string) error
+	// NotifyDue sends every notification that is due and returns how many
+	// were attempted.
+	NotifyDue(ctx context.Context) int
+	// An outbox event sink: a notification is queued for each watcher of
+	// the course a course.changed event is about.
+	repository.EventSink
+}
+
+type watchUsecase struct {
+	watches       repository.WatchRepository
+	notifications repository.WatchNotificationRepository
+	courses       repository.CourseRepository
+	users         repository.UserRepository
+	notifier      repository.CourseChangeNotifier
+	retry         NotificationRetryPolicy
+	now           func() time.Time
+}
+
+// NewWatchUsecase creates a new instance of WatchUsecase.
+func NewWatchUsecase(watches repository.WatchRepository, notifications repository.WatchNotificationRepository, courses repository.CourseRepository, users repository.UserRepository, notifier repository.CourseChangeNotifier, retry NotificationRetryPolicy) WatchUsecase {
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = DefaultNotificationMaxAttempts
+	}
+	if retry.Backoff <= 0 {
+		retry.Backoff = DefaultNotificationBackoff
+	}
+	if retry.MaxBackoff <= 0 {
+		retry.MaxBackoff = DefaultNotificationMaxBackoff
+	}
+	if retry.Lease <= 0 {
+		retry.Lease = DefaultNotificationLease
+	}
+	return &watchUsecase{
+		watches:       watches,
+		notifications: notifications,
+		courses:       courses,
+		users:         users,
+		notifier:      notifier,
+		retry:         retry,
+		now:           time.Now,
+	}
+}
+
+func (u *watchUsecase) Create(ctx context.Context, userID, courseID, sectionID string) (*entity.Watch, error) {
+	course, err := u.courses.GetByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	watch := &entity.Watch{
+		UserID:     userID,
+		CourseID:   course.ID,
+		CourseCode: course.Code,
+		Year:       course.Year,
+		Semester:   course.Semester,
+	}
+	if sectionID != "" {
+		found := false
+		for _, sec := range course.Sections {
+			if sec.ID == sectionID {
+				watch.SectionID, watch.Section, found = sec.ID, sec.Number, true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrSectionNotFound
+		}
+	}
+
+	existing, err := u.watches.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range existing {
+		if w.CourseID == watch.CourseID && w.SectionID == watch.SectionID {
+			return nil, ErrWatchExists
+		}
+	}
+	if len(existing) >= maxWatchesPerUser {
+		return nil, ErrWatchLimit
+	}
+
+	if err := u.watches.Create(ctx, watch); err != nil {
+		return nil, err
+	}
+	return watch, nil
+}
+
+func (u *watchUsecase) List(ctx context.Context, userID string) ([]*entity.Watch, error) {
+	return u.watches.GetByUser(ctx, userID)
+}
+
+func (u *watchUsecase) Delete(ctx context.Context, userID, id string) error {
+	ok, err := u.watches.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrWatchNotFound
+	}
+	return nil
+}
+
+func (u *watchUsecase) Name() string { return "watch" }
+
+// Handle queues one notification for each user watching the course of a
+// course.changed event, with the changes their watches cover: every change
+// for a course watch, or the changes to the watched sections. Other events
+// are ignored. Handling an event again queues no duplicates.
+func (u *watchUsecase) Handle(ctx context.Context, event *entity.DomainEvent) error {
+	if event.Type != constants.EventCourseChanged {
+		return nil
+	}
+	var change entity.CourseChangeEvent
+	if err := json.Unmarshal(event.Data, &change); err != nil {
+		log.Printf("[watch] skipping event %s: %v", event.ID, err)
+		return nil // retrying cannot fix the data
+	}
+
+	watches, err := u.watches.GetByCourse(ctx, event.AggregateID)
+	if err != nil {
+		return err
+	}
+
+	type coverage struct {
+		course   bool
+		sections map[string]bool
+	}
+	var userIDs []string
+	covered := map[string]*coverage{}
+	for _, w := range watches {
+		c, ok := covered[w.UserID]
+		if !ok {
+			c = &coverage{sections: map[string]bool{}}
+			covered[w.UserID] = c
+			userIDs = append(userIDs, w.UserID)
+		}
+		if w.SectionID == "" {
+			c.course = true
+		} else {
+			c.sections[w.SectionID] = true
+		}
+	}
+
+	queued := 0
+	now := u.now()
+	for _, userID := range userIDs {
+		c := covered[userID]
+		var changes []entity.CourseChange
+		for _, ch := range change.Changes {
+			if c.course || (ch.SectionID != "" && c.sections[ch.SectionID]) {
+				changes = append(changes, ch)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		notification := &entity.WatchNotification{
+			EventID:       event.ID,
+			UserID:        userID,
+			CourseID:      event.AggregateID,
+			Change:        change,
+			Status:        entity.WatchNotificationPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		notification.Change.Changes = changes
+		if err := u.notifications.Create(ctx, notification); err != nil {
+			return fmt.Errorf("queue notification for user %s: %w", userID, err)
+		}
+		queued++
+	}
+	if queued > 0 {
+		log.Printf("[watch] course %s:%d:%d: queued %d notifications", change.CourseCode, change.Year, change.Semester, queued)
+	}
+	return nil
+}
+
+func (u *watchUsecase) NotifyDue(ctx context.Context) int {
+	attempted := 0
+	for ctx.Err() == nil {
+		notification, err := u.notifications.ClaimDue(ctx, u.now(), u.retry.Lease)
+		if err != nil {
+			log.Printf("[watch] failed to claim due notifications: %v", err)
+			break
+		}
+		if notification == nil {
+			break
+		}
+		u.attempt(ctx, notification)
+		attempted++
+	}
+	return attempted
+}
+
+// attempt sends a claimed notification once and saves the outcome,
+// scheduling a retry with exponential backoff until the attempts run out.
+// Notifications to disabled or deleted users are dropped unsent.
+func (u *watchUsecase) attempt(ctx context.Context, notification *entity.WatchNotification) {
+	user, err := u.users.FindByID(ctx, notification.UserID)
+	if err != nil {
+		log.Printf("[watch] failed to load user %s for notification %s: %v", notification.UserID, notification.ID, err)
+		return // the lease expires and the notification is claimed again
+	}
+
+	if user == nil || user.Disabled {
+		notification.Status = entity.WatchNotificationFailed
+		notification.LastError = "user deleted or disabled"
+		if err := u.notifications.Update(ctx, notification); err != nil {
+			log.Printf("[watch] failed to save notification %s: %v", notification.ID, err)
+		}
+		return
+	}
+
+	err = u.notifier.NotifyCourseChanges(ctx, user, &notification.Change)
+	if ctx.Err() != nil {
+		return // shutting down; the lease expires and it is sent again
+	}
+	notification.Attempts++
+
+	now := u.now()
+	switch {
+	case err == nil:
+		notification.Status = entity.WatchNotificationSent
+		notification.LastError = ""
+		notification.SentAt = &now
+	case notification.Attempts >= u.retry.MaxAttempts:
+		notification.Status = entity.WatchNotificationFailed
+		notification.LastError = err.Error()
+		log.Printf("[watch] notification %s to user %s failed after %d attempts: %v", notification.ID, notification.UserID, notification.Attempts, err)
+	default:
+		notification.Status = entity.WatchNotificationPending
+		notification.LastError = err.Error()
+		notification.NextAttemptAt = now.Add(backoff(u.retry.Backoff, u.retry.MaxBackoff, notification.Attempts))
+	}
+
+	if err := u.notifications.Update(ctx, notification); err != nil {
+		log.Printf("[watch] failed to save notification %s: %v", notification.ID, err)
+	}
+}
+
+// NewWatchNotifier creates a worker that sends due watch notifications
+// every interval. Stopping it abandons any notification in progress, which
+// is sent again once its lease expires.
+func NewWatchNotifier(watches WatchUsecase, interval time.Duration) *Worker {
+	return NewWorker("watch", interval, func(ctx context.Context) {
+		watches.NotifyDue(ctx)
+	})
+}
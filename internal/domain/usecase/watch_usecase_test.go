@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ----- In-memory WatchRepository -----
+
+type fakeWatchRepo struct {
+	watches []*entity.Watch
+}
+
+func (r *fakeWatchRepo) Create(_ context.Context, w *entity.Watch) error {
+	w.ID = fmt.Sprintf("w%d", len(r.watches)+1)
+	r.watches = append(r.watches, w)
+	return nil
+}
+
+func (r *fakeWatchRepo) GetByUser(_ context.Context, userID string) ([]*entity.Watch, error) {
+	var out []*entity.Watch
+	for _, w := range r.watches {
+		if w.UserID == userID {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeWatchRepo) GetByCourse(_ context.Context, courseID string) ([]*entity.Watch, error) {
+	var out []*entity.Watch
+	for _, w := range r.watches {
+		if w.CourseID == courseID {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeWatchRepo) Delete(_ context.Context, id, userID string) (bool, error) {
+	for i, w := range r.watches {
+		if w.ID == id && w.UserID == userID {
+			r.watches = append(r.watches[:i], r.watches[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ----- In-memory WatchNotificationRepository -----
+
+type fakeWatchNotificationRepo struct {
+	notifications []*entity.WatchNotification
+}
+
+func (r *fakeWatchNotificationRepo) Create(_ context.Context, n *entity.WatchNotification) error {
+	for _, existing := range r.notifications {
+		if existing.EventID == n.EventID && existing.UserID == n.UserID {
+			return nil // unique per event and user
+		}
+	}
+	n.ID = fmt.Sprintf("n%d", len(r.notifications)+1)
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+func (r *fakeWatchNotificationRepo) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*entity.WatchNotification, error) {
+	for _, n := range r.notifications {
+		if n.Status == entity.WatchNotificationPending && !n.NextAttemptAt.After(now) {
+			n.NextAttemptAt = now.Add(lease)
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeWatchNotificationRepo) Update(_ context.Context, _ *entity.WatchNotification) error {
+	return nil // notifications are shared with the caller
+}
+
+// byUser returns the notification queued for userID, or nil.
+func (r *fakeWatchNotificationRepo) byUser(userID string) *entity.WatchNotification {
+	for _, n := range r.notifications {
+		if n.UserID == userID {
+			return n
+		}
+	}
+	return nil
+}
+
+// ----- Capturing change notifier -----
+
+type captureChangeNotifier struct {
+	sent map[string]*entity.CourseChangeEvent // by user ID
+	err  error
+}
+
+func (n *captureChangeNotifier) NotifyCourseChanges(_ context.Context, user *entity.User, event *entity.CourseChangeEvent) error {
+	if n.err != nil {
+		return n.err
+	}
+	if n.sent == nil {
+		n.sent = map[string]*entity.CourseChangeEvent{}
+	}
+	n.sent[user.ID] = event
+	return nil
+}
+
+func watchTestCourses() *mockCourseRepo {
+	repo := newMockCourseRepo()
+	repo.courses[mockKey("CP353004", 2568, 1)] = &entity.Course{
+		BaseEntity: entity.BaseEntity{ID: "c1"}, Code: "CP353004", Year: 2568, Semester: 1,
+		Sections: []entity.Section{{ID: "s1", Number: "01"}, {ID: "s2", Number: "02"}},
+	}
+	return repo
+}
+
+func TestWatchCreate(t *testing.T) {
+	watches := &fakeWatchRepo{}
+	uc := NewWatchUsecase(watches, &fakeWatchNotificationRepo{}, watchTestCourses(), new(mockUserRepo), &captureChangeNotifier{}, NotificationRetryPolicy{})
+	ctx := context.Background()
+
+	w, err := uc.Create(ctx, "u1", "c1", "s2")
+	assert.NoError(t, err)
+	assert.Equal(t, "CP353004", w.CourseCode)
+	assert.Equal(t, "02", w.Section)
+
+	_, err = uc.Create(ctx, "u1", "c1", "")
+	assert.NoError(t, err, "a course watch is distinct from a section watch")
+	_, err = uc.Create(ctx, "u1", "c1", "s2")
+	assert.ErrorIs(t, err, ErrWatchExists)
+	_, err = uc.Create(ctx, "u1", "c1", "s9")
+	assert.ErrorIs(t, err, ErrSectionNotFound)
+	_, err = uc.Create(ctx, "u1", "missing", "")
+	assert.ErrorIs(t, err, ErrCourseNotFound)
+
+	list, err := uc.List(ctx, "u1")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	assert.ErrorIs(t, uc.Delete(ctx, "u2", w.ID), ErrWatchNotFound, "users cannot delete each other's watches")
+	assert.NoError(t, uc.Delete(ctx, "u1", w.ID))
+}
+
+func TestWatchCreate_Limit(t *testing.T) {
+	watches := &fakeWatchRepo{}
+	for i := 0; i < maxWatchesPerUser; i++ {
+		watches.watches = append(watches.watches, &entity.Watch{UserID: "u1", CourseID: "other"})
+	}
+	uc := NewWatchUsecase(watches, &fakeWatchNotificationRepo{}, watchTestCourses(), new(mockUserRepo), &captureChangeNotifier{}, NotificationRetryPolicy{})
+
+	_, err := uc.Create(context.Background(), "u1", "c1", "")
+	assert.ErrorIs(t, err, ErrWatchLimit)
+}
+
+func newTestWatchUsecase(watches *fakeWatchRepo, users *mockUserRepo, notifier *captureChangeNotifier, retry NotificationRetryPolicy) (*watchUsecase, *fakeWatchNotificationRepo, *time.Time) {
+	notifications := &fakeWatchNotificationRepo{}
+	uc := NewWatchUsecase(watches, notifications, watchTestCourses(), users, notifier, retry).(*watchUsecase)
+	now := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	return uc, notifications, &now
+}
+
+func TestWatchHandle(t *testing.T) {
+	watches := &fakeWatchRepo{watches: []*entity.Watch{
+		{UserID: "course-watcher", CourseID: "c1"},
+		{UserID: "s1-watcher", CourseID: "c1", SectionID: "s1"},
+		{UserID: "s2-watcher", CourseID: "c1", SectionID: "s2"},
+		{UserID: "elsewhere", CourseID: "c2"},
+	}}
+	users := new(mockUserRepo)
+	uc, notifications, now := newTestWatchUsecase(watches, users, &captureChangeNotifier{}, NotificationRetryPolicy{})
+
+	event := courseChangedEvent(t, "c1", &entity.CourseChangeEvent{CourseCode: "CP353004", Changes: []entity.CourseChange{
+		{Kind: entity.CourseChangeInfo, Detail: "credits"},
+		{Kind: entity.CourseChangeSectionClosed, SectionID: "s1", Section: "01", New: "Closed"},
+	}})
+	assert.NoError(t, uc.Handle(context.Background(), event))
+	assert.NoError(t, uc.Handle(context.Background(), event), "handling the event again queues no duplicates")
+
+	assert.Len(t, notifications.notifications, 2, "s2-watcher has no matching changes")
+	if n := notifications.byUser("course-watcher"); assert.NotNil(t, n) {
+		assert.Equal(t, "e1", n.EventID)
+		assert.Equal(t, "c1", n.CourseID)
+		assert.Equal(t, "CP353004", n.Change.CourseCode)
+		assert.Len(t, n.Change.Changes, 2)
+		assert.Equal(t, entity.WatchNotificationPending, n.Status)
+		assert.Equal(t, *now, n.NextAttemptAt, "new notifications are due at once")
+	}
+	if n := notifications.byUser("s1-watcher"); assert.NotNil(t, n) && assert.Len(t, n.Change.Changes, 1) {
+		assert.Equal(t, entity.CourseChangeSectionClosed, n.Change.Changes[0].Kind)
+	}
+	users.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestWatchHandle_IgnoresOtherEvents(t *testing.T) {
+	watches := &fakeWatchRepo{watches: []*entity.Watch{{UserID: "u1", CourseID: "c1"}}}
+	uc, notifications, _ := newTestWatchUsecase(watches, new(mockUserRepo), &captureChangeNotifier{}, NotificationRetryPolicy{})
+
+	assert.NoError(t, uc.Handle(context.Background(), &entity.DomainEvent{Type: constants.WebhookCourseUpdated, AggregateID: "c1", Data: []byte(`{}`)}))
+	assert.NoError(t, uc.Handle(context.Background(), &entity.DomainEvent{Type: constants.EventCourseChanged, AggregateID: "c1", Data: []byte(`not json`)}),
+		"undecodable data is not retried")
+	assert.Empty(t, notifications.notifications)
+}
+
+func TestWatchNotifyDue(t *testing.T) {
+	watches := &fakeWatchRepo{watches: []*entity.Watch{
+		{UserID: "u1", CourseID: "c1"},
+		{UserID: "disabled", CourseID: "c1"},
+		{UserID: "deleted", CourseID: "c1"},
+	}}
+	users := new(mockUserRepo)
+	users.On("FindByID", mock.Anything, "u1").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}}, nil)
+	users.On("FindByID", mock.Anything, "disabled").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "disabled"}, Disabled: true}, nil)
+	users.On("FindByID", mock.Anything, "deleted").Return(nil, nil)
+	notifier := &captureChangeNotifier{}
+	uc, notifications, now := newTestWatchUsecase(watches, users, notifier, NotificationRetryPolicy{})
+	assert.NoError(t, uc.Handle(context.Background(), courseChangedEvent(t, "c1",
+		&entity.CourseChangeEvent{CourseCode: "CP353004", Changes: []entity.CourseChange{{Kind: entity.CourseChangeSeats, SectionID: "s1"}}})))
+
+	assert.Equal(t, 3, uc.NotifyDue(context.Background()))
+	assert.Len(t, notifier.sent, 1, "disabled and deleted users are not notified")
+	assert.Equal(t, "CP353004", notifier.sent["u1"].CourseCode)
+	if n := notifications.byUser("u1"); assert.NotNil(t, n) {
+		assert.Equal(t, entity.WatchNotificationSent, n.Status)
+		assert.Equal(t, 1, n.Attempts)
+		if assert.NotNil(t, n.SentAt) {
+			assert.Equal(t, *now, *n.SentAt)
+		}
+	}
+	for _, id := range []string{"disabled", "deleted"} {
+		n := notifications.byUser(id)
+		assert.Equal(t, entity.WatchNotificationFailed, n.Status, id)
+		assert.Equal(t, 0, n.Attempts, id)
+	}
+	assert.Equal(t, 0, uc.NotifyDue(context.Background()), "finished notifications are not sent again")
+}
+
+func TestWatchNotifyDue_RetriesWithBackoff(t *testing.T) {
+	watches := &fakeWatchRepo{watches: []*entity.Watch{{UserID: "u1", CourseID: "c1"}}}
+	users := new(mockUserRepo)
+	users.On("FindByID", mock.Anything, "u1").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}}, nil)
+	notifier := &captureChangeNotifier{err: errors.New("smtp down")}
+	uc, notifications, now := newTestWatchUsecase(watches, users, notifier,
+		NotificationRetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 90 * time.Second})
+	assert.NoError(t, uc.Handle(context.Background(), courseChangedEvent(t, "c1",
+		&entity.CourseChangeEvent{Changes: []entity.CourseChange{{Kind: entity.CourseChangeSeats, SectionID: "s1"}}})))
+	n := notifications.notifications[0]
+
+	for attempt, wait := range []time.Duration{time.Minute, 90 * time.Second} {
+		assert.Equal(t, 1, uc.NotifyDue(context.Background()))
+		assert.Equal(t, entity.WatchNotificationPending, n.Status)
+		assert.Equal(t, attempt+1, n.Attempts)
+		assert.Equal(t, "smtp down", n.LastError)
+		assert.Equal(t, now.Add(wait), n.NextAttemptAt)
+		assert.Equal(t, 0, uc.NotifyDue(context.Background()), "not due before its backoff")
+		*now = n.NextAttemptAt
+	}
+
+	assert.Equal(t, 1, uc.NotifyDue(context.Background()))
+	assert.Equal(t, entity.WatchNotificationFailed, n.Status, "gives up after MaxAttempts")
+	assert.Equal(t, 3, n.Attempts)
+	assert.Nil(t, n.SentAt)
+}
+
+func TestWatchNotifyDue_LeavesNotificationOnShutdown(t *testing.T) {
+	watches := &fakeWatchRepo{watches: []*entity.Watch{{UserID: "u1", CourseID: "c1"}}}
+	users := new(mockUserRepo)
+	users.On("FindByID", mock.Anything, "u1").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}}, nil)
+	uc, notifications, _ := newTestWatchUsecase(watches, users, &captureChangeNotifier{err: errors.New("canceled")}, NotificationRetryPolicy{})
+	assert.NoError(t, uc.Handle(context.Background(), courseChangedEvent(t, "c1",
+		&entity.CourseChangeEvent{Changes: []entity.CourseChange{{Kind: entity.CourseChangeSeats, SectionID: "s1"}}})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uc.attempt(ctx, notifications.notifications[0])
+	assert.Equal(t, 0, notifications.notifications[0].Attempts, "an interrupted attempt is not counted")
+	assert.Equal(t, entity.WatchNotificationPending, notifications.notifications[0].Status)
+}
+
+// courseChangedEvent builds the course.changed outbox event for a change to courseID.
+func courseChangedEvent(t *testing.T, courseID string, change *entity.CourseChangeEvent) *entity.DomainEvent {
+	t.Helper()
+	data, err := json.Marshal(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &entity.DomainEvent{ID: "e1", Type: constants.EventCourseChanged, AggregateID: courseID, Data: data}
+}
@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
+)
+
+var generateWebhookSecret = func() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute http or https URL")
+	ErrUnknownWebhookEvent     = errors.New("unknown webhook event")
+)
+
+// WebhookRetryPolicy controls how failed deliveries are retried. Zero fields
+// take the defaults below.
+type WebhookRetryPolicy struct {
+	MaxAttempts int           // attempts before a delivery is marked failed
+	Backoff     time.Duration // wait after the first failure, doubled after each one
+	MaxBackoff  time.Duration // cap on the wait between attempts
+	Lease       time.Duration // how long a claimed delivery is hidden from other workers
+}
+
+// Default webhook retry policy.
+const (
+	DefaultWebhookMaxAttempts = 8
+	DefaultWebhookBackoff     = 30 * time.Second
+	DefaultWebhookMaxBackoff  = time.Hour
+	DefaultWebhookLease       = time.Minute
+)
+
+// WebhookUsecase manages webhook subscriptions and delivers events to them.
+type WebhookUsecase interface {
+	// Create validates and stores a webhook, generating its secret when
+	// none is given. The secret is only returned here.
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	List(ctx context.Context) ([]*entity.Webhook, error)
+	GetByID(ctx context.Context, id string) (*entity.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, filter repository.WebhookDeliveryFilter, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.WebhookDelivery], error)
+	GetDelivery(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	// Replay queues a new delivery of the same payload to the same webhook.
+	Replay(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	// DeliverDue attempts every delivery that is due and returns how many
+	// were attempted.
+	DeliverDue(ctx context.Context) int
+	// The outbox relay hands the usecase every domain event; those a
+	// webhook subscribes to are queued for delivery.
+	repository.EventSink
+}
+
+type webhookUsecase struct {
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	sender     repository.WebhookSender
+	retry      WebhookRetryPolicy
+	now        func() time.Time
+}
+
+// NewWebhookUsecase creates a new instance of WebhookUsecase.
+func NewWebhookUsecase(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, sender repository.WebhookSender, retry WebhookRetryPolicy) WebhookUsecase {
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if retry.Backoff <= 0 {
+		retry.Backoff = DefaultWebhookBackoff
+	}
+	if retry.MaxBackoff <= 0 {
+		retry.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if retry.Lease <= 0 {
+		retry.Lease = DefaultWebhookLease
+	}
+	return &webhookUsecase{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		sender:     sender,
+		retry:      retry,
+		now:        time.Now,
+	}
+}
+
+func (u *webhookUsecase) Create(ctx context.Context, webhook *entity.Webhook) error {
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if len(webhook.Events) == 0 {
+		return ErrUnknownWebhookEvent
+	}
+	for _, e := range webhook.Events {
+		if !constants.ValidWebhookEvents[e] {
+			return ErrUnknownWebhookEvent
+		}
+	}
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+	return u.webhooks.Create(ctx, webhook)
+}
+
+func (u *webhookUsecase) List(ctx context.Context) ([]*entity.Webhook, error) {
+	return u.webhooks.GetAll(ctx)
+}
+
+func (u *webhookUsecase) GetByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	webhook, err := u.webhooks.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (u *webhookUsecase) Delete(ctx context.Context, id string) error {
+	deleted, err := u.webhooks.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (u *webhookUsecase) ListDeliveries(ctx context.Context, filter repository.WebhookDeliveryFilter, pq pagination.PaginationQuery) (*pagination.PaginatedResult[*entity.WebhookDelivery], error) {
+	items, total, err := u.deliveries.GetPaginated(ctx, filter, pq.Page, pq.Limit)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewResult(items, pq.Page, pq.Limit, total)
+	return &result, nil
+}
+
+func (u *webhookUsecase) GetDelivery(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	delivery, err := u.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (u *webhookUsecase) Replay(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	original, err := u.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.GetByID(ctx, original.WebhookID); err != nil {
+		return nil, err
+	}
+	replay := &entity.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: u.now(),
+		ReplayOf:      original.ID,
+	}
+	if err := u.deliveries.Create(ctx, replay); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// webhookPayload is the JSON body of every delivery. ID is the domain
+// event's ID, so receivers can drop duplicates: retries, replays and an
+// event relayed twice all reuse it.
+type webhookPayload struct {
+	ID         string          `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (u *webhookUsecase) Name() string { return "webhook" }
+
+// Handle queues a delivery of the event to every webhook subscribed to it.
+// The dispatcher sends them. If queueing fails part way the relay hands the
+// event over again, so earlier subscribers may get it twice.
+func (u *webhookUsecase) Handle(ctx context.Context, event *entity.DomainEvent) error {
+	if !constants.ValidWebhookEvents[event.Type] {
+		return nil
+	}
+	webhooks, err := u.webhooks.GetByEvent(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:         event.ID,
+		Event:      event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       json.RawMessage(event.Data),
+	})
+	if err != nil {
+		return err
+	}
+	now := u.now()
+	for _, w := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			WebhookID:     w.ID,
+			Event:         event.Type,
+			Payload:       payload,
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		}
+		if err := u.deliveries.Create(ctx, delivery); err != nil {
+			return fmt.Errorf("queue %s for webhook %s: %w", event.Type, w.ID, err)
+		}
+	}
+	return nil
+}
+
+func (u *webhookUsecase) DeliverDue(ctx context.Context) int {
+	attempted := 0
+	for ctx.Err() == nil {
+		delivery, err := u.deliveries.ClaimDue(ctx, u.now(), u.retry.Lease)
+		if err != nil {
+			log.Printf("[webhook] failed to claim due deliveries: %v", err)
+			break
+		}
+		if delivery == nil {
+			break
+		}
+		u.attempt(ctx, delivery)
+		attempted++
+	}
+	return attempted
+}
+
+// attempt sends a claimed delivery once and saves the outcome, scheduling
+// a retry with exponential backoff until the attempts run out.
+func (u *webhookUsecase) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	webhook, err := u.webhooks.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		log.Printf("[webhook] failed to load webhook %s for delivery %s: %v", delivery.WebhookID, delivery.ID, err)
+		return // the lease expires and the delivery is claimed again
+	}
+
+	if webhook == nil {
+		// Deleted since the event was queued: nowhere left to send it.
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = ErrWebhookNotFound.Error()
+		if err := u.deliveries.Update(ctx, delivery); err != nil {
+			log.Printf("[webhook] failed to save delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	status, err := u.sender.Send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		return // shutting down; the lease expires and it is sent again
+	}
+	delivery.Attempts++
+	delivery.LastStatusCode = status
+
+	now := u.now()
+	switch {
+	case err == nil:
+		delivery.Status = entity.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= u.retry.MaxAttempts:
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+		log.Printf("[webhook] delivery %s of %s failed after %d attempts: %v", delivery.ID, delivery.Event, delivery.Attempts, err)
+	default:
+		delivery.Status = entity.WebhookDeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(backoff(u.retry.Backoff, u.retry.MaxBackoff, delivery.Attempts))
+	}
+
+	if err := u.deliveries.Update(ctx, delivery); err != nil {
+		log.Printf("[webhook] failed to save delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// NewWebhookDispatcher creates a worker that delivers due webhook
+// deliveries every interval. Stopping it abandons any delivery in progress,
+// which is retried once its lease expires.
+func NewWebhookDispatcher(webhooks WebhookUsecase, interval time.Duration) *Worker {
+	return NewWorker("webhook", interval, func(ctx context.Context) {
+		webhooks.DeliverDue(ctx)
+	})
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/constants"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+// ----- In-memory webhook repositories -----
+
+type fakeWebhookRepo struct {
+	webhooks []*entity.Webhook
+}
+
+func (r *fakeWebhookRepo) Create(_ context.Context, w *entity.Webhook) error {
+	w.ID = fmt.Sprintf("h%d", len(r.webhooks)+1)
+	r.webhooks = append(r.webhooks, w)
+	return nil
+}
+
+func (r *fakeWebhookRepo) GetAll(_ context.Context) ([]*entity.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r *fakeWebhookRepo) GetByID(_ context.Context, id string) (*entity.Webhook, error) {
+	for _, w := range r.webhooks {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeWebhookRepo) GetByEvent(_ context.Context, event string) ([]*entity.Webhook, error) {
+	var out []*entity.Webhook
+	for _, w := range r.webhooks {
+		if w.Subscribes(event) {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeWebhookRepo) Delete(_ context.Context, id string) (bool, error) {
+	for i, w := range r.webhooks {
+		if w.ID == id {
+			r.webhooks = append(r.webhooks[:i], r.webhooks[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeWebhookDeliveryRepo struct {
+	deliveries []*entity.WebhookDelivery
+	nextID     int
+}
+
+func (r *fakeWebhookDeliveryRepo) Create(_ context.Context, d *entity.WebhookDelivery) error {
+	r.nextID++
+	d.ID = fmt.Sprintf("d%d", r.nextID)
+	r.deliveries = append(r.deliveries, d)
+	return nil
+}
+
+func (r *fakeWebhookDeliveryRepo) GetByID(_ context.Context, id string) (*entity.WebhookDelivery, error) {
+	for _, d := range r.deliveries {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeWebhookDeliveryRepo) GetPaginated(_ context.Context, f repository.WebhookDeliveryFilter, _, _ int) ([]*entity.WebhookDelivery, int64, error) {
+	var out []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if (f.WebhookID == "" || d.WebhookID == f.WebhookID) && (f.Event == "" || d.Event == f.Event) && (f.Status == "" || d.Status == f.Status) {
+			out = append(out, d)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (r *fakeWebhookDeliveryRepo) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*entity.WebhookDelivery, error) {
+	due := make([]*entity.WebhookDelivery, 0, len(r.deliveries))
+	for _, d := range r.deliveries {
+		if d.Status == entity.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	due[0].NextAttemptAt = now.Add(lease)
+	claimed := *due[0]
+	return &claimed, nil
+}
+
+func (r *fakeWebhookDeliveryRepo) Update(_ context.Context, d *entity.WebhookDelivery) error {
+	for i, existing := range r.deliveries {
+		if existing.ID == d.ID {
+			updated := *d
+			r.deliveries[i] = &updated
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+// ----- Scripted sender -----
+
+type fakeWebhookSender struct {
+	statuses []int // returned in turn; the last one repeats
+	sent     []*entity.WebhookDelivery
+}
+
+func (s *fakeWebhookSender) Send(_ context.Context, _ *entity.Webhook, d *entity.WebhookDelivery) (int, error) {
+	s.sent = append(s.sent, d)
+	status := s.statuses[0]
+	if len(s.statuses) > 1 {
+		s.statuses = s.statuses[1:]
+	}
+	if status < 200 || status > 299 {
+		return status, fmt.Errorf("webhook returned %d", status)
+	}
+	return status, nil
+}
+
+func newTestWebhookUsecase(sender *fakeWebhookSender, retry WebhookRetryPolicy) (*webhookUsecase, *fakeWebhookRepo, *fakeWebhookDeliveryRepo, *time.Time) {
+	hooks := &fakeWebhookRepo{}
+	deliveries := &fakeWebhookDeliveryRepo{}
+	uc := NewWebhookUsecase(hooks, deliveries, sender, retry).(*webhookUsecase)
+	now := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	return uc, hooks, deliveries, &now
+}
+
+// ----- Create -----
+
+func TestWebhookCreate_GeneratesSecret(t *testing.T) {
+	uc, hooks, _, _ := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+
+	w := &entity.Webhook{URL: "https://example.com/hook", Events: []string{constants.WebhookCourseUpdated}}
+	assert.NoError(t, uc.Create(context.Background(), w))
+	assert.NotEmpty(t, w.ID)
+	assert.Regexp(t, `^whsec_`, w.Secret)
+	assert.Len(t, hooks.webhooks, 1)
+
+	given := &entity.Webhook{URL: "http://example.com/hook", Events: []string{constants.WebhookCourseCreated}, Secret: "my-own-secret-value"}
+	assert.NoError(t, uc.Create(context.Background(), given))
+	assert.Equal(t, "my-own-secret-value", given.Secret)
+}
+
+func TestWebhookCreate_Invalid(t *testing.T) {
+	uc, hooks, _, _ := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+
+	for _, w := range []*entity.Webhook{
+		{URL: "ftp://example.com/hook", Events: []string{constants.WebhookCourseUpdated}},
+		{URL: "/relative", Events: []string{constants.WebhookCourseUpdated}},
+	} {
+		assert.ErrorIs(t, uc.Create(context.Background(), w), ErrInvalidWebhookURL)
+	}
+	for _, events := range [][]string{nil, {"course.renamed"}} {
+		w := &entity.Webhook{URL: "https://example.com/hook", Events: events}
+		assert.ErrorIs(t, uc.Create(context.Background(), w), ErrUnknownWebhookEvent)
+	}
+	assert.Empty(t, hooks.webhooks)
+}
+
+func TestWebhookDelete_NotFound(t *testing.T) {
+	uc, _, _, _ := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+	assert.ErrorIs(t, uc.Delete(context.Background(), "missing"), ErrWebhookNotFound)
+}
+
+// ----- Handle -----
+
+func courseEvent(eventType string) *entity.DomainEvent {
+	return &entity.DomainEvent{
+		ID:          "e1",
+		Type:        eventType,
+		AggregateID: "c1",
+		Data:        []byte(`{"code":"CP353004"}`),
+		OccurredAt:  time.Date(2026, 1, 5, 7, 59, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookHandle_QueuesForSubscribers(t *testing.T) {
+	uc, hooks, deliveries, now := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+	hooks.webhooks = []*entity.Webhook{
+		{ID: "h1", Events: []string{constants.WebhookCourseUpdated}},
+		{ID: "h2", Events: []string{constants.WebhookCourseDeleted}},
+		{ID: "h3", Events: []string{constants.WebhookCourseUpdated, constants.WebhookCourseDeleted}},
+	}
+
+	assert.NoError(t, uc.Handle(context.Background(), courseEvent(constants.WebhookCourseUpdated)))
+
+	if assert.Len(t, deliveries.deliveries, 2) {
+		first, second := deliveries.deliveries[0], deliveries.deliveries[1]
+		assert.Equal(t, "h1", first.WebhookID)
+		assert.Equal(t, "h3", second.WebhookID)
+		assert.Equal(t, entity.WebhookDeliveryPending, first.Status)
+		assert.Equal(t, *now, first.NextAttemptAt)
+		assert.Equal(t, first.Payload, second.Payload, "every subscriber gets the same event")
+
+		var payload struct {
+			ID         string            `json:"id"`
+			Event      string            `json:"event"`
+			OccurredAt time.Time         `json:"occurred_at"`
+			Data       map[string]string `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(first.Payload, &payload))
+		assert.Equal(t, "e1", payload.ID, "the payload is identified by the domain event")
+		assert.Equal(t, constants.WebhookCourseUpdated, payload.Event)
+		assert.Equal(t, courseEvent("").OccurredAt, payload.OccurredAt)
+		assert.Equal(t, "CP353004", payload.Data["code"])
+	}
+}
+
+func TestWebhookHandle_NoSubscribers(t *testing.T) {
+	uc, hooks, deliveries, _ := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+	assert.NoError(t, uc.Handle(context.Background(), courseEvent(constants.WebhookCourseCreated)))
+	assert.Empty(t, deliveries.deliveries)
+
+	hooks.webhooks = []*entity.Webhook{{ID: "h1", Events: []string{constants.WebhookCourseCreated}}}
+	assert.NoError(t, uc.Handle(context.Background(), courseEvent(constants.EventUserCreated)))
+	assert.Empty(t, deliveries.deliveries, "events webhooks cannot subscribe to are ignored")
+}
+
+// ----- DeliverDue -----
+
+func TestWebhookDeliverDue_Succeeds(t *testing.T) {
+	sender := &fakeWebhookSender{statuses: []int{204}}
+	uc, hooks, deliveries, now := newTestWebhookUsecase(sender, WebhookRetryPolicy{})
+	hooks.webhooks = []*entity.Webhook{{ID: "h1", Events: []string{constants.WebhookCourseCreated}}}
+	assert.NoError(t, uc.Handle(context.Background(), courseEvent(constants.WebhookCourseCreated)))
+
+	assert.Equal(t, 1, uc.DeliverDue(context.Background()))
+	d := deliveries.deliveries[0]
+	assert.Equal(t, entity.WebhookDeliverySucceeded, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, 204, d.LastStatusCode)
+	if assert.NotNil(t, d.DeliveredAt) {
+		assert.Equal(t, *now, *d.DeliveredAt)
+	}
+	assert.Equal(t, 0, uc.DeliverDue(context.Background()), "nothing is left to send")
+}
+
+func TestWebhookDeliverDue_RetriesWithBackoffThenFails(t *testing.T) {
+	sender := &fakeWebhookSender{statuses: []int{500}}
+	uc, hooks, deliveries, now := newTestWebhookUsecase(sender, WebhookRetryPolicy{
+		MaxAttempts: 4, Backoff: time.Minute, MaxBackoff: 3 * time.Minute,
+	})
+	hooks.webhooks = []*entity.Webhook{{ID: "h1", Events: []string{constants.WebhookCourseCreated}}}
+	assert.NoError(t, uc.Handle(context.Background(), courseEvent(constants.WebhookCourseCreated)))
+
+	for attempt, wait := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		assert.Equal(t, 1, uc.DeliverDue(context.Background()))
+		d := deliveries.deliveries[0]
+		assert.Equal(t, entity.WebhookDeliveryPending, d.Status)
+		assert.Equal(t, attempt+1, d.Attempts)
+		assert.Equal(t, 500, d.LastStatusCode)
+		assert.Equal(t, "webhook returned 500", d.LastError)
+		assert.Equal(t, now.Add(wait), d.NextAttemptAt)
+
+		assert.Equal(t, 0, uc.DeliverDue(context.Background()), "not due before the backoff")
+		*now = d.NextAttemptAt
+	}
+
+	assert.Equal(t, 1, uc.DeliverDue(context.Background()))
+	d := deliveries.deliveries[0]
+	assert.Equal(t, entity.WebhookDeliveryFailed, d.Status)
+	assert.Equal(t, 4, d.Attempts)
+	assert.Len(t, sender.sent, 4)
+}
+
+func TestWebhookDeliverDue_WebhookDeleted(t *testing.T) {
+	sender := &fakeWebhookSender{statuses: []int{200}}
+	uc, _, deliveries, now := newTestWebhookUsecase(sender, WebhookRetryPolicy{})
+	deliveries.deliveries = []*entity.WebhookDelivery{{ID: "d1", WebhookID: "gone", Status: entity.WebhookDeliveryPending, NextAttemptAt: *now}}
+
+	assert.Equal(t, 1, uc.DeliverDue(context.Background()))
+	assert.Equal(t, entity.WebhookDeliveryFailed, deliveries.deliveries[0].Status)
+	assert.Equal(t, "webhook not found", deliveries.deliveries[0].LastError)
+	assert.Equal(t, 0, deliveries.deliveries[0].Attempts)
+	assert.Empty(t, sender.sent)
+}
+
+// ----- Deliveries & Replay -----
+
+func TestWebhookListDeliveries(t *testing.T) {
+	uc, _, deliveries, _ := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+	deliveries.deliveries = []*entity.WebhookDelivery{
+		{ID: "d1", WebhookID: "h1", Status: entity.WebhookDeliveryFailed},
+		{ID: "d2", WebhookID: "h1", Status: entity.WebhookDeliverySucceeded},
+	}
+
+	result, err := uc.ListDeliveries(context.Background(), repository.WebhookDeliveryFilter{Status: entity.WebhookDeliveryFailed}, pagination.FromQuery(1, 10))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	assert.Equal(t, "d1", result.Items[0].ID)
+}
+
+func TestWebhookReplay(t *testing.T) {
+	uc, hooks, deliveries, now := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+	hooks.webhooks = []*entity.Webhook{{ID: "h1"}}
+	deliveries.deliveries = []*entity.WebhookDelivery{{
+		ID: "d1", WebhookID: "h1", Event: constants.WebhookCourseDeleted, Payload: []byte(`{"id":"e1"}`),
+		Status: entity.WebhookDeliveryFailed, Attempts: 8, LastError: "boom",
+	}}
+	deliveries.nextID = 1
+
+	replay, err := uc.Replay(context.Background(), "d1")
+	assert.NoError(t, err)
+	assert.Equal(t, "d2", replay.ID)
+	assert.Equal(t, "d1", replay.ReplayOf)
+	assert.Equal(t, "h1", replay.WebhookID)
+	assert.Equal(t, constants.WebhookCourseDeleted, replay.Event)
+	assert.Equal(t, []byte(`{"id":"e1"}`), replay.Payload)
+	assert.Equal(t, entity.WebhookDeliveryPending, replay.Status)
+	assert.Equal(t, 0, replay.Attempts)
+	assert.Equal(t, *now, replay.NextAttemptAt)
+	assert.Equal(t, entity.WebhookDeliveryFailed, deliveries.deliveries[0].Status, "the original is left as it was")
+}
+
+func TestWebhookReplay_NotFound(t *testing.T) {
+	uc, _, deliveries, _ := newTestWebhookUsecase(nil, WebhookRetryPolicy{})
+
+	_, err := uc.Replay(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrWebhookDeliveryNotFound)
+
+	deliveries.deliveries = []*entity.WebhookDelivery{{ID: "d1", WebhookID: "gone"}}
+	_, err = uc.Replay(context.Background(), "d1")
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Worker runs a polling function every interval in the background.
+type Worker struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context)
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewWorker creates a worker that calls run every interval once started.
+// run must return promptly once its context is cancelled.
+func NewWorker(name string, interval time.Duration, run func(ctx context.Context)) *Worker {
+	return &Worker{name: name, interval: interval, run: run}
+}
+
+// Start spawns the worker goroutine.
+func (w *Worker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.run(ctx)
+			}
+		}
+	}()
+	log.Printf("[%s] worker started (interval=%s)", w.name, w.interval)
+}
+
+// Stop cancels the run in progress, if any, and waits for the worker to exit.
+func (w *Worker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+	log.Printf("[%s] worker stopped", w.name)
+}
+
+// backoff returns the wait before the next attempt after the given number
+// of failed ones: base, doubled after each further failure, capped at max.
+func backoff(base, max time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
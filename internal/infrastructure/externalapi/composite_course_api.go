@@ -0,0 +1,198 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CourseSource is one upstream of a CompositeCourseAPI.
+type CourseSource struct {
+	Name string // recorded on fetched courses, e.g. "grpc"
+	API  repository.CourseExternalAPI
+}
+
+// CompositeCourseAPI queries its sources in priority order. A course comes
+// from the first source that returns it; a source that fails or does not
+// know the course is skipped. Fields the supplying source left empty are
+// then filled from lower-priority sources (see fillMissing).
+type CompositeCourseAPI struct {
+	sources []CourseSource
+}
+
+// NewCompositeCourseAPI creates a CompositeCourseAPI; sources are given
+// highest priority first.
+func NewCompositeCourseAPI(sources ...CourseSource) *CompositeCourseAPI {
+	return &CompositeCourseAPI{sources: sources}
+}
+
+// FetchByCode implements repository.CourseExternalAPI.
+func (a *CompositeCourseAPI) FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	results, err := a.fetch([]string{code}, func(api repository.CourseExternalAPI, _ []string) (map[string]repository.CourseFetchResult, error) {
+		course, err := api.FetchByCode(ctx, code, acadyear, semester)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]repository.CourseFetchResult{code: {Course: course}}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := results[code]
+	return res.Course, res.Err
+}
+
+// FetchByCodes implements repository.CourseExternalAPI.
+func (a *CompositeCourseAPI) FetchByCodes(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	return a.fetch(courseCodes, func(api repository.CourseExternalAPI, need []string) (map[string]repository.CourseFetchResult, error) {
+		return api.FetchByCodes(ctx, need, acadyear, semester)
+	})
+}
+
+// fetch walks the sources, asking each one only for the codes that are
+// still missing or incomplete. It fails only if no source answered at all.
+func (a *CompositeCourseAPI) fetch(courseCodes []string, fetch func(repository.CourseExternalAPI, []string) (map[string]repository.CourseFetchResult, error)) (map[string]repository.CourseFetchResult, error) {
+	results := make(map[string]repository.CourseFetchResult, len(courseCodes))
+	failures := make(map[string]error)
+	var sourceErr error
+	answered := false
+
+	for _, src := range a.sources {
+		var need []string
+		for _, code := range courseCodes {
+			if res, ok := results[code]; !ok || incomplete(res.Course) {
+				need = append(need, code)
+			}
+		}
+		if len(need) == 0 {
+			break
+		}
+
+		got, err := fetch(src.API, need)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", src.Name, err)
+			sourceErr = preferError(sourceErr, err)
+			for _, code := range need {
+				if _, ok := results[code]; !ok {
+					failures[code] = preferError(failures[code], err)
+				}
+			}
+			continue
+		}
+		answered = true
+
+		for _, code := range need {
+			res, ok := got[code]
+			if !ok || (res.Err == nil && res.Course == nil) {
+				res.Err = fmt.Errorf("%w: %s", repository.ErrExternalCourseNotFound, code)
+			}
+			if base, ok := results[code]; ok {
+				if res.Err == nil {
+					fillMissing(base.Course, res.Course, src.Name)
+				}
+				continue
+			}
+			if res.Err != nil {
+				failures[code] = preferError(failures[code], fmt.Errorf("%s: %w", src.Name, res.Err))
+				continue
+			}
+			res.Course.Source = src.Name
+			results[code] = res
+		}
+	}
+
+	if !answered {
+		if sourceErr == nil {
+			sourceErr = errors.New("no course source configured")
+		}
+		return nil, sourceErr
+	}
+	for _, code := range courseCodes {
+		if _, ok := results[code]; !ok {
+			results[code] = repository.CourseFetchResult{Err: failures[code]}
+		}
+	}
+	return results, nil
+}
+
+// preferError keeps the more telling of two errors: a source failure says
+// more than "not found", since the failed source might have had the course.
+func preferError(current, next error) error {
+	if current == nil || (isNotFound(current) && !isNotFound(next)) {
+		return next
+	}
+	return current
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, repository.ErrExternalCourseNotFound) || status.Code(err) == codes.NotFound
+}
+
+// incomplete reports whether course lacks a field fillMissing can fill.
+func incomplete(course *entity.Course) bool {
+	if course.NameEN == "" || course.NameTH == "" || course.Faculty == "" || course.Department == "" || course.Credits == "" {
+		return true
+	}
+	for _, s := range course.Sections {
+		if s.Campus == "" || s.Program == "" || len(s.Instructor) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fillMissing is the merge policy: empty course names, faculty, department
+// and credits, and the campus, program and instructors of a section, are
+// taken from other. Sections are matched by number (and campus/program when
+// both sides have them); the supplying source's section list is kept as is.
+// Each filled field is recorded in dst.FilledFrom.
+func fillMissing(dst, other *entity.Course, source string) {
+	record := func(field string) {
+		if dst.FilledFrom == nil {
+			dst.FilledFrom = make(map[string]string)
+		}
+		dst.FilledFrom[field] = source
+	}
+	fill := func(field string, dst *string, v string) {
+		if *dst == "" && v != "" {
+			*dst = v
+			record(field)
+		}
+	}
+
+	fill("name_en", &dst.NameEN, other.NameEN)
+	fill("name_th", &dst.NameTH, other.NameTH)
+	fill("faculty", &dst.Faculty, other.Faculty)
+	fill("department", &dst.Department, other.Department)
+	fill("credits", &dst.Credits, other.Credits)
+
+	for i := range dst.Sections {
+		sec := &dst.Sections[i]
+		match := matchSection(other.Sections, sec)
+		if match == nil {
+			continue
+		}
+		prefix := "sections[" + sec.Number + "]."
+		fill(prefix+"campus", &sec.Campus, match.Campus)
+		fill(prefix+"program", &sec.Program, match.Program)
+		if len(sec.Instructor) == 0 && len(match.Instructor) > 0 {
+			sec.Instructor = match.Instructor
+			record(prefix + "instructor")
+		}
+	}
+}
+
+func matchSection(sections []entity.Section, sec *entity.Section) *entity.Section {
+	agree := func(a, b string) bool { return a == "" || b == "" || a == b }
+	for i, s := range sections {
+		if s.Number == sec.Number && agree(s.Campus, sec.Campus) && agree(s.Program, sec.Program) {
+			return &sections[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ---- stub source ----
+
+type stubSource struct {
+	courses map[string]entity.Course
+	err     error      // fails every call
+	asked   [][]string // codes asked for, per call
+}
+
+func (s *stubSource) FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	results, err := s.FetchByCodes(ctx, []string{code}, acadyear, semester)
+	if err != nil {
+		return nil, err
+	}
+	return results[code].Course, results[code].Err
+}
+
+func (s *stubSource) FetchByCodes(_ context.Context, courseCodes []string, _, _ int) (map[string]repository.CourseFetchResult, error) {
+	s.asked = append(s.asked, courseCodes)
+	if s.err != nil {
+		return nil, s.err
+	}
+	results := make(map[string]repository.CourseFetchResult, len(courseCodes))
+	for _, code := range courseCodes {
+		c, ok := s.courses[code]
+		if !ok {
+			results[code] = repository.CourseFetchResult{Err: fmt.Errorf("%w: %s", repository.ErrExternalCourseNotFound, code)}
+			continue
+		}
+		c.Sections = append([]entity.Section(nil), c.Sections...)
+		results[code] = repository.CourseFetchResult{Course: &c}
+	}
+	return results, nil
+}
+
+func completeCourse(code string) entity.Course {
+	return entity.Course{
+		Code: code, NameEN: "Software Engineering", NameTH: "วิศวกรรมซอฟต์แวร์", Faculty: "CP", Department: "CS", Credits: "3 (2-2-5)",
+		Sections: []entity.Section{{Number: "01", Campus: "ขอนแก่น", Program: "ปริญญาตรี ภาคปกติ", Instructor: []string{"Dr. Smith"}}},
+	}
+}
+
+// ---- tests ----
+
+func TestComposite_FallsBackOnFailureAndNotFound(t *testing.T) {
+	primary := &stubSource{err: status.Error(codes.Unavailable, "down")}
+	secondary := &stubSource{courses: map[string]entity.Course{"CP353004": completeCourse("CP353004")}}
+	tertiary := &stubSource{courses: map[string]entity.Course{"CP353002": completeCourse("CP353002")}}
+	api := NewCompositeCourseAPI(
+		CourseSource{Name: "grpc", API: primary},
+		CourseSource{Name: "http", API: secondary},
+		CourseSource{Name: "file", API: tertiary},
+	)
+
+	course, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course.Source != "http" || course.FilledFrom != nil {
+		t.Errorf("expected a complete course from http, got source %q filled %v", course.Source, course.FilledFrom)
+	}
+	if len(tertiary.asked) != 0 {
+		t.Errorf("a complete course must not query lower sources, asked %v", tertiary.asked)
+	}
+
+	results, err := api.FetchByCodes(context.Background(), []string{"CP353004", "CP353002", "XX000000"}, 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := results["CP353004"]; r.Err != nil || r.Course.Source != "http" {
+		t.Errorf("CP353004: expected http, got %+v", r)
+	}
+	if r := results["CP353002"]; r.Err != nil || r.Course.Source != "file" {
+		t.Errorf("CP353002: expected file, got %+v", r)
+	}
+	if got := tertiary.asked[len(tertiary.asked)-1]; len(got) != 2 {
+		t.Errorf("expected only unresolved codes to reach the file source, asked %v", got)
+	}
+	// The primary was down, so "not found" from the others is not conclusive.
+	if r := results["XX000000"]; status.Code(r.Err) != codes.Unavailable {
+		t.Errorf("XX000000: expected the primary's failure, got %v", r.Err)
+	}
+}
+
+func TestComposite_AllSourcesFail(t *testing.T) {
+	api := NewCompositeCourseAPI(
+		CourseSource{Name: "grpc", API: &stubSource{err: status.Error(codes.NotFound, "course not found")}},
+		CourseSource{Name: "http", API: &stubSource{}},
+	)
+	if _, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1); !isNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	down := errors.New("connection refused")
+	api = NewCompositeCourseAPI(
+		CourseSource{Name: "grpc", API: &stubSource{err: down}},
+		CourseSource{Name: "http", API: &stubSource{err: status.Error(codes.Unavailable, "down")}},
+	)
+	if _, err := api.FetchByCodes(context.Background(), []string{"CP353004"}, 2568, 1); !errors.Is(err, down) {
+		t.Errorf("expected the first source failure, got %v", err)
+	}
+}
+
+func TestComposite_FillsMissingFields(t *testing.T) {
+	partial := completeCourse("CP353004")
+	partial.Department = ""
+	partial.Sections = []entity.Section{
+		{Number: "01", Program: "ปริญญาตรี ภาคปกติ", Instructor: []string{"Dr. Smith"}},
+		{Number: "02", Campus: "หนองคาย"},
+	}
+	fallback := entity.Course{
+		Code: "CP353004", NameEN: "Other name", Department: "CS",
+		Sections: []entity.Section{
+			{Number: "01", Campus: "ขอนแก่น", Program: "ปริญญาตรี ภาคปกติ"},
+			{Number: "02", Campus: "ขอนแก่น", Program: "ปริญญาตรี ภาคพิเศษ"},
+			{Number: "03", Campus: "ขอนแก่น"},
+		},
+	}
+	api := NewCompositeCourseAPI(
+		CourseSource{Name: "grpc", API: &stubSource{courses: map[string]entity.Course{"CP353004": partial}}},
+		CourseSource{Name: "file", API: &stubSource{courses: map[string]entity.Course{"CP353004": fallback}}},
+	)
+
+	course, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course.Source != "grpc" || course.NameEN != "Software Engineering" || course.Department != "CS" {
+		t.Errorf("unexpected merge: %+v", course)
+	}
+	if len(course.Sections) != 2 || course.Sections[0].Campus != "ขอนแก่น" {
+		t.Errorf("expected section 01 campus filled and the section list kept, got %+v", course.Sections)
+	}
+	// Section 02 is at a different campus in the fallback, so it isn't the same section.
+	if course.Sections[1].Program != "" {
+		t.Errorf("expected section 02 left alone, got %+v", course.Sections[1])
+	}
+	want := map[string]string{"department": "file", "sections[01].campus": "file"}
+	if len(course.FilledFrom) != len(want) {
+		t.Fatalf("expected FilledFrom %v, got %v", want, course.FilledFrom)
+	}
+	for k, v := range want {
+		if course.FilledFrom[k] != v {
+			t.Errorf("FilledFrom[%s]: expected %q, got %q", k, v, course.FilledFrom[k])
+		}
+	}
+}
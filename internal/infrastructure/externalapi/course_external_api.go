@@ -2,16 +2,24 @@ package externalapi
 
 import (
 	"context"
-	"log"
+	"errors"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
 	pb "github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// maxUnaryBatch is the largest batch sent with FetchByCodes. Bigger batches
+// use StreamByCodes so the reply isn't bound by the gRPC message size limit.
+const maxUnaryBatch = 50
+
 // courseExternalAPI is the gRPC implementation of repository.CourseExternalAPI.
 type courseExternalAPI struct {
 	client pb.CourseServiceClient
@@ -32,54 +40,164 @@ func (a *courseExternalAPI) FetchByCode(ctx context.Context, code string, acadye
 	if err != nil {
 		return nil, err
 	}
+	return toCourse(code, resp), nil
+}
+
+// errNoBatch is returned by batchSource.fetchBatch when the upstream has no
+// batch endpoint.
+var errNoBatch = errors.New("course api: no batch endpoint")
+
+// batchSource is implemented by sources that fall back to fetching a batch
+// one code at a time. ResilientCourseAPI uses it to guard each of those
+// requests on its own rather than the whole batch with one deadline.
+type batchSource interface {
+	// fetchBatch is FetchByCodes without the fallback: it returns errNoBatch
+	// when there is no batch endpoint to call.
+	fetchBatch(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error)
+}
+
+// FetchByCodes uses the batch RPCs, falling back to one FetchByCode call per
+// code when the upstream service does not implement them.
+func (a *courseExternalAPI) FetchByCodes(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	results, err := a.fetchBatch(ctx, courseCodes, acadyear, semester)
+	if errors.Is(err, errNoBatch) {
+		return fetchEach(ctx, courseCodes, func(ctx context.Context, code string) (*entity.Course, error) {
+			return a.FetchByCode(ctx, code, acadyear, semester)
+		})
+	}
+	return results, err
+}
+
+func (a *courseExternalAPI) fetchBatch(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	req := &pb.FetchByCodesRequest{
+		Codes:    courseCodes,
+		Acadyear: int32(acadyear),
+		Semester: int32(semester),
+	}
+	// Results are keyed by the requested code; upstream may change its case.
+	requested := make(map[string]string, len(courseCodes))
+	for _, code := range courseCodes {
+		requested[strings.ToUpper(code)] = code
+	}
+
+	results := make(map[string]repository.CourseFetchResult, len(courseCodes))
+	var err error
+	if len(courseCodes) > maxUnaryBatch {
+		err = a.streamByCodes(ctx, req, requested, results)
+	} else {
+		err = a.fetchByCodes(ctx, req, requested, results)
+	}
+	if status.Code(err) == codes.Unimplemented {
+		return nil, errNoBatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range courseCodes {
+		if _, ok := results[code]; !ok {
+			results[code] = repository.CourseFetchResult{Err: repository.ErrExternalCourseNotFound}
+		}
+	}
+	return results, nil
+}
+
+func (a *courseExternalAPI) fetchByCodes(ctx context.Context, req *pb.FetchByCodesRequest, requested map[string]string, results map[string]repository.CourseFetchResult) error {
+	resp, err := a.client.FetchByCodes(ctx, req)
+	if err != nil {
+		return err
+	}
+	for _, c := range resp.Courses {
+		if code, ok := requested[strings.ToUpper(c.Code)]; ok {
+			results[code] = toResult(code, c)
+		}
+	}
+	return nil
+}
 
+func (a *courseExternalAPI) streamByCodes(ctx context.Context, req *pb.FetchByCodesRequest, requested map[string]string, results map[string]repository.CourseFetchResult) error {
+	stream, err := a.client.StreamByCodes(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		code, ok := requested[strings.ToUpper(item.Code)]
+		if !ok || item.Course == nil {
+			continue // unknown codes are filled in by FetchByCodes
+		}
+		results[code] = toResult(code, item.Course)
+	}
+}
+
+// fetchEach is the fallback for upstreams without a batch endpoint: it
+// fetches the codes one by one. Answers about a single course are kept with
+// its code; the batch stops at the first failure that isn't about one.
+func fetchEach(ctx context.Context, courseCodes []string, fetch func(ctx context.Context, code string) (*entity.Course, error)) (map[string]repository.CourseFetchResult, error) {
+	results := make(map[string]repository.CourseFetchResult, len(courseCodes))
+	for _, code := range courseCodes {
+		course, err := fetch(ctx, code)
+		if status.Code(err) == codes.NotFound {
+			err = repository.ErrExternalCourseNotFound
+		}
+		if err != nil && !errors.Is(err, repository.ErrExternalCourseNotFound) && !errors.Is(err, repository.ErrMalformedCourse) {
+			return nil, err
+		}
+		results[code] = repository.CourseFetchResult{Course: course, Err: err}
+	}
+	return results, nil
+}
+
+func toResult(code string, resp *pb.FetchByCodeResponse) repository.CourseFetchResult {
+	return repository.CourseFetchResult{Course: toCourse(code, resp)}
+}
+
+// toCourse converts an upstream response for code into a Course entity.
+func toCourse(code string, resp *pb.FetchByCodeResponse) *entity.Course {
 	course := protoToCourse(resp)
 	course.Code = code
 	course.UpdatedAt = time.Now()
-	return course, nil
+	return course
 }
 
 // protoToCourse converts a gRPC FetchByCodeResponse to a domain Course entity.
+// A value that fails to parse leaves its field empty (an unreadable schedule
+// time keeps its slot, marked TBA) and is listed in the course's Unparsed
+// values.
 func protoToCourse(resp *pb.FetchByCodeResponse) *entity.Course {
+	var unparsed []entity.UnparsedValue
 	sections := make([]entity.Section, len(resp.Sections))
 	for i, s := range resp.Sections {
-		schedules := make([]entity.Schedule, len(s.Schedules))
-		for j, sc := range s.Schedules {
-			var startTimeStr, endTimeStr string
-			parts := strings.Split(sc.Time, "-")
-			if len(parts) == 2 {
-				startTimeStr = strings.TrimSpace(parts[0])
-				endTimeStr = strings.TrimSpace(parts[1])
-			}
-
-			schedules[j] = entity.Schedule{
-				Day:       sc.Day,
-				StartTime: startTimeStr,
-				EndTime:   endTimeStr,
-				Room:      sc.Room,
-				Type:      sc.Type,
+		var schedules []entity.Schedule
+		for _, sc := range s.Schedules {
+			expanded, err := entity.NewSchedules(sc.Day, sc.Time, sc.Room, sc.Type)
+			if err != nil {
+				unparsed = append(unparsed, entity.UnparsedValue{Section: s.Number, Field: "time", Value: sc.Time, Err: err})
 			}
+			schedules = append(schedules, expanded...)
 		}
 
-		var examStartStr, examEndStr string
+		var examStart, examEnd time.Time
 		if s.ExamDate != "" {
-			es, ee, err := parseThaiExamDate(s.ExamDate)
-			if err == nil {
-				examStartStr = es
-				examEndStr = ee
-			} else {
-				log.Printf("Error parsing exam date: %v", err)
+			var err error
+			examStart, examEnd, err = thaicalendar.ParseExamDate(s.ExamDate)
+			if err != nil {
+				unparsed = append(unparsed, entity.UnparsedValue{Section: s.Number, Field: "exam_date", Value: s.ExamDate, Err: err})
 			}
 		}
 
-		var midtermStartStr, midtermEndStr string
+		var midtermStart, midtermEnd time.Time
 		if s.MidtermDate != "" {
-			ms, me, err := parseThaiExamDate(s.MidtermDate)
-			if err == nil {
-				midtermStartStr = ms
-				midtermEndStr = me
-			} else {
-				log.Printf("Error parsing midterm date: %v", err)
+			var err error
+			midtermStart, midtermEnd, err = thaicalendar.ParseExamDate(s.MidtermDate)
+			if err != nil {
+				unparsed = append(unparsed, entity.UnparsedValue{Section: s.Number, Field: "midterm_date", Value: s.MidtermDate, Err: err})
 			}
 		}
 
@@ -88,10 +206,10 @@ func protoToCourse(resp *pb.FetchByCodeResponse) *entity.Course {
 			Schedules:    schedules,
 			Seats:        int(s.Seats),
 			Instructor:   s.Instructor,
-			ExamStart:    examStartStr,
-			ExamEnd:      examEndStr,
-			MidtermStart: midtermStartStr,
-			MidtermEnd:   midtermEndStr,
+			ExamStart:    examStart,
+			ExamEnd:      examEnd,
+			MidtermStart: midtermStart,
+			MidtermEnd:   midtermEnd,
 			Note:         s.Note,
 			ReservedFor:  s.ReservedFor,
 			Campus:       s.Campus,
@@ -99,7 +217,7 @@ func protoToCourse(resp *pb.FetchByCodeResponse) *entity.Course {
 		}
 	}
 
-	return &entity.Course{
+	course := &entity.Course{
 		Code:         resp.Code,
 		NameEN:       resp.NameEn,
 		NameTH:       resp.NameTh,
@@ -110,5 +228,7 @@ func protoToCourse(resp *pb.FetchByCodeResponse) *entity.Course {
 		Semester:     int(resp.Semester),
 		Year:         int(resp.Year),
 		Sections:     sections,
+		Unparsed:     unparsed,
 	}
+	return course
 }
@@ -3,11 +3,18 @@ package externalapi
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
 	"time"
 
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
 	pb "github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ---- mock CourseServiceClient ----
@@ -15,6 +22,12 @@ import (
 type mockCourseServiceClient struct {
 	resp *pb.FetchByCodeResponse
 	err  error
+
+	batch      *pb.FetchByCodesResponse
+	items      []*pb.FetchByCodesItem
+	batchErr   error
+	unaryCalls int
+	streamed   bool
 }
 
 func (m *mockCourseServiceClient) FetchByCode(
@@ -22,9 +35,45 @@ func (m *mockCourseServiceClient) FetchByCode(
 	in *pb.FetchByCodeRequest,
 	opts ...grpc.CallOption,
 ) (*pb.FetchByCodeResponse, error) {
+	m.unaryCalls++
 	return m.resp, m.err
 }
 
+func (m *mockCourseServiceClient) FetchByCodes(
+	ctx context.Context,
+	in *pb.FetchByCodesRequest,
+	opts ...grpc.CallOption,
+) (*pb.FetchByCodesResponse, error) {
+	return m.batch, m.batchErr
+}
+
+func (m *mockCourseServiceClient) StreamByCodes(
+	ctx context.Context,
+	in *pb.FetchByCodesRequest,
+	opts ...grpc.CallOption,
+) (grpc.ServerStreamingClient[pb.FetchByCodesItem], error) {
+	m.streamed = true
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+	return &mockItemStream{items: m.items}, nil
+}
+
+// mockItemStream replays items, then io.EOF.
+type mockItemStream struct {
+	grpc.ClientStream
+	items []*pb.FetchByCodesItem
+}
+
+func (s *mockItemStream) Recv() (*pb.FetchByCodesItem, error) {
+	if len(s.items) == 0 {
+		return nil, io.EOF
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
 // ---- FetchByCode tests ----
 
 func TestFetchByCode_Success(t *testing.T) {
@@ -119,10 +168,11 @@ func TestFetchByCode_Success(t *testing.T) {
 		t.Errorf("expected note 'Closed', got %s", sec.Note)
 	}
 	// Exam should be parsed
-	if sec.ExamStart == "" {
-		t.Error("expected ExamStart to be parsed")
+	wantExamStart := time.Date(2026, time.March, 31, 13, 0, 0, 0, thaicalendar.Location)
+	if !sec.ExamStart.Equal(wantExamStart) {
+		t.Errorf("expected ExamStart %v, got %v", wantExamStart, sec.ExamStart)
 	}
-	if sec.ExamEnd == "" {
+	if sec.ExamEnd.IsZero() {
 		t.Error("expected ExamEnd to be parsed")
 	}
 
@@ -139,11 +189,14 @@ func TestFetchByCode_Success(t *testing.T) {
 	if sched.Type != "C" {
 		t.Errorf("expected type 'C', got %s", sched.Type)
 	}
+	if sched.Weekday != entity.WeekdayMonday || sched.Kind != entity.ScheduleKindLecture {
+		t.Errorf("expected MON/LECTURE, got %s/%s", sched.Weekday, sched.Kind)
+	}
 	// StartTime should be 13:00
-	if sched.StartTime != "13:00" {
+	if sched.StartTime != entity.NewTimeOfDay(13, 0) {
 		t.Errorf("expected start 13:00, got %v", sched.StartTime)
 	}
-	if sched.EndTime != "15:00" {
+	if sched.EndTime != entity.NewTimeOfDay(15, 0) {
 		t.Errorf("expected end 15:00, got %v", sched.EndTime)
 	}
 }
@@ -163,12 +216,123 @@ func TestFetchByCode_GRPCError(t *testing.T) {
 	}
 }
 
+// ---- FetchByCodes tests ----
+
+func TestFetchByCodes_Unary(t *testing.T) {
+	mock := &mockCourseServiceClient{
+		batch: &pb.FetchByCodesResponse{
+			Courses: []*pb.FetchByCodeResponse{
+				{Code: "cp353004", NameEn: "Software Engineering"},
+				{Code: "CP353002", Sections: []*pb.Section{{Number: "01", ExamDate: "bad date string"}}},
+			},
+			NotFound: []string{"CP999999"},
+		},
+	}
+	api := &courseExternalAPI{client: mock}
+
+	results, err := api.FetchByCodes(context.Background(), []string{"CP353004", "CP353002", "CP999999"}, 2568, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if mock.streamed || mock.unaryCalls != 0 {
+		t.Error("expected a single FetchByCodes call")
+	}
+	if r := results["CP353004"]; r.Err != nil || r.Course.Code != "CP353004" || r.Course.NameEN != "Software Engineering" {
+		t.Errorf("unexpected result for CP353004: %+v", r)
+	}
+	if r := results["CP353002"]; r.Err != nil || r.Course == nil || len(r.Course.Unparsed) != 1 {
+		t.Errorf("expected CP353002 with its exam date unparsed, got %+v", r)
+	}
+	if r := results["CP999999"]; !errors.Is(r.Err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("expected ErrExternalCourseNotFound for CP999999, got %+v", r)
+	}
+}
+
+func TestFetchByCodes_LargeBatchStreams(t *testing.T) {
+	courseCodes := make([]string, maxUnaryBatch+1)
+	var items []*pb.FetchByCodesItem
+	for i := range courseCodes {
+		courseCodes[i] = fmt.Sprintf("CP%06d", i)
+		if i%2 == 0 {
+			items = append(items, &pb.FetchByCodesItem{Code: courseCodes[i], Course: &pb.FetchByCodeResponse{Code: courseCodes[i]}})
+		} else {
+			items = append(items, &pb.FetchByCodesItem{Code: courseCodes[i]})
+		}
+	}
+	mock := &mockCourseServiceClient{items: items}
+	api := &courseExternalAPI{client: mock}
+
+	results, err := api.FetchByCodes(context.Background(), courseCodes, 2568, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !mock.streamed {
+		t.Error("expected StreamByCodes for a large batch")
+	}
+	if len(results) != len(courseCodes) {
+		t.Fatalf("expected %d results, got %d", len(courseCodes), len(results))
+	}
+	if results[courseCodes[0]].Course == nil || !errors.Is(results[courseCodes[1]].Err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("unexpected results: %+v %+v", results[courseCodes[0]], results[courseCodes[1]])
+	}
+}
+
+func TestFetchByCodes_FallsBackWhenUnimplemented(t *testing.T) {
+	mock := &mockCourseServiceClient{
+		batchErr: status.Error(codes.Unimplemented, "method FetchByCodes not implemented"),
+		err:      status.Error(codes.NotFound, "course not found"),
+	}
+	api := &courseExternalAPI{client: mock}
+
+	results, err := api.FetchByCodes(context.Background(), []string{"CP353004", "CP353002"}, 2568, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if mock.unaryCalls != 2 {
+		t.Errorf("expected 2 FetchByCode calls, got %d", mock.unaryCalls)
+	}
+	if !errors.Is(results["CP353004"].Err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("expected ErrExternalCourseNotFound, got %+v", results["CP353004"])
+	}
+}
+
+func TestFetchByCodes_BatchError(t *testing.T) {
+	mock := &mockCourseServiceClient{batchErr: errors.New("connection refused")}
+	api := &courseExternalAPI{client: mock}
+
+	if _, err := api.FetchByCodes(context.Background(), []string{"CP353004"}, 2568, 1); err == nil || err.Error() != "connection refused" {
+		t.Errorf("expected 'connection refused', got %v", err)
+	}
+}
+
 // ---- protoToCourse edge-case tests ----
 
+func TestFetchByCode_UnparsedValue(t *testing.T) {
+	mock := &mockCourseServiceClient{
+		resp: &pb.FetchByCodeResponse{
+			NameEn:   "Intro",
+			Sections: []*pb.Section{{Number: "01", ExamDate: "bad date string", Seats: 40}},
+		},
+	}
+	api := &courseExternalAPI{client: mock}
+
+	course, err := api.FetchByCode(context.Background(), "CS101", 2568, 1)
+	if err != nil {
+		t.Fatalf("expected the course to be kept, got %v", err)
+	}
+	if course.NameEN != "Intro" || course.Sections[0].Seats != 40 || !course.Sections[0].ExamStart.IsZero() {
+		t.Errorf("expected the course with an empty exam date, got %+v", course)
+	}
+	if len(course.Unparsed) != 1 || course.Unparsed[0].Value != "bad date string" {
+		t.Errorf("expected the exam date to be reported, got %+v", course.Unparsed)
+	}
+}
+
 func TestProtoToCourse_InvalidScheduleTime(t *testing.T) {
 	resp := &pb.FetchByCodeResponse{
 		Sections: []*pb.Section{
 			{
+				Number: "01",
 				Schedules: []*pb.Schedule{
 					{Day: "จันทร์", Time: "invalid", Room: "R1", Type: "L"},
 				},
@@ -177,16 +341,47 @@ func TestProtoToCourse_InvalidScheduleTime(t *testing.T) {
 	}
 
 	course := protoToCourse(resp)
+	if len(course.Unparsed) != 1 || course.Unparsed[0].Field != "time" || course.Unparsed[0].Section != "01" || course.Unparsed[0].Value != "invalid" {
+		t.Fatalf("expected the schedule time to be reported, got %+v", course.Unparsed)
+	}
 	if len(course.Sections) != 1 {
 		t.Fatalf("expected 1 section, got %d", len(course.Sections))
 	}
-	sched := course.Sections[0].Schedules[0]
-	// time should remain zero when parsing fails
-	if sched.StartTime != "" {
-		t.Error("expected empty StartTime for invalid time")
+	if scs := course.Sections[0].Schedules; len(scs) != 1 || !scs[0].TBA || scs[0].Weekday != entity.WeekdayMonday || scs[0].Room != "R1" || scs[0].Type != "L" {
+		t.Errorf("expected the slot to be kept as TBA, got %+v", scs)
 	}
-	if sched.EndTime != "" {
-		t.Error("expected empty EndTime for invalid time")
+}
+
+func TestProtoToCourse_RegistrarTimeFormats(t *testing.T) {
+	resp := &pb.FetchByCodeResponse{
+		Sections: []*pb.Section{
+			{
+				Number: "01",
+				Schedules: []*pb.Schedule{
+					{Day: "จันทร์", Time: "13:00 - 15:00 น.", Room: "R1", Type: "C"},
+					{Day: "อังคาร", Time: "09:00-10:30, 13:00-14:30", Room: "R2", Type: "L"},
+					{Day: "พุธ", Time: "TBA", Room: "", Type: "C"},
+				},
+			},
+		},
+	}
+
+	course := protoToCourse(resp)
+	if len(course.Unparsed) != 0 {
+		t.Fatalf("unexpected unparsed values: %+v", course.Unparsed)
+	}
+	scheds := course.Sections[0].Schedules
+	if len(scheds) != 4 {
+		t.Fatalf("expected 4 schedules, got %d", len(scheds))
+	}
+	if scheds[0].StartTime.String() != "13:00" || scheds[0].EndTime.String() != "15:00" {
+		t.Errorf("unexpected first slot %s-%s", scheds[0].StartTime, scheds[0].EndTime)
+	}
+	if scheds[2].Weekday != entity.WeekdayTuesday || scheds[2].StartTime.String() != "13:00" {
+		t.Errorf("unexpected second range %+v", scheds[2])
+	}
+	if !scheds[3].TBA || scheds[3].StartTime.Valid {
+		t.Errorf("expected TBA schedule without times, got %+v", scheds[3])
 	}
 }
 
@@ -201,10 +396,8 @@ func TestProtoToCourse_BadStartTime(t *testing.T) {
 		},
 	}
 
-	course := protoToCourse(resp)
-	sched := course.Sections[0].Schedules[0]
-	if sched.StartTime != "XX:XX" {
-		t.Error("expected 'XX:XX' for bad start time since it's just a string split now")
+	if course := protoToCourse(resp); len(course.Unparsed) != 1 || course.Unparsed[0].Err == nil {
+		t.Errorf("expected the bad start time to be reported, got %+v", course.Unparsed)
 	}
 }
 
@@ -219,12 +412,15 @@ func TestProtoToCourse_EmptyExamAndMidterm(t *testing.T) {
 	}
 
 	course := protoToCourse(resp)
+	if len(course.Unparsed) != 0 {
+		t.Fatalf("unexpected unparsed values: %+v", course.Unparsed)
+	}
 	sec := course.Sections[0]
-	if sec.ExamStart != "" {
-		t.Error("expected empty ExamStart for empty exam date")
+	if !sec.ExamStart.IsZero() {
+		t.Error("expected zero ExamStart for empty exam date")
 	}
-	if sec.MidtermStart != "" {
-		t.Error("expected empty MidtermStart for empty midterm date")
+	if !sec.MidtermStart.IsZero() {
+		t.Error("expected zero MidtermStart for empty midterm date")
 	}
 }
 
@@ -232,16 +428,18 @@ func TestProtoToCourse_InvalidExamDate(t *testing.T) {
 	resp := &pb.FetchByCodeResponse{
 		Sections: []*pb.Section{
 			{
+				Number:   "02",
 				ExamDate: "bad date string",
 			},
 		},
 	}
 
 	course := protoToCourse(resp)
-	sec := course.Sections[0]
-	// Should remain zero when parsing fails
-	if sec.ExamStart != "" {
-		t.Error("expected empty ExamStart for invalid exam date")
+	if len(course.Unparsed) != 1 || course.Unparsed[0].Field != "exam_date" || course.Unparsed[0].Section != "02" {
+		t.Errorf("expected the exam date to be reported, got %+v", course.Unparsed)
+	}
+	if !course.Sections[0].ExamStart.IsZero() || !course.Sections[0].ExamEnd.IsZero() {
+		t.Error("expected the exam date to be left empty")
 	}
 }
 
@@ -255,9 +453,8 @@ func TestProtoToCourse_InvalidMidtermDate(t *testing.T) {
 	}
 
 	course := protoToCourse(resp)
-	sec := course.Sections[0]
-	if sec.MidtermStart != "" {
-		t.Error("expected empty MidtermStart for invalid midterm date")
+	if len(course.Unparsed) != 1 || course.Unparsed[0].Field != "midterm_date" || course.Unparsed[0].Value != "not a date" {
+		t.Errorf("expected the midterm date to be reported, got %+v", course.Unparsed)
 	}
 }
 
@@ -271,11 +468,14 @@ func TestProtoToCourse_ValidMidterm(t *testing.T) {
 	}
 
 	course := protoToCourse(resp)
+	if len(course.Unparsed) != 0 {
+		t.Fatalf("unexpected unparsed values: %+v", course.Unparsed)
+	}
 	sec := course.Sections[0]
-	if sec.MidtermStart == "" {
+	if sec.MidtermStart.IsZero() {
 		t.Error("expected MidtermStart to be parsed")
 	}
-	if sec.MidtermEnd == "" {
+	if sec.MidtermEnd.IsZero() {
 		t.Error("expected MidtermEnd to be parsed")
 	}
 }
@@ -303,6 +503,9 @@ func TestProtoToCourse_NoSections(t *testing.T) {
 	}
 
 	course := protoToCourse(resp)
+	if len(course.Unparsed) != 0 {
+		t.Fatalf("unexpected unparsed values: %+v", course.Unparsed)
+	}
 	if len(course.Sections) != 0 {
 		t.Errorf("expected 0 sections, got %d", len(course.Sections))
 	}
@@ -0,0 +1,67 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+// courseFileAPI serves courses from a static JSON file, for data the
+// registrar does not publish or as a last-resort fallback.
+type courseFileAPI struct {
+	courses map[string][]courseDocument // by upper-case code
+}
+
+// NewCourseFileAPI loads a JSON array of course documents (the HTTP API's
+// format) from path. A document with year or semester 0 matches every term.
+func NewCourseFileAPI(path string) (repository.CourseExternalAPI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read course file: %w", err)
+	}
+	var docs []courseDocument
+	if err := json.Unmarshal(data, &docs); err != nil {
+		return nil, fmt.Errorf("parse course file %s: %w", path, err)
+	}
+
+	courses := make(map[string][]courseDocument, len(docs))
+	for i, doc := range docs {
+		if doc.Code == "" {
+			return nil, fmt.Errorf("parse course file %s: course %d has no code", path, i)
+		}
+		code := strings.ToUpper(doc.Code)
+		courses[code] = append(courses[code], doc)
+	}
+	return &courseFileAPI{courses: courses}, nil
+}
+
+// FetchByCode implements repository.CourseExternalAPI.
+func (a *courseFileAPI) FetchByCode(_ context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	for _, doc := range a.courses[strings.ToUpper(code)] {
+		if (doc.Year == 0 || int(doc.Year) == acadyear) && (doc.Semester == 0 || int(doc.Semester) == semester) {
+			if doc.Year == 0 {
+				doc.Year = int32(acadyear)
+			}
+			if doc.Semester == 0 {
+				doc.Semester = int32(semester)
+			}
+			return toCourse(code, doc.toProto()), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", repository.ErrExternalCourseNotFound, code)
+}
+
+// FetchByCodes implements repository.CourseExternalAPI.
+func (a *courseFileAPI) FetchByCodes(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	results := make(map[string]repository.CourseFetchResult, len(courseCodes))
+	for _, code := range courseCodes {
+		course, err := a.FetchByCode(ctx, code, acadyear, semester)
+		results[code] = repository.CourseFetchResult{Course: course, Err: err}
+	}
+	return results, nil
+}
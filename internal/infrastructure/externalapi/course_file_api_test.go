@@ -0,0 +1,75 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+func writeCourseFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "courses.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCourseFileAPI(t *testing.T) {
+	path := writeCourseFile(t, `[
+		{"code": "cp353004", "name_en": "Software Engineering", "department": "CS", "year": 2568, "semester": 1,
+		 "program": "ปริญญาตรี ภาคปกติ", "sections": [{"number": "01", "campus": "ขอนแก่น"}]},
+		{"code": "CP353004", "name_en": "Software Engineering (2)", "year": 2568, "semester": 2},
+		{"code": "SC313002", "name_en": "Any term"},
+		{"code": "SC313003", "sections": [{"number": "01", "exam_date": "bad date string"}]}
+	]`)
+	api, err := NewCourseFileAPI(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	course, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course.NameEN != "Software Engineering" || course.Department != "CS" || course.Sections[0].Program != "ปริญญาตรี ภาคปกติ" {
+		t.Errorf("unexpected course: %+v", course)
+	}
+
+	course, err = api.FetchByCode(context.Background(), "SC313002", 2567, 3)
+	if err != nil || course.Year != 2567 || course.Semester != 3 {
+		t.Errorf("expected a term-less entry to match any term, got %+v, %v", course, err)
+	}
+
+	results, err := api.FetchByCodes(context.Background(), []string{"CP353004", "SC313003", "XX000000"}, 2568, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := results["CP353004"]; r.Err != nil || r.Course.NameEN != "Software Engineering (2)" {
+		t.Errorf("expected the semester 2 entry, got %+v", r)
+	}
+	if r := results["SC313003"]; r.Err != nil || len(r.Course.Unparsed) != 1 {
+		t.Errorf("expected SC313003 with its exam date unparsed, got %+v", r)
+	}
+	if r := results["XX000000"]; !errors.Is(r.Err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("expected not found, got %v", r.Err)
+	}
+}
+
+func TestCourseFileAPI_InvalidFile(t *testing.T) {
+	for name, content := range map[string]string{
+		"not json": `{`,
+		"no code":  `[{"name_en": "Software Engineering"}]`,
+	} {
+		if _, err := NewCourseFileAPI(writeCourseFile(t, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := NewCourseFileAPI(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
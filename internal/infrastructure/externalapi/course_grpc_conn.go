@@ -0,0 +1,130 @@
+package externalapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// GRPCConnConfig configures the connection to the course gRPC service.
+type GRPCConnConfig struct {
+	Addr string
+
+	TLS        bool
+	CAFile     string // PEM CA bundle; empty uses the system roots
+	CertFile   string // client certificate for mTLS, with KeyFile
+	KeyFile    string
+	ServerName string // overrides the name verified against the server certificate
+
+	Token        string // sent as "authorization: Bearer <token>"
+	APIKey       string // sent in APIKeyHeader
+	APIKeyHeader string // default "x-api-key"
+
+	KeepaliveTime    time.Duration // ping after this long without activity; 0 disables
+	KeepaliveTimeout time.Duration // wait this long for the ping ack
+	MaxAttempts      int           // attempts per RPC on UNAVAILABLE, including the first (1-5)
+}
+
+// DialCourseGRPC creates a client connection to the course service. The
+// connection is made lazily, on the first RPC.
+func DialCourseGRPC(cfg GRPCConnConfig) (*grpc.ClientConn, error) {
+	transport := insecure.NewCredentials()
+	if cfg.TLS {
+		tlsCfg, err := clientTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport = credentials.NewTLS(tlsCfg)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(transport)}
+
+	md := map[string]string{}
+	if cfg.Token != "" {
+		md["authorization"] = "Bearer " + cfg.Token
+	}
+	if cfg.APIKey != "" {
+		header := cfg.APIKeyHeader
+		if header == "" {
+			header = "x-api-key"
+		}
+		md[header] = cfg.APIKey
+	}
+	if len(md) > 0 {
+		opts = append(opts, grpc.WithPerRPCCredentials(staticCredentials{md: md, requireTLS: cfg.TLS}))
+	}
+
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}))
+	}
+	if cfg.MaxAttempts > 1 {
+		opts = append(opts, grpc.WithDefaultServiceConfig(retryServiceConfig(cfg.MaxAttempts)))
+	}
+
+	return grpc.NewClient(cfg.Addr, opts...)
+}
+
+func clientTLSConfig(cfg GRPCConnConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: cfg.ServerName}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read course gRPC CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("course gRPC CA bundle %s: no certificates found", cfg.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load course gRPC client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+// retryServiceConfig retries every CourseService RPC that fails with
+// UNAVAILABLE, which gRPC only does when no response has been received yet.
+func retryServiceConfig(maxAttempts int) string {
+	return fmt.Sprintf(`{"methodConfig": [{
+		"name": [{"service": %q}],
+		"retryPolicy": {
+			"maxAttempts": %d,
+			"initialBackoff": "0.2s",
+			"maxBackoff": "2s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]}`, pb.CourseService_ServiceDesc.ServiceName, maxAttempts)
+}
+
+// staticCredentials attaches fixed metadata to every RPC.
+type staticCredentials struct {
+	md         map[string]string
+	requireTLS bool
+}
+
+func (c staticCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return c.md, nil
+}
+
+// RequireTransportSecurity is false only for plaintext connections, which
+// config allows outside production for local mock servers.
+func (c staticCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
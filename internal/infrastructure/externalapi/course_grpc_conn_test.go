@@ -0,0 +1,230 @@
+package externalapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ---- test PKI ----
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	ca := &testCA{cert: cert, key: key, dir: t.TempDir()}
+	ca.write(t, "ca.pem", "CERTIFICATE", der)
+	return ca
+}
+
+func (ca *testCA) write(t *testing.T, name, typ string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(ca.dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// issue signs a leaf certificate and writes it and its key to name.pem and
+// name-key.pem.
+func (ca *testCA) issue(t *testing.T, name string, serial int64, dnsName string, usage x509.ExtKeyUsage) (tls.Certificate, string, string) {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	certFile := ca.write(t, name+".pem", "CERTIFICATE", der)
+	keyFile := ca.write(t, name+"-key.pem", "EC PRIVATE KEY", keyDER)
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair, certFile, keyFile
+}
+
+// ---- test server ----
+
+type connTestServer struct {
+	pb.UnimplementedCourseServiceServer
+	failFirst int32 // calls answered with UNAVAILABLE before succeeding
+	calls     atomic.Int32
+	md        atomic.Value // metadata.MD of the last call
+}
+
+func (s *connTestServer) FetchByCode(ctx context.Context, req *pb.FetchByCodeRequest) (*pb.FetchByCodeResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.md.Store(md)
+	if s.calls.Add(1) <= s.failFirst {
+		return nil, status.Error(codes.Unavailable, "warming up")
+	}
+	return &pb.FetchByCodeResponse{Code: req.Code}, nil
+}
+
+func startConnTestServer(t *testing.T, srv *connTestServer, opts ...grpc.ServerOption) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(opts...)
+	pb.RegisterCourseServiceServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func fetchOnce(t *testing.T, conn *grpc.ClientConn) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := pb.NewCourseServiceClient(conn).FetchByCode(ctx, &pb.FetchByCodeRequest{Code: "CP353004"})
+	return err
+}
+
+// ---- tests ----
+
+func TestDialCourseGRPC_MutualTLSAndToken(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, _, _ := ca.issue(t, "server", 2, "courses.internal", x509.ExtKeyUsageServerAuth)
+	_, clientCertFile, clientKeyFile := ca.issue(t, "client", 3, "client", x509.ExtKeyUsageClientAuth)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	srv := &connTestServer{}
+	addr := startConnTestServer(t, srv, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+
+	cfg := GRPCConnConfig{
+		Addr:       addr,
+		TLS:        true,
+		CAFile:     filepath.Join(ca.dir, "ca.pem"),
+		CertFile:   clientCertFile,
+		KeyFile:    clientKeyFile,
+		ServerName: "courses.internal", // the certificate doesn't name 127.0.0.1
+		Token:      "s3cret",
+	}
+	conn, err := DialCourseGRPC(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+	if err := fetchOnce(t, conn); err != nil {
+		t.Fatalf("expected the call to succeed over mTLS, got %v", err)
+	}
+	md := srv.md.Load().(metadata.MD)
+	if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer s3cret" {
+		t.Errorf("expected bearer token metadata, got %v", got)
+	}
+
+	// Without a client certificate the server rejects the handshake.
+	cfg.CertFile, cfg.KeyFile = "", ""
+	noClientCert, err := DialCourseGRPC(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer noClientCert.Close()
+	if err := fetchOnce(t, noClientCert); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the handshake to fail without a client certificate, got %v", err)
+	}
+}
+
+func TestDialCourseGRPC_APIKeyAndRetry(t *testing.T) {
+	srv := &connTestServer{failFirst: 2}
+	addr := startConnTestServer(t, srv)
+
+	conn, err := DialCourseGRPC(GRPCConnConfig{Addr: addr, APIKey: "k3y", APIKeyHeader: "x-course-key", MaxAttempts: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+	if err := fetchOnce(t, conn); err != nil {
+		t.Fatalf("expected the retries to succeed, got %v", err)
+	}
+	if got := srv.calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if got := srv.md.Load().(metadata.MD).Get("x-course-key"); len(got) != 1 || got[0] != "k3y" {
+		t.Errorf("expected API key metadata, got %v", got)
+	}
+
+	// One attempt: the first UNAVAILABLE is returned.
+	srv.calls.Store(0)
+	single, err := DialCourseGRPC(GRPCConnConfig{Addr: addr, MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer single.Close()
+	if err := fetchOnce(t, single); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected UNAVAILABLE without retries, got %v", err)
+	}
+}
+
+func TestDialCourseGRPC_BadTLSFiles(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, cfg := range map[string]GRPCConnConfig{
+		"missing CA":  {Addr: "localhost:1", TLS: true, CAFile: filepath.Join(dir, "missing.pem")},
+		"empty CA":    {Addr: "localhost:1", TLS: true, CAFile: notPEM},
+		"bad keypair": {Addr: "localhost:1", TLS: true, CertFile: notPEM, KeyFile: notPEM},
+	} {
+		if _, err := DialCourseGRPC(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
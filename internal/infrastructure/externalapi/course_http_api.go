@@ -0,0 +1,160 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	pb "github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepb"
+)
+
+// maxCourseResponse caps the size of a course JSON response.
+const maxCourseResponse = 4 << 20
+
+// courseDocument is the JSON form of a course, served by the HTTP API at
+// <base>/<code> and listed in static course files. It has the same fields as
+// the gRPC FetchByCodeResponse, except that program may also be given once
+// for the whole course.
+type courseDocument struct {
+	Code         string            `json:"code"`
+	NameEN       string            `json:"name_en"`
+	NameTH       string            `json:"name_th"`
+	Faculty      string            `json:"faculty"`
+	Department   string            `json:"department"`
+	Credits      string            `json:"credits"`
+	Prerequisite string            `json:"prerequisite"`
+	Semester     int32             `json:"semester"`
+	Year         int32             `json:"year"`
+	Program      string            `json:"program"` // default for sections without one
+	Sections     []documentSection `json:"sections"`
+}
+
+type documentSection struct {
+	Number      string             `json:"number"`
+	Schedules   []documentSchedule `json:"schedules"`
+	Seats       int32              `json:"seats"`
+	Instructor  []string           `json:"instructor"`
+	ExamDate    string             `json:"exam_date"`
+	MidtermDate string             `json:"midterm_date"`
+	Note        string             `json:"note"`
+	ReservedFor []string           `json:"reserved_for"`
+	Campus      string             `json:"campus"`
+	Program     string             `json:"program"`
+}
+
+type documentSchedule struct {
+	Day  string `json:"day"`
+	Time string `json:"time"`
+	Room string `json:"room"`
+	Type string `json:"type"`
+}
+
+// courseHTTPAPI is the HTTP/JSON implementation of repository.CourseExternalAPI.
+type courseHTTPAPI struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewCourseHTTPAPI creates a CourseExternalAPI that fetches courses from
+// GET <baseURL>/<code>?acadyear=..&semester=... A zero timeout means 30s.
+func NewCourseHTTPAPI(baseURL string, timeout time.Duration) repository.CourseExternalAPI {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &courseHTTPAPI{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// FetchByCode implements repository.CourseExternalAPI. A 404 is reported as
+// repository.ErrExternalCourseNotFound.
+func (a *courseHTTPAPI) FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	q := url.Values{}
+	q.Set("acadyear", strconv.Itoa(acadyear))
+	q.Set("semester", strconv.Itoa(semester))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/"+url.PathEscape(code)+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("course api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", repository.ErrExternalCourseNotFound, code)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("course api: %s returned status %d", code, resp.StatusCode)
+	}
+
+	var doc courseDocument
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCourseResponse)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w for %s: %v", repository.ErrMalformedCourse, code, err)
+	}
+	return toCourse(code, doc.toProto()), nil
+}
+
+// FetchByCodes implements repository.CourseExternalAPI. The HTTP source has
+// no batch endpoint, so codes are fetched one by one.
+func (a *courseHTTPAPI) FetchByCodes(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	return fetchEach(ctx, courseCodes, func(ctx context.Context, code string) (*entity.Course, error) {
+		return a.FetchByCode(ctx, code, acadyear, semester)
+	})
+}
+
+func (a *courseHTTPAPI) fetchBatch(context.Context, []string, int, int) (map[string]repository.CourseFetchResult, error) {
+	return nil, errNoBatch
+}
+
+// toProto maps the document onto the gRPC message so both sources share
+// protoToCourse.
+func (c courseDocument) toProto() *pb.FetchByCodeResponse {
+	sections := make([]*pb.Section, len(c.Sections))
+	for i, s := range c.Sections {
+		schedules := make([]*pb.Schedule, len(s.Schedules))
+		for j, sc := range s.Schedules {
+			schedules[j] = &pb.Schedule{Day: sc.Day, Time: sc.Time, Room: sc.Room, Type: sc.Type}
+		}
+		program := s.Program
+		if program == "" {
+			program = c.Program
+		}
+		sections[i] = &pb.Section{
+			Number:      s.Number,
+			Schedules:   schedules,
+			Seats:       s.Seats,
+			Instructor:  s.Instructor,
+			ExamDate:    s.ExamDate,
+			MidtermDate: s.MidtermDate,
+			Note:        s.Note,
+			ReservedFor: s.ReservedFor,
+			Campus:      s.Campus,
+			Program:     program,
+		}
+	}
+	return &pb.FetchByCodeResponse{
+		Code:         c.Code,
+		NameEn:       c.NameEN,
+		NameTh:       c.NameTH,
+		Faculty:      c.Faculty,
+		Department:   c.Department,
+		Credits:      c.Credits,
+		Prerequisite: c.Prerequisite,
+		Semester:     c.Semester,
+		Year:         c.Year,
+		Sections:     sections,
+	}
+}
@@ -0,0 +1,196 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+// ---- fake course HTTP server ----
+
+func newCourseHTTPServer(t *testing.T, courses map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("acadyear") != "2568" || r.URL.Query().Get("semester") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		code := strings.TrimPrefix(r.URL.Path, "/")
+		if code == "DOWN" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, ok := courses[code]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "course not found"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+const httpCourseJSON = `{
+	"code": "CP353004",
+	"name_en": "Software Engineering",
+	"name_th": "วิศวกรรมซอฟต์แวร์",
+	"faculty": "วิทยาลัยการคอมพิวเตอร์",
+	"credits": "3 (2-2-5)",
+	"semester": 1,
+	"year": 2568,
+	"program": "ปริญญาตรี ภาคปกติ",
+	"sections": [
+		{
+			"number": "01",
+			"seats": 40,
+			"instructor": ["Dr. Smith"],
+			"exam_date": "31 มี.ค. 2569 เวลา 13:00 - 16:00",
+			"schedules": [{"day": "จันทร์", "time": "13:00-15:00", "room": "CP9 CP9127", "type": "C"}]
+		},
+		{"number": "02", "program": "ปริญญาตรี ภาคพิเศษ"}
+	]
+}`
+
+// ---- tests ----
+
+func TestCourseHTTPAPI_FetchByCode(t *testing.T) {
+	srv := newCourseHTTPServer(t, map[string]string{"CP353004": httpCourseJSON})
+	api := NewCourseHTTPAPI(srv.URL+"/", time.Second)
+
+	course, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course.Code != "CP353004" || course.NameEN != "Software Engineering" || course.Credits != "3 (2-2-5)" || len(course.Sections) != 2 {
+		t.Fatalf("unexpected course: %+v", course)
+	}
+	s1, s2 := course.Sections[0], course.Sections[1]
+	if s1.Seats != 40 || len(s1.Schedules) != 1 || s1.ExamStart.IsZero() {
+		t.Errorf("unexpected section 01: %+v", s1)
+	}
+	if s1.Program != "ปริญญาตรี ภาคปกติ" {
+		t.Errorf("expected course-level program on section 01, got %q", s1.Program)
+	}
+	if s2.Program != "ปริญญาตรี ภาคพิเศษ" {
+		t.Errorf("expected section program to win, got %q", s2.Program)
+	}
+}
+
+func TestCourseHTTPAPI_Errors(t *testing.T) {
+	srv := newCourseHTTPServer(t, map[string]string{
+		"BADJSON": `{"code": `,
+		"BADDATE": `{"code": "BADDATE", "sections": [{"number": "01", "exam_date": "bad date string"}]}`,
+	})
+	api := NewCourseHTTPAPI(srv.URL, time.Second)
+
+	tests := []struct {
+		code string
+		want error
+	}{
+		{"NOPE", repository.ErrExternalCourseNotFound},
+		{"BADJSON", repository.ErrMalformedCourse},
+	}
+	for _, tt := range tests {
+		if _, err := api.FetchByCode(context.Background(), tt.code, 2568, 1); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.code, tt.want, err)
+		}
+	}
+
+	course, err := api.FetchByCode(context.Background(), "BADDATE", 2568, 1)
+	if err != nil || len(course.Sections) != 1 || len(course.Unparsed) != 1 {
+		t.Errorf("expected BADDATE with its exam date unparsed, got %+v, %v", course, err)
+	}
+
+	_, err = api.FetchByCode(context.Background(), "DOWN", 2568, 1)
+	if err == nil || !strings.Contains(err.Error(), "503") || !isUpstreamFailure(err) {
+		t.Errorf("expected an upstream failure for 503, got %v", err)
+	}
+}
+
+func TestCourseHTTPAPI_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+	api := NewCourseHTTPAPI(srv.URL, 20*time.Millisecond)
+
+	start := time.Now()
+	if _, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request was not bounded by the timeout: %v", elapsed)
+	}
+}
+
+func TestCourseHTTPAPI_FetchByCodes(t *testing.T) {
+	srv := newCourseHTTPServer(t, map[string]string{"CP353004": httpCourseJSON})
+	api := NewCourseHTTPAPI(srv.URL, time.Second)
+
+	results, err := api.FetchByCodes(context.Background(), []string{"CP353004", "NOPE"}, 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := results["CP353004"]; r.Err != nil || r.Course == nil {
+		t.Errorf("expected CP353004 to be fetched, got %+v", r)
+	}
+	if r := results["NOPE"]; !errors.Is(r.Err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("expected NOPE not found, got %+v", r)
+	}
+
+	if _, err := api.FetchByCodes(context.Background(), []string{"CP353004", "DOWN"}, 2568, 1); err == nil {
+		t.Error("expected an upstream failure to fail the batch")
+	}
+}
+
+// TestCourseHTTPAPI_MockRequestShape serves a course from scripts/mock_course_api.py
+// the way that script reads requests: the code from the raw path alone and
+// the term from its query string.
+func TestCourseHTTPAPI_MockRequestShape(t *testing.T) {
+	const mockCourseJSON = `{
+		"code": "CP353004", "name_en": "Software Engineering", "credits": "3(2-2-5)",
+		"semester": 1, "year": 2567, "program": "Undergraduate (Regular)",
+		"sections": [{
+			"number": "02", "seats": 40,
+			"exam_date": "31 มี.ค. 2567 เวลา 13:00 - 16:00",
+			"schedules": [{"day": "Monday", "time": "15:00-17:00", "room": "CP9127", "type": "Lecture"}]
+		}]
+	}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, err := url.Parse(r.RequestURI)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		q := u.Query()
+		if strings.Trim(u.Path, "/") != "CP353004" || q.Get("acadyear") != "2567" || q.Get("semester") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(mockCourseJSON))
+	}))
+	t.Cleanup(srv.Close)
+	api := NewCourseHTTPAPI(srv.URL, time.Second)
+
+	course, err := api.FetchByCode(context.Background(), "CP353004", 2567, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if course.Code != "CP353004" || len(course.Sections) != 1 || len(course.Sections[0].Schedules) != 1 || len(course.Unparsed) != 0 {
+		t.Errorf("unexpected course: %+v", course)
+	}
+	if _, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1); !errors.Is(err, repository.ErrExternalCourseNotFound) {
+		t.Errorf("expected a course outside its term not to be found, got %v", err)
+	}
+}
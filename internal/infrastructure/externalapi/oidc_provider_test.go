@@ -0,0 +1,176 @@
+package externalapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ---- fake identity provider ----
+
+type fakeIDP struct {
+	srv     *httptest.Server
+	issuer  string // advertised in discovery
+	edKey   ed25519.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	idToken string // returned by the token endpoint
+}
+
+func newFakeIDP(t *testing.T) *fakeIDP {
+	t.Helper()
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	f := &fakeIDP{edKey: edKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.issuer,
+			"authorization_endpoint": f.srv.URL + "/authorize",
+			"token_endpoint":         f.srv.URL + "/token",
+			"jwks_uri":               f.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		enc := base64.RawURLEncoding.EncodeToString
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{
+			{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": enc(edKey.Public().(ed25519.PublicKey))},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": enc(ecKey.X.Bytes()), "y": enc(ecKey.Y.Bytes())},
+			{"kty": "oct", "kid": "hs", "k": "c2VjcmV0"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": f.idToken})
+	})
+	f.srv = httptest.NewServer(mux)
+	f.issuer = f.srv.URL
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeIDP) provider() repository.IdentityProvider {
+	return NewOIDCProvider(OIDCProviderConfig{Issuer: f.srv.URL, ClientID: "app", RedirectURL: "http://app/cb"})
+}
+
+func (f *fakeIDP) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss": f.srv.URL, "sub": "u1", "aud": "app", "nonce": "n",
+		"iat": now.Unix(), "exp": now.Add(time.Minute).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	tok.Header["kid"] = kid
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// ---- tests ----
+
+func TestOIDCAuthCodeURL(t *testing.T) {
+	f := newFakeIDP(t)
+	raw, err := f.provider().AuthCodeURL(context.Background(), "st", "n", "chal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(raw)
+	q := u.Query()
+	if u.Path != "/authorize" || q.Get("scope") != "openid" || q.Get("code_challenge") != "chal" ||
+		q.Get("code_challenge_method") != "S256" || q.Get("state") != "st" || q.Get("nonce") != "n" {
+		t.Errorf("unexpected authorization URL %s", raw)
+	}
+}
+
+func TestOIDCDiscovery_IssuerMismatch(t *testing.T) {
+	f := newFakeIDP(t)
+	f.issuer = "https://evil.example"
+	if _, err := f.provider().AuthCodeURL(context.Background(), "st", "n", "chal"); err == nil {
+		t.Error("expected issuer mismatch to be rejected")
+	}
+}
+
+func TestOIDCExchange_VerifiesIDToken(t *testing.T) {
+	f := newFakeIDP(t)
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{"EdDSA", func() string { return sign(t, jwt.SigningMethodEdDSA, "ed", f.edKey, f.claims()) }, false},
+		{"ES256", func() string { return sign(t, jwt.SigningMethodES256, "ec", f.ecKey, f.claims()) }, false},
+		{"HS256 rejected", func() string { return sign(t, jwt.SigningMethodHS256, "hs", []byte("secret"), f.claims()) }, true},
+		{"wrong audience", func() string {
+			c := f.claims()
+			c["aud"] = "other"
+			return sign(t, jwt.SigningMethodEdDSA, "ed", f.edKey, c)
+		}, true},
+		{"several audiences without azp", func() string {
+			c := f.claims()
+			c["aud"] = []string{"app", "other"}
+			return sign(t, jwt.SigningMethodEdDSA, "ed", f.edKey, c)
+		}, true},
+		{"expired", func() string {
+			c := f.claims()
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return sign(t, jwt.SigningMethodEdDSA, "ed", f.edKey, c)
+		}, true},
+		{"wrong issuer", func() string {
+			c := f.claims()
+			c["iss"] = "https://evil.example"
+			return sign(t, jwt.SigningMethodEdDSA, "ed", f.edKey, c)
+		}, true},
+		{"unknown kid", func() string { return sign(t, jwt.SigningMethodEdDSA, "nope", f.edKey, f.claims()) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f.idToken = tt.token()
+			identity, err := f.provider().Exchange(context.Background(), "code", "verifier", "n")
+			if tt.wantErr {
+				if !errors.Is(err, repository.ErrIdentityRejected) {
+					t.Errorf("expected ErrIdentityRejected, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.Subject != "u1" || identity.ID() != f.srv.URL+"|u1" {
+				t.Errorf("unexpected identity %+v", identity)
+			}
+		})
+	}
+}
+
+func TestOIDCExchange_TokenEndpointRejects(t *testing.T) {
+	f := newFakeIDP(t)
+	_, err := f.provider().Exchange(context.Background(), "code", "", "n")
+	if !errors.Is(err, repository.ErrIdentityRejected) {
+		t.Errorf("expected ErrIdentityRejected, got %v", err)
+	}
+}
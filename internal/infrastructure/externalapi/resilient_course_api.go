@@ -0,0 +1,134 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/resilience"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResilienceConfig configures ResilientCourseAPI.
+type ResilienceConfig struct {
+	CallTimeout   time.Duration // per-call deadline, including the wait for a slot
+	MaxConcurrent int           // concurrent upstream calls
+	Breaker       resilience.BreakerConfig
+}
+
+// ResilientCourseAPI wraps a repository.CourseExternalAPI with a circuit
+// breaker, a per-call deadline and a concurrency limit, so an unhealthy
+// upstream fails calls fast instead of holding every queue worker.
+type ResilientCourseAPI struct {
+	next     repository.CourseExternalAPI
+	breaker  *resilience.CircuitBreaker
+	bulkhead *resilience.Bulkhead
+	timeout  time.Duration
+}
+
+// NewResilientCourseAPI wraps next. A zero CallTimeout means 30s.
+func NewResilientCourseAPI(next repository.CourseExternalAPI, cfg ResilienceConfig) *ResilientCourseAPI {
+	if cfg.CallTimeout <= 0 {
+		cfg.CallTimeout = 30 * time.Second
+	}
+	return &ResilientCourseAPI{
+		next:     next,
+		breaker:  resilience.NewCircuitBreaker(cfg.Breaker),
+		bulkhead: resilience.NewBulkhead(cfg.MaxConcurrent),
+		timeout:  cfg.CallTimeout,
+	}
+}
+
+// FetchByCode implements repository.CourseExternalAPI.
+func (a *ResilientCourseAPI) FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	var course *entity.Course
+	err := a.call(ctx, func(ctx context.Context) (err error) {
+		course, err = a.next.FetchByCode(ctx, code, acadyear, semester)
+		return err
+	})
+	return course, err
+}
+
+// FetchByCodes implements repository.CourseExternalAPI. A batch the upstream
+// serves in one request is one call. When it has no batch endpoint, each
+// code is fetched with FetchByCode, so every request gets its own deadline
+// and slot and a long batch does not run out of time.
+func (a *ResilientCourseAPI) FetchByCodes(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	fetchBatch := a.next.FetchByCodes
+	src, ok := a.next.(batchSource)
+	if ok {
+		fetchBatch = src.fetchBatch
+	}
+
+	var results map[string]repository.CourseFetchResult
+	err := a.call(ctx, func(ctx context.Context) (err error) {
+		results, err = fetchBatch(ctx, courseCodes, acadyear, semester)
+		return err
+	})
+	if !errors.Is(err, errNoBatch) {
+		return results, err
+	}
+	return fetchEach(ctx, courseCodes, func(ctx context.Context, code string) (*entity.Course, error) {
+		return a.FetchByCode(ctx, code, acadyear, semester)
+	})
+}
+
+// Health reports the breaker and concurrency state.
+func (a *ResilientCourseAPI) Health() entity.UpstreamHealth {
+	st := a.breaker.Status()
+	return entity.UpstreamHealth{
+		Breaker:             st.State.String(),
+		ConsecutiveFailures: st.ConsecutiveFailures,
+		OpenUntil:           st.OpenUntil,
+		InFlight:            a.bulkhead.InFlight(),
+		MaxConcurrent:       a.bulkhead.Capacity(),
+	}
+}
+
+func (a *ResilientCourseAPI) call(ctx context.Context, fn func(context.Context) error) error {
+	if err := a.breaker.Allow(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	if err := a.bulkhead.Acquire(ctx); err != nil {
+		// Our own backlog, not an upstream failure.
+		a.breaker.Ignore()
+		return err
+	}
+	defer a.bulkhead.Release()
+
+	err := fn(ctx)
+	switch {
+	case err == nil:
+		a.breaker.Success()
+	case errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled || errors.Is(err, errNoBatch):
+		// Given up by the caller, or nothing was asked of the upstream.
+		a.breaker.Ignore()
+	case isUpstreamFailure(err):
+		a.breaker.Failure()
+	default:
+		a.breaker.Success()
+	}
+	return err
+}
+
+// isUpstreamFailure reports whether err says the upstream is unhealthy, as
+// opposed to an answer about the request itself (not found, bad data).
+func isUpstreamFailure(err error) bool {
+	if errors.Is(err, repository.ErrMalformedCourse) || errors.Is(err, repository.ErrExternalCourseNotFound) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange,
+		codes.AlreadyExists, codes.PermissionDenied, codes.Unauthenticated, codes.Unimplemented:
+		return false
+	}
+	// Unavailable, DeadlineExceeded, ResourceExhausted, Internal, and errors
+	// without a gRPC status such as an expired context.
+	return true
+}
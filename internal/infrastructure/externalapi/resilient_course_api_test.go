@@ -0,0 +1,172 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/resilience"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ---- fake CourseExternalAPI ----
+
+type fakeCourseAPI struct {
+	calls atomic.Int32
+	fetch func(ctx context.Context) error
+}
+
+func (f *fakeCourseAPI) FetchByCode(ctx context.Context, code string, acadyear, semester int) (*entity.Course, error) {
+	f.calls.Add(1)
+	if err := f.fetch(ctx); err != nil {
+		return nil, err
+	}
+	return &entity.Course{Code: code}, nil
+}
+
+func (f *fakeCourseAPI) FetchByCodes(ctx context.Context, courseCodes []string, acadyear, semester int) (map[string]repository.CourseFetchResult, error) {
+	f.calls.Add(1)
+	if err := f.fetch(ctx); err != nil {
+		return nil, err
+	}
+	return map[string]repository.CourseFetchResult{}, nil
+}
+
+func newResilient(next repository.CourseExternalAPI, threshold int) *ResilientCourseAPI {
+	return NewResilientCourseAPI(next, ResilienceConfig{
+		CallTimeout:   time.Second,
+		MaxConcurrent: 2,
+		Breaker:       resilience.BreakerConfig{FailureThreshold: threshold, OpenTimeout: time.Minute},
+	})
+}
+
+// ---- tests ----
+
+func TestResilient_OpensOnUpstreamFailures(t *testing.T) {
+	next := &fakeCourseAPI{fetch: func(context.Context) error { return status.Error(codes.Unavailable, "connection refused") }}
+	api := newResilient(next, 3)
+
+	for i := 0; i < 3; i++ {
+		if _, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1); status.Code(err) != codes.Unavailable {
+			t.Fatalf("call %d: expected Unavailable, got %v", i, err)
+		}
+	}
+	_, err := api.FetchByCodes(context.Background(), []string{"CP353004"}, 2568, 1)
+	if !errors.Is(err, resilience.ErrOpen) {
+		t.Fatalf("expected ErrOpen, got %v", err)
+	}
+	if next.calls.Load() != 3 {
+		t.Errorf("expected the open breaker to skip the upstream, got %d calls", next.calls.Load())
+	}
+
+	h := api.Health()
+	if h.Breaker != "open" || h.ConsecutiveFailures != 3 || h.OpenUntil.IsZero() || h.MaxConcurrent != 2 {
+		t.Errorf("unexpected health: %+v", h)
+	}
+}
+
+func TestResilient_RequestErrorsDoNotTrip(t *testing.T) {
+	errs := []error{
+		status.Error(codes.NotFound, "course not found"),
+		repository.ErrMalformedCourse,
+		context.Canceled,
+	}
+	for _, want := range errs {
+		next := &fakeCourseAPI{fetch: func(context.Context) error { return want }}
+		api := newResilient(next, 1)
+
+		for i := 0; i < 2; i++ {
+			if _, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1); !errors.Is(err, want) && status.Code(err) != status.Code(want) {
+				t.Fatalf("expected %v, got %v", want, err)
+			}
+		}
+		if h := api.Health(); h.Breaker != "closed" {
+			t.Errorf("%v: expected breaker closed, got %s", want, h.Breaker)
+		}
+	}
+}
+
+func TestResilient_CallDeadline(t *testing.T) {
+	next := &fakeCourseAPI{fetch: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	api := NewResilientCourseAPI(next, ResilienceConfig{CallTimeout: 20 * time.Millisecond, MaxConcurrent: 1})
+
+	start := time.Now()
+	_, err := api.FetchByCode(context.Background(), "CP353004", 2568, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("call was not bounded by the deadline: %v", elapsed)
+	}
+	if h := api.Health(); h.ConsecutiveFailures != 1 {
+		t.Errorf("expected a timeout to count as a failure, got %+v", h)
+	}
+}
+
+func TestResilient_ConcurrencyLimit(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	next := &fakeCourseAPI{fetch: func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}}
+	api := NewResilientCourseAPI(next, ResilienceConfig{CallTimeout: 50 * time.Millisecond, MaxConcurrent: 1})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := api.FetchByCode(context.Background(), "A", 2568, 1)
+		done <- err
+	}()
+	<-started
+	if h := api.Health(); h.InFlight != 1 {
+		t.Errorf("expected 1 call in flight, got %d", h.InFlight)
+	}
+
+	_, err := api.FetchByCode(context.Background(), "B", 2568, 1)
+	if !errors.Is(err, resilience.ErrBulkheadFull) {
+		t.Errorf("expected ErrBulkheadFull, got %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("expected first call to succeed, got %v", err)
+	}
+	if h := api.Health(); h.Breaker != "closed" || h.ConsecutiveFailures != 0 {
+		t.Errorf("a full bulkhead must not count against the upstream: %+v", h)
+	}
+}
+
+func TestResilient_BatchWithoutEndpointGetsDeadlinePerCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"code": "` + strings.TrimPrefix(r.URL.Path, "/") + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	api := NewResilientCourseAPI(NewCourseHTTPAPI(srv.URL, time.Second), ResilienceConfig{CallTimeout: 100 * time.Millisecond, MaxConcurrent: 1})
+
+	// Together the requests outlast the call deadline; each one is well within it.
+	courseCodes := []string{"A", "B", "C", "D", "E"}
+	results, err := api.FetchByCodes(context.Background(), courseCodes, 2568, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, code := range courseCodes {
+		if r := results[code]; r.Err != nil || r.Course == nil {
+			t.Errorf("expected %s to be fetched, got %+v", code, r)
+		}
+	}
+	if h := api.Health(); h.ConsecutiveFailures != 0 || h.InFlight != 0 {
+		t.Errorf("unexpected health: %+v", h)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
@@ -68,3 +69,17 @@ func buildURI(host, dbName, user, password string) string {
 	}
 	return fmt.Sprintf("mongodb://%s/%s", host, dbName)
 }
+
+// SupportsTransactions reports whether the deployment accepts multi-document
+// transactions, which need a replica set or a sharded cluster. A standalone
+// server does not.
+func (m *MongoDB) SupportsTransactions(ctx context.Context) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := m.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// changeSubject summarises a change event, e.g. "CP353004 2568/1: 2 changes".
+func changeSubject(event *entity.CourseChangeEvent) string {
+	noun := "changes"
+	if len(event.Changes) == 1 {
+		noun = "change"
+	}
+	return fmt.Sprintf("%s %d/%d: %d %s", event.CourseCode, event.Year, event.Semester, len(event.Changes), noun)
+}
+
+// formatChange describes one change on a line, e.g.
+// "section 01 ROOM_CHANGED WED 09:00-11:00 (C): CP9127 -> SC2101".
+func formatChange(c entity.CourseChange) string {
+	var b strings.Builder
+	if c.Section != "" {
+		b.WriteString("section " + c.Section + " ")
+	}
+	b.WriteString(string(c.Kind))
+	if c.Detail != "" {
+		b.WriteString(" " + c.Detail)
+	}
+	switch {
+	case c.Old != "" && c.New != "":
+		fmt.Fprintf(&b, ": %s -> %s", c.Old, c.New)
+	case c.New != "":
+		b.WriteString(": " + c.New)
+	case c.Old != "":
+		b.WriteString(": was " + c.Old)
+	}
+	return b.String()
+}
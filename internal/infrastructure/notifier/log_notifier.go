@@ -0,0 +1,65 @@
+// Package notifier delivers messages to users outside the API.
+package notifier
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+type logNotifier struct {
+	resetURL string
+}
+
+// NewLogNotifier returns a PasswordResetNotifier that writes reset links to
+// the server log, for development only: anyone who can read the log can use
+// the tokens. The token is appended to resetURL as ?token=...; an empty
+// resetURL logs the bare token.
+func NewLogNotifier(resetURL string) repository.PasswordResetNotifier {
+	return &logNotifier{resetURL: resetURL}
+}
+
+func (n *logNotifier) SendPasswordReset(_ context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	link, err := resetLink(n.resetURL, token)
+	if err != nil {
+		return err
+	}
+	log.Printf("[password-reset] reset for user %s (%s), valid until %s: %s",
+		user.Username, user.ID, expiresAt.Format(time.RFC3339), link)
+	return nil
+}
+
+type logChangeNotifier struct{}
+
+// NewLogChangeNotifier returns a CourseChangeNotifier that writes
+// notifications to the server log, for development.
+func NewLogChangeNotifier() repository.CourseChangeNotifier {
+	return logChangeNotifier{}
+}
+
+func (logChangeNotifier) NotifyCourseChanges(_ context.Context, user *entity.User, event *entity.CourseChangeEvent) error {
+	log.Printf("[watch-notify] to user %s (%s): %s", user.Username, user.ID, changeSubject(event))
+	for _, c := range event.Changes {
+		log.Printf("[watch-notify]   %s", formatChange(c))
+	}
+	return nil
+}
+
+type logEventSink struct{}
+
+// NewLogEventSink returns an EventSink that writes relayed domain events to
+// the server log, for development and auditing.
+func NewLogEventSink() repository.EventSink {
+	return logEventSink{}
+}
+
+func (logEventSink) Name() string { return "log" }
+
+func (logEventSink) Handle(_ context.Context, event *entity.DomainEvent) error {
+	log.Printf("[event] %s %s of %s at %s: %s",
+		event.ID, event.Type, event.AggregateID, event.OccurredAt.Format(time.RFC3339), event.Data)
+	return nil
+}
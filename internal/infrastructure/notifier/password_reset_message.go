@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+)
+
+// resetSubject is the subject of a password reset message.
+const resetSubject = "Reset your password"
+
+// resetLink appends token to resetURL as ?token=...; an empty resetURL gives
+// the bare token.
+func resetLink(resetURL, token string) (string, error) {
+	if resetURL == "" {
+		return token, nil
+	}
+	u, err := url.Parse(resetURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// formatExpiry formats when a reset link expires, in Thai time.
+func formatExpiry(expiresAt time.Time) string {
+	return thaicalendar.FormatShort(expiresAt.In(thaicalendar.Location))
+}
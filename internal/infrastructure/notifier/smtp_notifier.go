@@ -0,0 +1,188 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
+)
+
+// SMTPConfig configures e-mail notifications.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // empty sends without authentication
+	Password string
+	From     string
+	// RecipientDomain is appended to usernames that are not e-mail
+	// addresses, e.g. "kkumail.com" turns "653040123-4" into
+	// "653040123-4@kkumail.com".
+	RecipientDomain string
+	Timeout         time.Duration // for the whole SMTP conversation
+}
+
+type smtpNotifier struct {
+	cfg      SMTPConfig
+	resetURL string
+}
+
+// NewSMTPNotifier returns a CourseChangeNotifier that e-mails users. The
+// connection is upgraded with STARTTLS whenever the server offers it.
+func NewSMTPNotifier(cfg SMTPConfig) repository.CourseChangeNotifier {
+	return &smtpNotifier{cfg: cfg}
+}
+
+// NewSMTPPasswordResetNotifier returns a PasswordResetNotifier that e-mails
+// users their reset link. The token is appended to resetURL as ?token=...;
+// an empty resetURL sends the bare token.
+func NewSMTPPasswordResetNotifier(cfg SMTPConfig, resetURL string) repository.PasswordResetNotifier {
+	return &smtpNotifier{cfg: cfg, resetURL: resetURL}
+}
+
+func (n *smtpNotifier) NotifyCourseChanges(ctx context.Context, user *entity.User, event *entity.CourseChangeEvent) error {
+	to, err := n.recipient(user)
+	if err != nil {
+		return err
+	}
+	msg, err := n.message(to, changeSubject(event), func(w io.Writer) {
+		fmt.Fprintf(w, "%s %d/%d changed on %s:\r\n\r\n", event.CourseCode, event.Year, event.Semester,
+			thaicalendar.FormatShort(event.DetectedAt.In(thaicalendar.Location)))
+		for _, c := range event.Changes {
+			fmt.Fprintf(w, "- %s\r\n", formatChange(c))
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return n.deliver(ctx, to, msg)
+}
+
+func (n *smtpNotifier) SendPasswordReset(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	to, err := n.recipient(user)
+	if err != nil {
+		return err
+	}
+	link, err := resetLink(n.resetURL, token)
+	if err != nil {
+		return err
+	}
+	msg, err := n.message(to, resetSubject, func(w io.Writer) {
+		fmt.Fprintf(w, "A password reset was requested for %s.\r\n\r\n", user.Username)
+		fmt.Fprintf(w, "Set a new password with this link before %s:\r\n\r\n%s\r\n", formatExpiry(expiresAt), link)
+	})
+	if err != nil {
+		return err
+	}
+	return n.deliver(ctx, to, msg)
+}
+
+// deliver sends msg to to, within the configured timeout.
+func (n *smtpNotifier) deliver(ctx context.Context, to string, msg []byte) error {
+	if n.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.cfg.Timeout)
+		defer cancel()
+	}
+	return n.send(ctx, to, msg)
+}
+
+// recipient is the user's username if it is an e-mail address, otherwise
+// the username at RecipientDomain.
+func (n *smtpNotifier) recipient(user *entity.User) (string, error) {
+	addr := user.Username
+	if !strings.Contains(addr, "@") {
+		if n.cfg.RecipientDomain == "" {
+			return "", fmt.Errorf("user %s has no e-mail address", user.ID)
+		}
+		addr += "@" + n.cfg.RecipientDomain
+	}
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return "", fmt.Errorf("user %s: invalid e-mail address: %w", user.ID, err)
+	}
+	return parsed.Address, nil
+}
+
+// message builds a plain-text e-mail; writeBody writes its body.
+func (n *smtpNotifier) message(to, subject string, writeBody func(w io.Writer)) ([]byte, error) {
+	var body bytes.Buffer
+	qp := quotedprintable.NewWriter(&body)
+	writeBody(qp)
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+func (n *smtpNotifier) send(ctx context.Context, to string, msg []byte) error {
+	from, err := mail.ParseAddress(n.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
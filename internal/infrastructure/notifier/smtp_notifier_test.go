@@ -0,0 +1,152 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+)
+
+// smtpSession is what the fake server received.
+type smtpSession struct {
+	from, to string
+	data     string
+}
+
+// startFakeSMTP accepts one plaintext SMTP session without authentication.
+func startFakeSMTP(t *testing.T) (string, int, <-chan smtpSession) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	done := make(chan smtpSession, 1)
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		var s smtpSession
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimSpace(line)
+			switch upper := strings.ToUpper(cmd); {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 fake")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				s.to = strings.Trim(cmd[len("RCPT TO:"):], "<> ")
+				reply("250 OK")
+			case upper == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				s.data = data.String()
+				reply("250 queued")
+			case upper == "QUIT":
+				reply("221 bye")
+				done <- s
+				return
+			default:
+				reply("502 unsupported")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, done
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	host, port, done := startFakeSMTP(t)
+	n := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "CPNext <noreply@example.com>", RecipientDomain: "kkumail.com", Timeout: 5 * time.Second})
+
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "653040123-4"}
+	if err := n.NotifyCourseChanges(context.Background(), user, testChangeEvent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := <-done
+	if s.from != "noreply@example.com" || s.to != "653040123-4@kkumail.com" {
+		t.Errorf("unexpected envelope: from %q to %q", s.from, s.to)
+	}
+	headers, body, _ := strings.Cut(s.data, "\r\n\r\n")
+	if !strings.Contains(headers, "Subject: CP353004 2568/1: 2 changes") {
+		t.Errorf("unexpected headers: %s", headers)
+	}
+	decoded, _ := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if !strings.Contains(string(decoded), "- section 01 ROOM_CHANGED MON 13:00-15:00 (C): CP9127 -> SC2101") ||
+		!strings.Contains(string(decoded), "- section 01 SECTION_CLOSED: Closed") {
+		t.Errorf("unexpected body: %s", decoded)
+	}
+}
+
+func TestSMTPNotifier_Recipient(t *testing.T) {
+	withDomain := &smtpNotifier{cfg: SMTPConfig{RecipientDomain: "kkumail.com"}}
+	without := &smtpNotifier{}
+
+	if got, err := without.recipient(&entity.User{Username: "somchai@kku.ac.th"}); err != nil || got != "somchai@kku.ac.th" {
+		t.Errorf("expected the username as address, got %q, %v", got, err)
+	}
+	if got, err := withDomain.recipient(&entity.User{Username: "somchai"}); err != nil || got != "somchai@kkumail.com" {
+		t.Errorf("expected the recipient domain to be appended, got %q, %v", got, err)
+	}
+	if _, err := without.recipient(&entity.User{Username: "somchai"}); err == nil {
+		t.Error("expected an error for a username without a domain")
+	}
+	if _, err := withDomain.recipient(&entity.User{Username: "som chai\r\nBcc: x"}); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestSMTPPasswordResetNotifier_Send(t *testing.T) {
+	host, port, done := startFakeSMTP(t)
+	n := NewSMTPPasswordResetNotifier(SMTPConfig{Host: host, Port: port, From: "noreply@example.com", RecipientDomain: "kkumail.com", Timeout: 5 * time.Second},
+		"https://app.example.com/reset")
+
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "653040123-4"}
+	if err := n.SendPasswordReset(context.Background(), user, "tok123", time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := <-done
+	if s.to != "653040123-4@kkumail.com" {
+		t.Errorf("unexpected recipient %q", s.to)
+	}
+	headers, body, _ := strings.Cut(s.data, "\r\n\r\n")
+	if !strings.Contains(headers, "Subject: Reset your password") {
+		t.Errorf("unexpected headers: %s", headers)
+	}
+	decoded, _ := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if !strings.Contains(string(decoded), "https://app.example.com/reset?token=tok123") {
+		t.Errorf("expected the reset link in the body, got: %s", decoded)
+	}
+}
@@ -0,0 +1,135 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/signature"
+)
+
+type webhookNotifier struct {
+	url      string
+	secret   string
+	resetURL string
+	client   *http.Client
+}
+
+// NewWebhookNotifier returns a CourseChangeNotifier that POSTs each
+// notification as JSON to url, for a service that delivers them (push,
+// LINE, e-mail). When secret is set the body is signed with HMAC-SHA256.
+func NewWebhookNotifier(url, secret string, timeout time.Duration) repository.CourseChangeNotifier {
+	return &webhookNotifier{url: url, secret: secret, client: &http.Client{Timeout: timeout}}
+}
+
+// NewWebhookPasswordResetNotifier returns a PasswordResetNotifier that POSTs
+// each reset link as JSON to url, signed like NewWebhookNotifier's
+// notifications. The token is appended to resetURL as ?token=...; an empty
+// resetURL sends the bare token.
+func NewWebhookPasswordResetNotifier(url, secret string, timeout time.Duration, resetURL string) repository.PasswordResetNotifier {
+	return &webhookNotifier{url: url, secret: secret, resetURL: resetURL, client: &http.Client{Timeout: timeout}}
+}
+
+// webhookNotification is the JSON body of a notification.
+type webhookNotification struct {
+	UserID     string          `json:"user_id"`
+	Username   string          `json:"username"`
+	CourseCode string          `json:"course_code"`
+	Year       int             `json:"year"`
+	Semester   int             `json:"semester"`
+	Source     string          `json:"source,omitempty"`
+	DetectedAt time.Time       `json:"detected_at"`
+	Changes    []webhookChange `json:"changes"`
+}
+
+// webhookPasswordReset is the JSON body of a password reset. Type tells it
+// apart from course change notifications sent to the same URL.
+type webhookPasswordReset struct {
+	Type      string    `json:"type"` // always "password_reset"
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Link      string    `json:"link"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type webhookChange struct {
+	Kind      string `json:"kind"`
+	SectionID string `json:"section_id,omitempty"`
+	Section   string `json:"section,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+func (n *webhookNotifier) NotifyCourseChanges(ctx context.Context, user *entity.User, event *entity.CourseChangeEvent) error {
+	changes := make([]webhookChange, len(event.Changes))
+	for i, c := range event.Changes {
+		changes[i] = webhookChange{
+			Kind:      string(c.Kind),
+			SectionID: c.SectionID,
+			Section:   c.Section,
+			Detail:    c.Detail,
+			Old:       c.Old,
+			New:       c.New,
+		}
+	}
+	body, err := json.Marshal(webhookNotification{
+		UserID:     user.ID,
+		Username:   user.Username,
+		CourseCode: event.CourseCode,
+		Year:       event.Year,
+		Semester:   event.Semester,
+		Source:     event.Source,
+		DetectedAt: event.DetectedAt,
+		Changes:    changes,
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, body)
+}
+
+func (n *webhookNotifier) SendPasswordReset(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	link, err := resetLink(n.resetURL, token)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(webhookPasswordReset{
+		Type:      "password_reset",
+		UserID:    user.ID,
+		Username:  user.Username,
+		Link:      link,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, body)
+}
+
+// post sends body to the webhook, signed when there is a secret.
+func (n *webhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		req.Header.Set(signature.Header, signature.Sign(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/signature"
+)
+
+func testChangeEvent() *entity.CourseChangeEvent {
+	return &entity.CourseChangeEvent{
+		CourseCode: "CP353004", Year: 2568, Semester: 1, Source: "grpc",
+		DetectedAt: time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC),
+		Changes: []entity.CourseChange{
+			{Kind: entity.CourseChangeRoom, SectionID: "s1", Section: "01", Detail: "MON 13:00-15:00 (C)", Old: "CP9127", New: "SC2101"},
+			{Kind: entity.CourseChangeSectionClosed, SectionID: "s1", Section: "01", New: "Closed"},
+		},
+	}
+}
+
+func TestWebhookNotifier_SignsBody(t *testing.T) {
+	var (
+		body []byte
+		sig  string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		sig = r.Header.Get(signature.Header)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(srv.URL, "s3cret", 5*time.Second)
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "653040123-4"}
+	if err := n.NotifyCourseChanges(context.Background(), user, testChangeEvent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !signature.Verify("s3cret", body, sig) {
+		t.Errorf("expected a valid signature, got %q", sig)
+	}
+	var got webhookNotification
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if got.UserID != "u1" || got.Username != "653040123-4" || got.CourseCode != "CP353004" || len(got.Changes) != 2 || got.Changes[1].Kind != "SECTION_CLOSED" {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(signature.Header) != "" {
+			t.Error("expected no signature without a secret")
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(srv.URL, "", 5*time.Second)
+	if err := n.NotifyCourseChanges(context.Background(), &entity.User{}, testChangeEvent()); err == nil {
+		t.Error("expected an error for a 502 response")
+	}
+}
+
+func TestWebhookPasswordResetNotifier(t *testing.T) {
+	var (
+		body []byte
+		sig  string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		sig = r.Header.Get(signature.Header)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookPasswordResetNotifier(srv.URL, "s3cret", 5*time.Second, "https://app.example.com/reset")
+	user := &entity.User{BaseEntity: entity.BaseEntity{ID: "u1"}, Username: "653040123-4"}
+	expiresAt := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	if err := n.SendPasswordReset(context.Background(), user, "tok123", expiresAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !signature.Verify("s3cret", body, sig) {
+		t.Errorf("expected a valid signature, got %q", sig)
+	}
+	var got webhookPasswordReset
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if got.Type != "password_reset" || got.UserID != "u1" || got.Link != "https://app.example.com/reset?token=tok123" || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
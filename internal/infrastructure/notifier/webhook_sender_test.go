@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/signature"
+)
+
+func TestWebhookSender_SignsPayload(t *testing.T) {
+	var (
+		body    []byte
+		headers http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	hook := &entity.Webhook{ID: "h1", URL: srv.URL, Secret: "whsec_test"}
+	delivery := &entity.WebhookDelivery{ID: "d1", Event: "course.updated", Payload: []byte(`{"id":"e1","event":"course.updated"}`)}
+
+	status, err := NewWebhookSender(5*time.Second).Send(context.Background(), hook, delivery)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", status)
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("expected the payload as body, got %s", body)
+	}
+	if !signature.Verify("whsec_test", body, headers.Get(signature.Header)) {
+		t.Errorf("expected a valid signature, got %q", headers.Get(signature.Header))
+	}
+	if headers.Get("X-Webhook-Event") != "course.updated" || headers.Get("X-Webhook-Delivery") != "d1" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+	if headers.Get("Content-Type") != "application/json" {
+		t.Errorf("expected a JSON content type, got %q", headers.Get("Content-Type"))
+	}
+}
+
+func TestWebhookSender_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	hook := &entity.Webhook{URL: srv.URL, Secret: "whsec_test"}
+	status, err := NewWebhookSender(5*time.Second).Send(context.Background(), hook, &entity.WebhookDelivery{Payload: []byte(`{}`)})
+	if err == nil {
+		t.Fatal("expected an error for a 502 response")
+	}
+	if status != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", status)
+	}
+}
+
+func TestWebhookSender_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.Close()
+
+	hook := &entity.Webhook{URL: srv.URL}
+	status, err := NewWebhookSender(time.Second).Send(context.Background(), hook, &entity.WebhookDelivery{Payload: []byte(`{}`)})
+	if err == nil || status != 0 {
+		t.Errorf("expected an error and no status, got %d, %v", status, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package memory provides in-process repository implementations for
+// single-instance deployments and tests. State is lost on restart and is
+// not shared between replicas.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+)
+
+// pruneInterval is how often expired counters are swept from the map.
+const pruneInterval = time.Minute
+
+type loginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[string]*entity.LoginAttempt
+	lastPrune time.Time
+}
+
+// NewLoginAttemptRepository creates an in-memory LoginAttemptRepository.
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &loginAttemptRepository{attempts: map[string]*entity.LoginAttempt{}}
+}
+
+func (r *loginAttemptRepository) Get(_ context.Context, key string) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a := r.live(key, time.Now())
+	if a == nil {
+		return nil, nil
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(_ context.Context, key string, now time.Time, window time.Duration, expiresAt time.Time) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(now)
+
+	a := r.live(key, now)
+	if a == nil {
+		a = &entity.LoginAttempt{Key: key}
+		r.attempts[key] = a
+	}
+	if a.WindowStart.Before(now.Add(-window)) {
+		a.Failures = 0
+		a.WindowStart = now
+	}
+	a.Failures++
+	a.ExpiresAt = expiresAt
+
+	cp := *a
+	return &cp, nil
+}
+
+func (r *loginAttemptRepository) Lock(_ context.Context, key string, until, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a := r.live(key, time.Now())
+	if a == nil {
+		a = &entity.LoginAttempt{Key: key}
+		r.attempts[key] = a
+	}
+	a.Lockouts++
+	a.Failures = 0
+	a.WindowStart = time.Time{}
+	a.LockedUntil = until
+	a.ExpiresAt = expiresAt
+	return nil
+}
+
+func (r *loginAttemptRepository) Reset(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, key)
+	return nil
+}
+
+// live returns the unexpired counter for key. Callers hold r.mu.
+func (r *loginAttemptRepository) live(key string, now time.Time) *entity.LoginAttempt {
+	a := r.attempts[key]
+	if a == nil || !now.Before(a.ExpiresAt) {
+		return nil
+	}
+	return a
+}
+
+// prune drops expired counters so the map does not grow without bound
+// under a spray of random usernames. Callers hold r.mu.
+func (r *loginAttemptRepository) prune(now time.Time) {
+	if now.Sub(r.lastPrune) < pruneInterval {
+		return
+	}
+	r.lastPrune = now
+	for k, a := range r.attempts {
+		if !now.Before(a.ExpiresAt) {
+			delete(r.attempts, k)
+		}
+	}
+}
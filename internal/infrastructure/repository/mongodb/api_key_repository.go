@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const apiKeyCollection = "api_keys"
+
+// apiKeyModel is the MongoDB-specific representation of an API key.
+type apiKeyModel struct {
+	BaseModel  `bson:",inline"`
+	Name       string     `bson:"name"`
+	Prefix     string     `bson:"prefix"`
+	KeyHash    string     `bson:"key_hash"`
+	Scopes     []string   `bson:"scopes"`
+	CreatedBy  string     `bson:"created_by"`
+	ExpiresAt  *time.Time `bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *apiKeyModel) toEntity() *entity.APIKey {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+
+	return &entity.APIKey{
+		BaseEntity: entity.BaseEntity{
+			ID:        id,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		Name:       m.Name,
+		Prefix:     m.Prefix,
+		KeyHash:    m.KeyHash,
+		Scopes:     m.Scopes,
+		CreatedBy:  m.CreatedBy,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		RevokedAt:  m.RevokedAt,
+	}
+}
+
+// toAPIKeyModel converts a domain entity to a MongoDB model.
+func toAPIKeyModel(e *entity.APIKey) *apiKeyModel {
+	m := &apiKeyModel{
+		Name:       e.Name,
+		Prefix:     e.Prefix,
+		KeyHash:    e.KeyHash,
+		Scopes:     e.Scopes,
+		CreatedBy:  e.CreatedBy,
+		ExpiresAt:  e.ExpiresAt,
+		LastUsedAt: e.LastUsedAt,
+		RevokedAt:  e.RevokedAt,
+	}
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+	if e.ID != "" {
+		oid, err := bson.ObjectIDFromHex(e.ID)
+		if err == nil {
+			m.ID = &oid
+		}
+	}
+	return m
+}
+
+type apiKeyRepository struct {
+	db *mongo.Database
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository.
+func NewAPIKeyRepository(db *mongo.Database) repository.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = time.Now()
+
+	result, err := r.db.Collection(apiKeyCollection).InsertOne(ctx, toAPIKeyModel(key))
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		key.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	var model apiKeyModel
+	err := r.db.Collection(apiKeyCollection).FindOne(ctx, bson.M{"key_hash": hash}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *apiKeyRepository) GetAll(ctx context.Context) ([]*entity.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.db.Collection(apiKeyCollection).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*apiKeyModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	keys := make([]*entity.APIKey, len(models))
+	for i, m := range models {
+		keys[i] = m.toEntity()
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	filter := bson.M{"_id": oid, "revoked_at": bson.M{"$exists": false}}
+	result, err := r.db.Collection(apiKeyCollection).UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": now, "updated_at": now}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+	_, err = r.db.Collection(apiKeyCollection).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
@@ -0,0 +1,150 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const auditCollection = "audit_log"
+
+// auditEntryModel is the MongoDB-specific representation of an audit entry.
+type auditEntryModel struct {
+	ID         *bson.ObjectID         `bson:"_id,omitempty"`
+	ActorID    string                 `bson:"actor_id"`
+	ActorName  string                 `bson:"actor_name"`
+	Action     string                 `bson:"action"`
+	TargetType string                 `bson:"target_type"`
+	TargetID   string                 `bson:"target_id"`
+	Before     map[string]interface{} `bson:"before,omitempty"`
+	After      map[string]interface{} `bson:"after,omitempty"`
+	IP         string                 `bson:"ip"`
+	CreatedAt  time.Time              `bson:"created_at"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *auditEntryModel) toEntity() *entity.AuditEntry {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	return &entity.AuditEntry{
+		ID:         id,
+		ActorID:    m.ActorID,
+		ActorName:  m.ActorName,
+		Action:     m.Action,
+		TargetType: m.TargetType,
+		TargetID:   m.TargetID,
+		Before:     m.Before,
+		After:      m.After,
+		IP:         m.IP,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+type auditRepository struct {
+	db *mongo.Database
+}
+
+// NewAuditRepository creates a new instance of AuditRepository.
+func NewAuditRepository(db *mongo.Database) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	entry.CreatedAt = time.Now()
+
+	result, err := r.db.Collection(auditCollection).InsertOne(ctx, &auditEntryModel{
+		ActorID:    entry.ActorID,
+		ActorName:  entry.ActorName,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     entry.Before,
+		After:      entry.After,
+		IP:         entry.IP,
+		CreatedAt:  entry.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write back the generated ID to the entity.
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		entry.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *auditRepository) GetPaginated(ctx context.Context, filter repository.AuditFilter, page, limit int) ([]*entity.AuditEntry, int64, error) {
+	col := r.db.Collection(auditCollection)
+	query := auditQuery(filter)
+
+	total, err := col.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetSkip(int64((page - 1) * limit))
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := col.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*entity.AuditEntry
+	for cursor.Next(ctx) {
+		// Snapshots are free-form; decode nested documents as plain maps
+		// rather than bson.D so they serialise back to the same JSON.
+		dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(cursor.Current)))
+		dec.DefaultDocumentMap()
+
+		var model auditEntryModel
+		if err := dec.Decode(&model); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, model.toEntity())
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// auditQuery builds the MongoDB filter for an AuditFilter.
+func auditQuery(f repository.AuditFilter) bson.M {
+	q := bson.M{}
+	for field, val := range map[string]string{
+		"actor_id":    f.ActorID,
+		"action":      f.Action,
+		"target_type": f.TargetType,
+		"target_id":   f.TargetID,
+	} {
+		if val != "" {
+			q[field] = val
+		}
+	}
+
+	created := bson.M{}
+	if !f.From.IsZero() {
+		created["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		created["$lt"] = f.To
+	}
+	if len(created) > 0 {
+		q["created_at"] = created
+	}
+	return q
+}
@@ -0,0 +1,154 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const courseChangeCollection = "course_changes"
+
+// courseChangeEventModel is the MongoDB-specific representation of a course change event.
+type courseChangeEventModel struct {
+	ID         *bson.ObjectID      `bson:"_id,omitempty"`
+	CourseCode string              `bson:"course_code"`
+	Year       int                 `bson:"year"`
+	Semester   int                 `bson:"semester"`
+	Source     string              `bson:"source,omitempty"`
+	Changes    []courseChangeModel `bson:"changes"`
+	DetectedAt time.Time           `bson:"detected_at"`
+}
+
+type courseChangeModel struct {
+	Kind      string `bson:"kind"`
+	SectionID string `bson:"section_id,omitempty"`
+	Section   string `bson:"section,omitempty"`
+	Detail    string `bson:"detail,omitempty"`
+	Old       string `bson:"old,omitempty"`
+	New       string `bson:"new,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *courseChangeEventModel) toEntity() *entity.CourseChangeEvent {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	changes := make([]entity.CourseChange, len(m.Changes))
+	for i, c := range m.Changes {
+		changes[i] = entity.CourseChange{
+			Kind:      entity.CourseChangeKind(c.Kind),
+			SectionID: c.SectionID,
+			Section:   c.Section,
+			Detail:    c.Detail,
+			Old:       c.Old,
+			New:       c.New,
+		}
+	}
+	return &entity.CourseChangeEvent{
+		ID:         id,
+		CourseCode: m.CourseCode,
+		Year:       m.Year,
+		Semester:   m.Semester,
+		Source:     m.Source,
+		Changes:    changes,
+		DetectedAt: m.DetectedAt,
+	}
+}
+
+// toCourseChangeEventModel converts a domain entity to a MongoDB model. An
+// event without a valid ID gets one when inserted.
+func toCourseChangeEventModel(e *entity.CourseChangeEvent) *courseChangeEventModel {
+	changes := make([]courseChangeModel, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = courseChangeModel{
+			Kind:      string(c.Kind),
+			SectionID: c.SectionID,
+			Section:   c.Section,
+			Detail:    c.Detail,
+			Old:       c.Old,
+			New:       c.New,
+		}
+	}
+	m := &courseChangeEventModel{
+		CourseCode: e.CourseCode,
+		Year:       e.Year,
+		Semester:   e.Semester,
+		Source:     e.Source,
+		Changes:    changes,
+		DetectedAt: e.DetectedAt,
+	}
+	if oid, err := bson.ObjectIDFromHex(e.ID); err == nil {
+		m.ID = &oid
+	}
+	return m
+}
+
+type courseChangeRepository struct {
+	db *mongo.Database
+}
+
+// NewCourseChangeRepository creates a new instance of CourseChangeRepository.
+func NewCourseChangeRepository(db *mongo.Database) repository.CourseChangeRepository {
+	return &courseChangeRepository{db: db}
+}
+
+func (r *courseChangeRepository) Create(ctx context.Context, event *entity.CourseChangeEvent) error {
+	event.DetectedAt = time.Now()
+
+	model := toCourseChangeEventModel(event)
+	model.ID = nil
+	result, err := r.db.Collection(courseChangeCollection).InsertOne(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	// Write back the generated ID to the entity.
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		event.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *courseChangeRepository) GetPaginated(ctx context.Context, filter repository.CourseChangeFilter, page, limit int) ([]*entity.CourseChangeEvent, int64, error) {
+	col := r.db.Collection(courseChangeCollection)
+	query := bson.M{"course_code": filter.CourseCode}
+	if filter.Year != 0 {
+		query["year"] = filter.Year
+	}
+	if filter.Semester != 0 {
+		query["semester"] = filter.Semester
+	}
+
+	total, err := col.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "detected_at", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetSkip(int64((page - 1) * limit))
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := col.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*courseChangeEventModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, 0, err
+	}
+	events := make([]*entity.CourseChangeEvent, len(models))
+	for i, m := range models {
+		events[i] = m.toEntity()
+	}
+	return events, total, nil
+}
@@ -1,12 +1,16 @@
 package mongodb
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/pkg/thaicalendar"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -17,16 +21,19 @@ const courseCollection = "courses"
 // courseModel is the MongoDB-specific representation of a course (bson tags live here).
 type courseModel struct {
 	BaseModel    `bson:",inline"`
-	Code         string         `bson:"code"`
-	NameEN       string         `bson:"name_en"`
-	NameTH       string         `bson:"name_th"`
-	Faculty      string         `bson:"faculty"`
-	Department   string         `bson:"department,omitempty"`
-	Credits      string         `bson:"credits"`
-	Prerequisite string         `bson:"prerequisite,omitempty"`
-	Semester     int            `bson:"semester"`
-	Year         int            `bson:"year"`
-	Sections     []sectionModel `bson:"sections"`
+	Code         string            `bson:"code"`
+	NameEN       string            `bson:"name_en"`
+	NameTH       string            `bson:"name_th"`
+	Faculty      string            `bson:"faculty"`
+	Department   string            `bson:"department,omitempty"`
+	Credits      string            `bson:"credits"`
+	Prerequisite string            `bson:"prerequisite,omitempty"`
+	Semester     int               `bson:"semester"`
+	Year         int               `bson:"year"`
+	Sections     []sectionModel    `bson:"sections"`
+	Source       string            `bson:"source,omitempty"`
+	FilledFrom   map[string]string `bson:"filled_from,omitempty"`
+	Version      int               `bson:"version"` // 0 on documents written before versioning
 }
 
 type sectionModel struct {
@@ -35,10 +42,10 @@ type sectionModel struct {
 	Schedules    []scheduleModel `bson:"schedules"`
 	Seats        int             `bson:"seats"`
 	Instructor   []string        `bson:"instructor"`
-	ExamStart    string          `bson:"exam_start,omitempty"`
-	ExamEnd      string          `bson:"exam_end,omitempty"`
-	MidtermStart string          `bson:"midterm_start,omitempty"`
-	MidtermEnd   string          `bson:"midterm_end,omitempty"`
+	ExamStart    *time.Time      `bson:"exam_start,omitempty"`
+	ExamEnd      *time.Time      `bson:"exam_end,omitempty"`
+	MidtermStart *time.Time      `bson:"midterm_start,omitempty"`
+	MidtermEnd   *time.Time      `bson:"midterm_end,omitempty"`
 	Note         string          `bson:"note,omitempty"`
 	ReservedFor  []string        `bson:"reserved_for,omitempty"`
 	Campus       string          `bson:"campus,omitempty"`
@@ -47,10 +54,13 @@ type sectionModel struct {
 
 type scheduleModel struct {
 	Day       string `bson:"day"`
-	StartTime string `bson:"start_time"`
+	Weekday   string `bson:"weekday,omitempty"`
+	StartTime string `bson:"start_time"` // "HH:MM", Asia/Bangkok wall clock
 	EndTime   string `bson:"end_time"`
+	TBA       bool   `bson:"tba,omitempty"`
 	Room      string `bson:"room"`
 	Type      string `bson:"type"`
+	Kind      string `bson:"kind,omitempty"`
 }
 
 // compositeFilter builds the composite key filter for lookups.
@@ -63,19 +73,34 @@ func compositeFilter(code string, year, semester int) bson.M {
 	}
 }
 
+// toTimePtr stores zero times as absent fields.
+func toTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// fromTimePtr restores a stored instant in Asia/Bangkok (Mongo returns UTC).
+func fromTimePtr(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.In(thaicalendar.Location)
+}
+
 // toEntity converts a MongoDB model to a domain entity.
 func (m *courseModel) toEntity() *entity.Course {
 	sections := make([]entity.Section, len(m.Sections))
 	for i, s := range m.Sections {
 		schedules := make([]entity.Schedule, len(s.Schedules))
 		for j, sc := range s.Schedules {
-			schedules[j] = entity.Schedule{
-				Day:       sc.Day,
-				StartTime: sc.StartTime,
-				EndTime:   sc.EndTime,
-				Room:      sc.Room,
-				Type:      sc.Type,
-			}
+			start, _ := entity.ParseTimeOfDay(sc.StartTime)
+			end, _ := entity.ParseTimeOfDay(sc.EndTime)
+			// Re-normalise from the raw values so documents written before the
+			// canonical fields existed (or before an alias was added) still map.
+			schedules[j] = entity.NewSchedule(sc.Day, start, end, sc.Room, sc.Type)
+			schedules[j].TBA = sc.TBA
 		}
 		sections[i] = entity.Section{
 			ID:           s.ID.Hex(),
@@ -83,10 +108,10 @@ func (m *courseModel) toEntity() *entity.Course {
 			Schedules:    schedules,
 			Seats:        s.Seats,
 			Instructor:   s.Instructor,
-			ExamStart:    s.ExamStart,
-			ExamEnd:      s.ExamEnd,
-			MidtermStart: s.MidtermStart,
-			MidtermEnd:   s.MidtermEnd,
+			ExamStart:    fromTimePtr(s.ExamStart),
+			ExamEnd:      fromTimePtr(s.ExamEnd),
+			MidtermStart: fromTimePtr(s.MidtermStart),
+			MidtermEnd:   fromTimePtr(s.MidtermEnd),
 			Note:         s.Note,
 			ReservedFor:  s.ReservedFor,
 			Campus:       s.Campus,
@@ -116,6 +141,9 @@ func (m *courseModel) toEntity() *entity.Course {
 		Semester:     m.Semester,
 		Year:         m.Year,
 		Sections:     sections,
+		Source:       m.Source,
+		FilledFrom:   m.FilledFrom,
+		Version:      m.Version,
 	}
 }
 
@@ -127,10 +155,13 @@ func toCourseModel(e *entity.Course) *courseModel {
 		for j, sc := range s.Schedules {
 			schedules[j] = scheduleModel{
 				Day:       sc.Day,
-				StartTime: sc.StartTime,
-				EndTime:   sc.EndTime,
+				Weekday:   string(sc.Weekday),
+				StartTime: sc.StartTime.String(),
+				EndTime:   sc.EndTime.String(),
+				TBA:       sc.TBA,
 				Room:      sc.Room,
 				Type:      sc.Type,
+				Kind:      string(sc.Kind),
 			}
 		}
 
@@ -154,10 +185,10 @@ func toCourseModel(e *entity.Course) *courseModel {
 			Schedules:    schedules,
 			Seats:        s.Seats,
 			Instructor:   s.Instructor,
-			ExamStart:    s.ExamStart,
-			ExamEnd:      s.ExamEnd,
-			MidtermStart: s.MidtermStart,
-			MidtermEnd:   s.MidtermEnd,
+			ExamStart:    toTimePtr(s.ExamStart),
+			ExamEnd:      toTimePtr(s.ExamEnd),
+			MidtermStart: toTimePtr(s.MidtermStart),
+			MidtermEnd:   toTimePtr(s.MidtermEnd),
 			Note:         s.Note,
 			ReservedFor:  s.ReservedFor,
 			Campus:       s.Campus,
@@ -176,6 +207,9 @@ func toCourseModel(e *entity.Course) *courseModel {
 		Semester:     e.Semester,
 		Year:         e.Year,
 		Sections:     sections,
+		Source:       e.Source,
+		FilledFrom:   e.FilledFrom,
+		Version:      e.Version,
 	}
 	m.CreatedAt = e.CreatedAt
 	m.UpdatedAt = e.UpdatedAt
@@ -201,6 +235,7 @@ func NewCourseRepository(db *mongo.Database) repository.CourseRepository {
 func (r *courseRepository) Create(ctx context.Context, course *entity.Course) error {
 	course.CreatedAt = time.Now()
 	course.UpdatedAt = time.Now()
+	course.Version = 1
 
 	model := toCourseModel(course)
 	result, err := r.db.Collection(courseCollection).InsertOne(ctx, model)
@@ -289,11 +324,52 @@ func (r *courseRepository) GetByKey(ctx context.Context, code string, year, seme
 	return model.toEntity(), nil
 }
 
+func (r *courseRepository) GetByID(ctx context.Context, id string) (*entity.Course, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var model courseModel
+	err = r.db.Collection(courseCollection).FindOne(ctx, bson.M{"_id": oid, "deleted_at": bson.M{"$exists": false}}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+// Update replaces the course and archives the replaced document in the
+// course_versions collection, valid from its last update until now. A
+// course whose content is unchanged only has updated_at set: no version is
+// archived and its version stays the same.
 func (r *courseRepository) Update(ctx context.Context, course *entity.Course) error {
 	course.UpdatedAt = time.Now()
 	model := toCourseModel(course)
 
 	filter := compositeFilter(course.Code, course.Year, course.Semester)
+	var current courseModel
+	if err := r.db.Collection(courseCollection).FindOne(ctx, filter).Decode(&current); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errors.New("course not found")
+		}
+		return err
+	}
+	if sameContent(&current, model) {
+		// Matches only if no one replaced the course since it was read.
+		touch := bson.M{"version": current.Version}
+		maps.Copy(touch, filter)
+		result, err := r.db.Collection(courseCollection).UpdateOne(ctx, touch, bson.M{"$set": bson.M{"updated_at": model.UpdatedAt}})
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount > 0 {
+			course.Version = current.Version
+			return nil
+		}
+	}
+
 	update := bson.M{
 		"$set": bson.M{
 			"name_en":      model.NameEN,
@@ -305,20 +381,60 @@ func (r *courseRepository) Update(ctx context.Context, course *entity.Course) er
 			"semester":     model.Semester,
 			"year":         model.Year,
 			"sections":     model.Sections,
+			"source":       model.Source,
+			"filled_from":  model.FilledFrom,
 			"updated_at":   model.UpdatedAt,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	result, err := r.db.Collection(courseCollection).UpdateOne(ctx, filter, update)
+	// The update and the version bump are one atomic write; the document it
+	// returns is the version being replaced.
+	var old courseModel
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	err := r.db.Collection(courseCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&old)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errors.New("course not found")
+		}
 		return err
 	}
-	if result.MatchedCount == 0 {
-		return errors.New("course not found")
+	course.Version = old.Version + 1
+
+	if _, err := r.db.Collection(courseVersionCollection).InsertOne(ctx, &courseVersionModel{
+		CourseID:  *old.ID,
+		Version:   old.Version,
+		ValidFrom: old.UpdatedAt,
+		ValidTo:   model.UpdatedAt,
+		Course:    old,
+	}); err != nil {
+		return fmt.Errorf("archive course version %d: %w", old.Version, err)
 	}
 	return nil
 }
 
+// sameContent reports whether two course documents hold the same course,
+// ignoring their identity, timestamps and version.
+func sameContent(a, b *courseModel) bool {
+	if !maps.Equal(a.FilledFrom, b.FilledFrom) {
+		return false
+	}
+	// Maps encode in random order, so FilledFrom is compared above.
+	ca, cb := *a, *b
+	ca.BaseModel, cb.BaseModel = BaseModel{}, BaseModel{}
+	ca.Version, cb.Version = 0, 0
+	ca.FilledFrom, cb.FilledFrom = nil, nil
+	da, err := bson.Marshal(&ca)
+	if err != nil {
+		return false
+	}
+	db, err := bson.Marshal(&cb)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(da, db)
+}
+
 func (r *courseRepository) SoftDelete(ctx context.Context, code string, year, semester int) error {
 	now := time.Now()
 	filter := compositeFilter(code, year, semester)
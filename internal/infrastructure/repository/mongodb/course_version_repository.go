@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const courseVersionCollection = "course_versions"
+
+// courseVersionModel is a course document archived by courseRepository.Update.
+type courseVersionModel struct {
+	ID        *bson.ObjectID `bson:"_id,omitempty"`
+	CourseID  bson.ObjectID  `bson:"course_id"`
+	Version   int            `bson:"version"`
+	ValidFrom time.Time      `bson:"valid_from"`
+	ValidTo   time.Time      `bson:"valid_to"`
+	Course    courseModel    `bson:"course"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *courseVersionModel) toEntity() *entity.CourseVersion {
+	return &entity.CourseVersion{
+		CourseID:  m.CourseID.Hex(),
+		Version:   m.Version,
+		ValidFrom: m.ValidFrom,
+		ValidTo:   m.ValidTo,
+		Course:    m.Course.toEntity(),
+	}
+}
+
+type courseVersionRepository struct {
+	db *mongo.Database
+}
+
+// NewCourseVersionRepository creates a new instance of CourseVersionRepository.
+func NewCourseVersionRepository(db *mongo.Database) repository.CourseVersionRepository {
+	return &courseVersionRepository{db: db}
+}
+
+func (r *courseVersionRepository) GetVersions(ctx context.Context, courseID string, page, limit int) ([]*entity.CourseVersion, int64, error) {
+	oid, err := bson.ObjectIDFromHex(courseID)
+	if err != nil {
+		return nil, 0, err
+	}
+	col := r.db.Collection(courseVersionCollection)
+	query := bson.M{"course_id": oid}
+
+	total, err := col.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	if limit > 0 {
+		opts.SetSkip(int64((page - 1) * limit))
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := col.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*courseVersionModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, 0, err
+	}
+	versions := make([]*entity.CourseVersion, len(models))
+	for i, m := range models {
+		versions[i] = m.toEntity()
+	}
+	return versions, total, nil
+}
+
+// GetAsOf finds the course document that existed at asOf, including one
+// deleted since, then the oldest version still valid at asOf. Versions are
+// contiguous, so when every version had been replaced by asOf the document
+// itself is what was shown.
+func (r *courseVersionRepository) GetAsOf(ctx context.Context, code string, year, semester int, asOf time.Time) (*entity.Course, error) {
+	var current courseModel
+	err := r.db.Collection(courseCollection).FindOne(ctx,
+		bson.M{"code": code, "year": year, "semester": semester, "created_at": bson.M{"$lte": asOf}},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	).Decode(&current)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if current.DeletedAt != nil && !current.DeletedAt.After(asOf) {
+		return nil, nil
+	}
+
+	var version courseVersionModel
+	err = r.db.Collection(courseVersionCollection).FindOne(ctx,
+		bson.M{"course_id": current.ID, "valid_to": bson.M{"$gt": asOf}},
+		options.FindOne().SetSort(bson.D{{Key: "version", Value: 1}}),
+	).Decode(&version)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return current.toEntity(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return version.Course.toEntity(), nil
+}
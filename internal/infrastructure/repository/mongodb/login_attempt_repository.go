@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const loginAttemptCollection = "login_attempts"
+
+// loginAttemptModel is the MongoDB-specific representation of a failed-login counter.
+type loginAttemptModel struct {
+	Key         string    `bson:"_id"`
+	Failures    int       `bson:"failures"`
+	WindowStart time.Time `bson:"window_start,omitempty"`
+	Lockouts    int       `bson:"lockouts"`
+	LockedUntil time.Time `bson:"locked_until,omitempty"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *loginAttemptModel) toEntity() *entity.LoginAttempt {
+	return &entity.LoginAttempt{
+		Key:         m.Key,
+		Failures:    m.Failures,
+		WindowStart: m.WindowStart,
+		Lockouts:    m.Lockouts,
+		LockedUntil: m.LockedUntil,
+		ExpiresAt:   m.ExpiresAt,
+	}
+}
+
+type loginAttemptRepository struct {
+	db *mongo.Database
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository.
+// Expired counters are removed by the TTL index on expires_at.
+func NewLoginAttemptRepository(db *mongo.Database) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	// The TTL monitor runs about once a minute, so filter stale records too.
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var model loginAttemptModel
+	err := r.db.Collection(loginAttemptCollection).FindOne(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration, expiresAt time.Time) (*entity.LoginAttempt, error) {
+	// A pipeline update lets the window reset and the increment happen in
+	// one atomic operation. Within a single $set stage every expression sees
+	// the document as it was before the update.
+	// Records past expires_at may linger until the TTL monitor removes them;
+	// treat them as absent.
+	expired := bson.M{"$lte": bson.A{bson.M{"$ifNull": bson.A{"$expires_at", nil}}, now}}
+	stale := bson.M{"$or": bson.A{expired, bson.M{"$lt": bson.A{"$window_start", now.Add(-window)}}}}
+	pipeline := bson.A{
+		bson.M{"$set": bson.M{
+			"failures":     bson.M{"$cond": bson.A{stale, 1, bson.M{"$add": bson.A{"$failures", 1}}}},
+			"window_start": bson.M{"$cond": bson.A{stale, now, "$window_start"}},
+			"lockouts":     bson.M{"$cond": bson.A{expired, 0, "$lockouts"}},
+			"locked_until": bson.M{"$cond": bson.A{expired, "$$REMOVE", "$locked_until"}},
+			"expires_at":   expiresAt,
+		}},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var model loginAttemptModel
+	err := r.db.Collection(loginAttemptCollection).FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&model)
+	if err != nil {
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until, expiresAt time.Time) error {
+	update := bson.M{
+		"$set":   bson.M{"locked_until": until, "failures": 0, "expires_at": expiresAt},
+		"$unset": bson.M{"window_start": ""},
+		"$inc":   bson.M{"lockouts": 1},
+	}
+	_, err := r.db.Collection(loginAttemptCollection).UpdateOne(ctx, bson.M{"_id": key}, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	_, err := r.db.Collection(loginAttemptCollection).DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,308 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const migrationCollection = "schema_migrations"
+
+// migration is a one-way schema change applied at most once per database.
+type migration struct {
+	ID string
+	Up func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrationModel records an applied migration.
+type migrationModel struct {
+	ID        string    `bson:"_id"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// migrations lists every schema migration in the order it must run.
+// Append only — never reorder or rename an entry once released.
+var migrations = []migration{
+	{ID: "0001_section_exam_dates_to_datetime", Up: migrateSectionExamDates},
+	{ID: "0002_session_indexes", Up: createSessionIndexes},
+	{ID: "0003_login_attempt_ttl", Up: createLoginAttemptIndexes},
+	{ID: "0004_api_key_hash_index", Up: createAPIKeyIndexes},
+	{ID: "0005_oidc_indexes", Up: createOIDCIndexes},
+	{ID: "0006_audit_log_indexes", Up: createAuditIndexes},
+	{ID: "0007_password_reset_indexes", Up: createPasswordResetIndexes},
+	{ID: "0008_course_change_indexes", Up: createCourseChangeIndexes},
+	{ID: "0009_course_version_indexes", Up: createCourseVersionIndexes},
+	{ID: "0010_watch_indexes", Up: createWatchIndexes},
+	{ID: "0011_webhook_delivery_indexes", Up: createWebhookDeliveryIndexes},
+	{ID: "0012_outbox_indexes", Up: createOutboxIndexes},
+	{ID: "0013_unmapped_value_unique", Up: dedupeUnmappedValues},
+	{ID: "0014_watch_notification_indexes", Up: createWatchNotificationIndexes},
+}
+
+// RunMigrations applies every pending migration in order and records it in
+// the schema_migrations collection.
+func RunMigrations(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(migrationCollection)
+
+	for _, m := range migrations {
+		err := col.FindOne(ctx, bson.M{"_id": m.ID}).Err()
+		if err == nil {
+			continue // already applied
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("migration %s: %w", m.ID, err)
+		}
+
+		log.Printf("[migration] applying %s", m.ID)
+		if err := m.Up(ctx, db); err != nil {
+			return fmt.Errorf("migration %s: %w", m.ID, err)
+		}
+
+		if _, err := col.InsertOne(ctx, migrationModel{ID: m.ID, AppliedAt: time.Now()}); err != nil {
+			return fmt.Errorf("migration %s: record: %w", m.ID, err)
+		}
+		log.Printf("[migration] applied %s", m.ID)
+	}
+	return nil
+}
+
+// examDateFields are the section fields that used to hold "YYYY-MM-DD HH:MM:SS" strings.
+var examDateFields = []string{"exam_start", "exam_end", "midterm_start", "midterm_end"}
+
+// migrateSectionExamDates converts legacy string exam/midterm timestamps
+// (Asia/Bangkok wall clock, no zone) inside course sections into BSON dates.
+// Values that cannot be parsed become null rather than staying strings.
+func migrateSectionExamDates(ctx context.Context, db *mongo.Database) error {
+	var anyString bson.A
+	converted := bson.M{}
+	for _, f := range examDateFields {
+		anyString = append(anyString, bson.M{"sections." + f: bson.M{"$type": "string"}})
+		converted[f] = bson.M{
+			"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$type": "$$s." + f}, "string"}},
+				bson.M{"$dateFromString": bson.M{
+					"dateString": "$$s." + f,
+					"format":     "%Y-%m-%d %H:%M:%S",
+					"timezone":   "Asia/Bangkok",
+					"onError":    nil,
+					"onNull":     nil,
+				}},
+				"$$s." + f,
+			},
+		}
+	}
+
+	pipeline := bson.A{
+		bson.M{"$set": bson.M{
+			"sections": bson.M{"$map": bson.M{
+				"input": "$sections",
+				"as":    "s",
+				"in":    bson.M{"$mergeObjects": bson.A{"$$s", converted}},
+			}},
+		}},
+	}
+
+	result, err := db.Collection(courseCollection).UpdateMany(ctx, bson.M{"$or": anyString}, pipeline)
+	if err != nil {
+		return err
+	}
+	log.Printf("[migration] converted exam dates in %d course documents", result.ModifiedCount)
+	return nil
+}
+
+// createSessionIndexes indexes refresh-token lookups and lets MongoDB expire
+// sessions a day after their refresh token does.
+func createSessionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(sessionCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}},
+		{Keys: bson.D{{Key: "previous_token_hash", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60)},
+	})
+	return err
+}
+
+// createLoginAttemptIndexes lets MongoDB drop failed-login counters once
+// they expire.
+func createLoginAttemptIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(loginAttemptCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+// createAPIKeyIndexes indexes API key lookups by hash.
+func createAPIKeyIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(apiKeyCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// createOIDCIndexes expires pending OIDC logins and keeps each external
+// identity linked to at most one user.
+func createOIDCIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(oidcStateCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(userCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "external_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetSparse(true),
+	})
+	return err
+}
+
+// createAuditIndexes supports the audit log filters, each sorted newest first.
+func createAuditIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(auditCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// createPasswordResetIndexes expires reset tokens and supports revoking a
+// user's outstanding tokens.
+func createPasswordResetIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(passwordResetCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+	return err
+}
+
+// createCourseChangeIndexes supports listing a course's changes, newest
+// first, for one term or all of them.
+func createCourseChangeIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(courseChangeCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "course_code", Value: 1}, {Key: "detected_at", Value: -1}}},
+		{Keys: bson.D{{Key: "course_code", Value: 1}, {Key: "year", Value: 1}, {Key: "semester", Value: 1}, {Key: "detected_at", Value: -1}}},
+	})
+	return err
+}
+
+// createCourseVersionIndexes keeps version numbers unique per course and
+// serves point-in-time lookups, which search by course and valid_to.
+func createCourseVersionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(courseVersionCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "course_id", Value: 1}, {Key: "version", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "course_id", Value: 1}, {Key: "valid_to", Value: 1}}},
+	})
+	return err
+}
+
+// createWatchIndexes keeps a user from watching the same course or section
+// twice and supports matching a refreshed course against its watches.
+func createWatchIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(watchCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "course_id", Value: 1}, {Key: "section_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "course_id", Value: 1}}},
+	})
+	return err
+}
+
+// createWebhookDeliveryIndexes serves the dispatcher's search for due
+// deliveries and the delivery log, overall or per webhook, newest first.
+func createWebhookDeliveryIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(webhookDeliveryCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(webhookCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "events", Value: 1}},
+	})
+	return err
+}
+
+// createOutboxIndexes serves the relay's search for due events, oldest
+// first, and lets MongoDB drop events a week after they are published. The
+// TTL index skips unpublished events, which have no published_at. Creating
+// the indexes also creates the collection, which transactions on MongoDB
+// before 4.4 cannot do.
+func createOutboxIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(outboxCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "published_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(7 * 24 * 60 * 60)},
+	})
+	return err
+}
+
+// dedupeUnmappedValues merges unmapped values recorded more than once for the
+// same (field, value) by concurrent upserts, switches every value from a hit
+// count to the distinct courses seen, and adds the unique index that keeps
+// (field, value) pairs from being duplicated again. The courses behind old
+// hit counts are unknown, so each value restarts from its example course.
+func dedupeUnmappedValues(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(unmappedValueCollection)
+	cursor, err := col.Aggregate(ctx, bson.A{
+		bson.M{"$group": bson.M{
+			"_id":        bson.M{"field": "$field", "value": "$value"},
+			"ids":        bson.M{"$push": "$_id"},
+			"first_seen": bson.M{"$min": "$first_seen"},
+		}},
+		bson.M{"$match": bson.M{"ids.1": bson.M{"$exists": true}}},
+	})
+	if err != nil {
+		return err
+	}
+	var groups []struct {
+		IDs       []bson.ObjectID `bson:"ids"`
+		FirstSeen time.Time       `bson:"first_seen"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if _, err := col.UpdateOne(ctx, bson.M{"_id": g.IDs[0]}, bson.M{"$set": bson.M{"first_seen": g.FirstSeen}}); err != nil {
+			return err
+		}
+		if _, err := col.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": g.IDs[1:]}}); err != nil {
+			return err
+		}
+	}
+
+	_, err = col.UpdateMany(ctx, bson.M{"courses": bson.M{"$exists": false}}, bson.A{
+		bson.M{"$set": bson.M{"courses": bson.A{"$example_course"}, "count": 1}},
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[migration] merged %d duplicated unmapped values", len(groups))
+
+	_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "field", Value: 1}, {Key: "value", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// createWatchNotificationIndexes keeps a notification from being queued
+// twice for the same event and user, serves the worker's search for due
+// notifications, and lets MongoDB drop notifications a week after they are
+// sent. The TTL index skips unsent ones, which have no sent_at.
+func createWatchNotificationIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(watchNotificationCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "sent_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(7 * 24 * 60 * 60)},
+	})
+	return err
+}
@@ -0,0 +1,67 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const oidcStateCollection = "oidc_states"
+
+// oidcStateModel is the MongoDB-specific representation of a pending OIDC login.
+type oidcStateModel struct {
+	State        string    `bson:"_id"`
+	Nonce        string    `bson:"nonce"`
+	CodeVerifier string    `bson:"code_verifier"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *oidcStateModel) toEntity() *entity.OIDCLoginState {
+	return &entity.OIDCLoginState{
+		State:        m.State,
+		Nonce:        m.Nonce,
+		CodeVerifier: m.CodeVerifier,
+		ExpiresAt:    m.ExpiresAt,
+	}
+}
+
+type oidcStateRepository struct {
+	db *mongo.Database
+}
+
+// NewOIDCStateRepository creates a new instance of OIDCStateRepository.
+// Abandoned logins are removed by the TTL index on expires_at.
+func NewOIDCStateRepository(db *mongo.Database) repository.OIDCStateRepository {
+	return &oidcStateRepository{db: db}
+}
+
+func (r *oidcStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	_, err := r.db.Collection(oidcStateCollection).InsertOne(ctx, &oidcStateModel{
+		State:        state.State,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	})
+	return err
+}
+
+func (r *oidcStateRepository) Consume(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	// Deleting in the same operation makes a replayed callback fail.
+	filter := bson.M{"_id": state, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var model oidcStateModel
+	err := r.db.Collection(oidcStateCollection).FindOneAndDelete(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const outboxCollection = "outbox"
+
+// domainEventModel is the MongoDB-specific representation of an outbox event.
+// Unpublished events have no published_at field.
+type domainEventModel struct {
+	ID            *bson.ObjectID `bson:"_id,omitempty"`
+	Type          string         `bson:"type"`
+	AggregateID   string         `bson:"aggregate_id"`
+	Data          []byte         `bson:"data"`
+	OccurredAt    time.Time      `bson:"occurred_at"`
+	Attempts      int            `bson:"attempts"`
+	NextAttemptAt time.Time      `bson:"next_attempt_at"`
+	LastError     string         `bson:"last_error,omitempty"`
+	HandledBy     []string       `bson:"handled_by,omitempty"`
+	PublishedAt   *time.Time     `bson:"published_at,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *domainEventModel) toEntity() *entity.DomainEvent {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	return &entity.DomainEvent{
+		ID:            id,
+		Type:          m.Type,
+		AggregateID:   m.AggregateID,
+		Data:          m.Data,
+		OccurredAt:    m.OccurredAt,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		HandledBy:     m.HandledBy,
+		PublishedAt:   m.PublishedAt,
+	}
+}
+
+type outboxRepository struct {
+	db *mongo.Database
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository.
+func NewOutboxRepository(db *mongo.Database) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Append(ctx context.Context, event *entity.DomainEvent) error {
+	result, err := r.db.Collection(outboxCollection).InsertOne(ctx, &domainEventModel{
+		Type:          event.Type,
+		AggregateID:   event.AggregateID,
+		Data:          event.Data,
+		OccurredAt:    event.OccurredAt,
+		Attempts:      event.Attempts,
+		NextAttemptAt: event.NextAttemptAt,
+		LastError:     event.LastError,
+		HandledBy:     event.HandledBy,
+		PublishedAt:   event.PublishedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		event.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*entity.DomainEvent, error) {
+	filter := bson.M{
+		"published_at":    bson.M{"$exists": false},
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var model domainEventModel
+	err := r.db.Collection(outboxCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *outboxRepository) Update(ctx context.Context, event *entity.DomainEvent) error {
+	oid, err := bson.ObjectIDFromHex(event.ID)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+	set := bson.M{
+		"attempts":        event.Attempts,
+		"next_attempt_at": event.NextAttemptAt,
+		"last_error":      event.LastError,
+		"handled_by":      event.HandledBy,
+	}
+	if event.PublishedAt != nil {
+		set["published_at"] = event.PublishedAt
+	}
+	_, err = r.db.Collection(outboxCollection).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": set})
+	return err
+}
@@ -0,0 +1,90 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const passwordResetCollection = "password_reset_tokens"
+
+// passwordResetModel is the MongoDB-specific representation of a password reset token.
+type passwordResetModel struct {
+	TokenHash string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	CreatedBy string    `bson:"created_by"`
+	CreatedAt time.Time `bson:"created_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *passwordResetModel) toEntity() *entity.PasswordResetToken {
+	return &entity.PasswordResetToken{
+		TokenHash: m.TokenHash,
+		UserID:    m.UserID,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+	}
+}
+
+type passwordResetRepository struct {
+	db *mongo.Database
+}
+
+// NewPasswordResetRepository creates a new instance of PasswordResetRepository.
+// Expired tokens are removed by the TTL index on expires_at.
+func NewPasswordResetRepository(db *mongo.Database) repository.PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	token.CreatedAt = time.Now()
+	_, err := r.db.Collection(passwordResetCollection).InsertOne(ctx, &passwordResetModel{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	})
+	return err
+}
+
+func (r *passwordResetRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	filter := bson.M{"_id": tokenHash, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var model passwordResetModel
+	err := r.db.Collection(passwordResetCollection).FindOne(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *passwordResetRepository) Consume(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	// Deleting in the same operation makes a replayed token fail.
+	filter := bson.M{"_id": tokenHash, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var model passwordResetModel
+	err := r.db.Collection(passwordResetCollection).FindOneAndDelete(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *passwordResetRepository) DeleteForUser(ctx context.Context, userID string) error {
+	_, err := r.db.Collection(passwordResetCollection).DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const rolePermissionCollection = "role_permissions"
+
+// rolePermissionModel is the MongoDB-specific representation of a role binding.
+type rolePermissionModel struct {
+	Role        string    `bson:"_id"`
+	Permissions []string  `bson:"permissions"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+	UpdatedBy   string    `bson:"updated_by,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *rolePermissionModel) toEntity() *entity.RolePermissions {
+	perms := m.Permissions
+	if perms == nil {
+		perms = []string{}
+	}
+	return &entity.RolePermissions{
+		Role:        m.Role,
+		Permissions: perms,
+		UpdatedAt:   m.UpdatedAt,
+		UpdatedBy:   m.UpdatedBy,
+	}
+}
+
+// toRolePermissionModel converts a domain entity to a MongoDB model.
+func toRolePermissionModel(e *entity.RolePermissions) *rolePermissionModel {
+	perms := e.Permissions
+	if perms == nil {
+		perms = []string{}
+	}
+	return &rolePermissionModel{
+		Role:        e.Role,
+		Permissions: perms,
+		UpdatedAt:   e.UpdatedAt,
+		UpdatedBy:   e.UpdatedBy,
+	}
+}
+
+type rolePermissionRepository struct {
+	db *mongo.Database
+}
+
+// NewRolePermissionRepository creates a new instance of RolePermissionRepository.
+func NewRolePermissionRepository(db *mongo.Database) repository.RolePermissionRepository {
+	return &rolePermissionRepository{db: db}
+}
+
+func (r *rolePermissionRepository) GetByRole(ctx context.Context, role string) (*entity.RolePermissions, error) {
+	var model rolePermissionModel
+	err := r.db.Collection(rolePermissionCollection).FindOne(ctx, bson.M{"_id": role}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *rolePermissionRepository) Save(ctx context.Context, rp *entity.RolePermissions) error {
+	rp.UpdatedAt = time.Now()
+	_, err := r.db.Collection(rolePermissionCollection).ReplaceOne(ctx,
+		bson.M{"_id": rp.Role}, toRolePermissionModel(rp), options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,168 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const sessionCollection = "sessions"
+
+// sessionModel is the MongoDB-specific representation of a refresh-token session.
+type sessionModel struct {
+	BaseModel         `bson:",inline"`
+	UserID            string     `bson:"user_id"`
+	TokenHash         string     `bson:"token_hash"`
+	PreviousTokenHash string     `bson:"previous_token_hash,omitempty"`
+	ExpiresAt         time.Time  `bson:"expires_at"`
+	RevokedAt         *time.Time `bson:"revoked_at,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *sessionModel) toEntity() *entity.Session {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+
+	return &entity.Session{
+		BaseEntity: entity.BaseEntity{
+			ID:        id,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		UserID:            m.UserID,
+		TokenHash:         m.TokenHash,
+		PreviousTokenHash: m.PreviousTokenHash,
+		ExpiresAt:         m.ExpiresAt,
+		RevokedAt:         m.RevokedAt,
+	}
+}
+
+// toSessionModel converts a domain entity to a MongoDB model.
+func toSessionModel(e *entity.Session) *sessionModel {
+	m := &sessionModel{
+		UserID:            e.UserID,
+		TokenHash:         e.TokenHash,
+		PreviousTokenHash: e.PreviousTokenHash,
+		ExpiresAt:         e.ExpiresAt,
+		RevokedAt:         e.RevokedAt,
+	}
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+	if e.ID != "" {
+		oid, err := bson.ObjectIDFromHex(e.ID)
+		if err == nil {
+			m.ID = &oid
+		}
+	}
+	return m
+}
+
+type sessionRepository struct {
+	db *mongo.Database
+}
+
+// NewSessionRepository creates a new instance of SessionRepository.
+func NewSessionRepository(db *mongo.Database) repository.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = time.Now()
+
+	result, err := r.db.Collection(sessionCollection).InsertOne(ctx, toSessionModel(session))
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		session.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+func (r *sessionRepository) FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error) {
+	return r.findOne(ctx, bson.M{"$or": bson.A{
+		bson.M{"token_hash": hash},
+		bson.M{"previous_token_hash": hash},
+	}})
+}
+
+func (r *sessionRepository) findOne(ctx context.Context, filter bson.M) (*entity.Session, error) {
+	var model sessionModel
+	err := r.db.Collection(sessionCollection).FindOne(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time) (bool, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid id format")
+	}
+
+	filter := bson.M{"_id": oid, "token_hash": oldHash, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{
+		"token_hash":          newHash,
+		"previous_token_hash": oldHash,
+		"expires_at":          expiresAt,
+		"updated_at":          time.Now(),
+	}}
+
+	result, err := r.db.Collection(sessionCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+
+	now := time.Now()
+	filter := bson.M{"_id": oid, "revoked_at": bson.M{"$exists": false}}
+	_, err = r.db.Collection(sessionCollection).UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": now, "updated_at": now}})
+	return err
+}
+
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	return r.revokeMany(ctx, bson.M{"user_id": userID})
+}
+
+func (r *sessionRepository) RevokeAllForUserExcept(ctx context.Context, userID, keepID string) error {
+	filter := bson.M{"user_id": userID}
+	if oid, err := bson.ObjectIDFromHex(keepID); err == nil {
+		filter["_id"] = bson.M{"$ne": oid}
+	}
+	return r.revokeMany(ctx, filter)
+}
+
+func (r *sessionRepository) revokeMany(ctx context.Context, filter bson.M) error {
+	now := time.Now()
+	filter["revoked_at"] = bson.M{"$exists": false}
+	_, err := r.db.Collection(sessionCollection).UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked_at": now, "updated_at": now}})
+	return err
+}
@@ -0,0 +1,38 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type transactor struct {
+	client  *mongo.Client
+	enabled bool
+}
+
+// NewTransactor creates a Transactor backed by MongoDB sessions. Transactions
+// need a replica set or a sharded cluster; when enabled is false, as on a
+// standalone server, work runs without one and is not atomic.
+func NewTransactor(client *mongo.Client, enabled bool) repository.Transactor {
+	return &transactor{client: client, enabled: enabled}
+}
+
+// WithinTransaction runs fn in a transaction, retrying it on transient
+// errors, so fn may run more than once.
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !t.enabled {
+		return fn(ctx)
+	}
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const unmappedValueCollection = "unmapped_values"
+
+// unmappedValueModel is the MongoDB-specific representation of an UnmappedValue.
+// Courses holds the distinct course codes the value was seen on; Count is its
+// length, kept alongside so the report can be sorted by it.
+type unmappedValueModel struct {
+	Field         string    `bson:"field"`
+	Value         string    `bson:"value"`
+	Courses       []string  `bson:"courses"`
+	Count         int64     `bson:"count"`
+	ExampleCourse string    `bson:"example_course"`
+	FirstSeen     time.Time `bson:"first_seen"`
+	LastSeen      time.Time `bson:"last_seen"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *unmappedValueModel) toEntity() *entity.UnmappedValue {
+	return &entity.UnmappedValue{
+		Field:         m.Field,
+		Value:         m.Value,
+		Count:         m.Count,
+		ExampleCourse: m.ExampleCourse,
+		FirstSeen:     m.FirstSeen,
+		LastSeen:      m.LastSeen,
+	}
+}
+
+type unmappedValueRepository struct {
+	db *mongo.Database
+}
+
+// NewUnmappedValueRepository creates a new instance of UnmappedValueRepository.
+func NewUnmappedValueRepository(db *mongo.Database) repository.UnmappedValueRepository {
+	return &unmappedValueRepository{db: db}
+}
+
+// Record relies on the unique (field, value) index: concurrent upserts of a
+// new pair collide on it and the server retries the loser as an update.
+func (r *unmappedValueRepository) Record(ctx context.Context, field, value, courseCode string) error {
+	now := time.Now()
+	filter := bson.M{"field": field, "value": value}
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"courses":        bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$courses", bson.A{}}}, bson.A{courseCode}}},
+			"example_course": courseCode,
+			"first_seen":     bson.M{"$ifNull": bson.A{"$first_seen", now}},
+			"last_seen":      now,
+		}},
+		bson.M{"$set": bson.M{"count": bson.M{"$size": "$courses"}}},
+	}
+
+	_, err := r.db.Collection(unmappedValueCollection).UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *unmappedValueRepository) GetAll(ctx context.Context) ([]*entity.UnmappedValue, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "count", Value: -1}}).
+		SetProjection(bson.M{"courses": 0})
+	cursor, err := r.db.Collection(unmappedValueCollection).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*unmappedValueModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	values := make([]*entity.UnmappedValue, len(models))
+	for i, m := range models {
+		values[i] = m.toEntity()
+	}
+	return values, nil
+}
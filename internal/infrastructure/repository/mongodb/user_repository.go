@@ -20,6 +20,10 @@ type userModel struct {
 	Username  string `bson:"username"`
 	Password  string `bson:"password"`
 	Role      string `bson:"role"`
+	Disabled  bool   `bson:"disabled,omitempty"`
+	// ExternalID is omitted when empty so the sparse unique index ignores local users.
+	ExternalID         string `bson:"external_id,omitempty"`
+	MustChangePassword bool   `bson:"must_change_password,omitempty"`
 }
 
 // toEntity converts a MongoDB model to a domain entity.
@@ -36,18 +40,24 @@ func (m *userModel) toEntity() *entity.User {
 			UpdatedAt: m.UpdatedAt,
 			DeletedAt: m.DeletedAt,
 		},
-		Username: m.Username,
-		Password: m.Password,
-		Role:     m.Role,
+		Username:           m.Username,
+		Password:           m.Password,
+		Role:               m.Role,
+		Disabled:           m.Disabled,
+		ExternalID:         m.ExternalID,
+		MustChangePassword: m.MustChangePassword,
 	}
 }
 
 // toUserModel converts a domain entity to a MongoDB model.
 func toUserModel(e *entity.User) *userModel {
 	m := &userModel{
-		Username: e.Username,
-		Password: e.Password,
-		Role:     string(e.Role),
+		Username:           e.Username,
+		Password:           e.Password,
+		Role:               string(e.Role),
+		Disabled:           e.Disabled,
+		ExternalID:         e.ExternalID,
+		MustChangePassword: e.MustChangePassword,
 	}
 	m.CreatedAt = e.CreatedAt
 	m.UpdatedAt = e.UpdatedAt
@@ -103,6 +113,46 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 	return model.toEntity(), nil
 }
 
+func (r *userRepository) FindByExternalID(ctx context.Context, externalID string) (*entity.User, error) {
+	var model userModel
+	filter := bson.M{"external_id": externalID, "deleted_at": bson.M{"$exists": false}}
+	err := r.db.Collection(userCollection).FindOne(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *userRepository) ExistsByRole(ctx context.Context, role string) (bool, error) {
+	filter := bson.M{"role": role, "deleted_at": bson.M{"$exists": false}}
+	count, err := r.db.Collection(userCollection).CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	var model userModel
+	filter := bson.M{"_id": oid, "deleted_at": bson.M{"$exists": false}}
+	err = r.db.Collection(userCollection).FindOne(ctx, filter).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
 func (r *userRepository) GetPaginated(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
 	col := r.db.Collection(userCollection)
 
@@ -135,3 +185,59 @@ func (r *userRepository) GetPaginated(ctx context.Context, page, limit int) ([]*
 	}
 	return users, total, nil
 }
+
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	user.UpdatedAt = time.Now()
+
+	oid, err := bson.ObjectIDFromHex(user.ID)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+
+	filter := bson.M{
+		"_id":        oid,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"username":             user.Username,
+			"password":             user.Password,
+			"role":                 user.Role,
+			"disabled":             user.Disabled,
+			"updated_at":           user.UpdatedAt,
+			"must_change_password": user.MustChangePassword,
+		},
+	}
+
+	result, err := r.db.Collection(userCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":        oid,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}}
+
+	result, err := r.db.Collection(userCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
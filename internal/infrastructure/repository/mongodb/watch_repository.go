@@ -0,0 +1,233 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	watchCollection             = "watches"
+	watchNotificationCollection = "watch_notifications"
+)
+
+// watchModel is the MongoDB-specific representation of a watch.
+type watchModel struct {
+	ID         *bson.ObjectID `bson:"_id,omitempty"`
+	UserID     string         `bson:"user_id"`
+	CourseID   string         `bson:"course_id"`
+	SectionID  string         `bson:"section_id"` // "" for a course watch, so the unique index covers both kinds
+	CourseCode string         `bson:"course_code"`
+	Year       int            `bson:"year"`
+	Semester   int            `bson:"semester"`
+	Section    string         `bson:"section,omitempty"`
+	CreatedAt  time.Time      `bson:"created_at"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *watchModel) toEntity() *entity.Watch {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	return &entity.Watch{
+		ID:         id,
+		UserID:     m.UserID,
+		CourseID:   m.CourseID,
+		SectionID:  m.SectionID,
+		CourseCode: m.CourseCode,
+		Year:       m.Year,
+		Semester:   m.Semester,
+		Section:    m.Section,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+type watchRepository struct {
+	db *mongo.Database
+}
+
+// NewWatchRepository creates a new instance of WatchRepository.
+func NewWatchRepository(db *mongo.Database) repository.WatchRepository {
+	return &watchRepository{db: db}
+}
+
+func (r *watchRepository) Create(ctx context.Context, watch *entity.Watch) error {
+	watch.CreatedAt = time.Now()
+	result, err := r.db.Collection(watchCollection).InsertOne(ctx, &watchModel{
+		UserID:     watch.UserID,
+		CourseID:   watch.CourseID,
+		SectionID:  watch.SectionID,
+		CourseCode: watch.CourseCode,
+		Year:       watch.Year,
+		Semester:   watch.Semester,
+		Section:    watch.Section,
+		CreatedAt:  watch.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write back the generated ID to the entity.
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		watch.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *watchRepository) GetByUser(ctx context.Context, userID string) ([]*entity.Watch, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+func (r *watchRepository) GetByCourse(ctx context.Context, courseID string) ([]*entity.Watch, error) {
+	return r.find(ctx, bson.M{"course_id": courseID})
+}
+
+func (r *watchRepository) find(ctx context.Context, filter bson.M) ([]*entity.Watch, error) {
+	cursor, err := r.db.Collection(watchCollection).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*watchModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+	watches := make([]*entity.Watch, len(models))
+	for i, m := range models {
+		watches[i] = m.toEntity()
+	}
+	return watches, nil
+}
+
+func (r *watchRepository) Delete(ctx context.Context, id, userID string) (bool, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	result, err := r.db.Collection(watchCollection).DeleteOne(ctx, bson.M{"_id": oid, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// watchNotificationModel is the MongoDB-specific representation of a watch notification.
+type watchNotificationModel struct {
+	ID            *bson.ObjectID         `bson:"_id,omitempty"`
+	EventID       string                 `bson:"event_id"`
+	UserID        string                 `bson:"user_id"`
+	CourseID      string                 `bson:"course_id"`
+	Change        courseChangeEventModel `bson:"change"`
+	Status        string                 `bson:"status"`
+	Attempts      int                    `bson:"attempts"`
+	NextAttemptAt time.Time              `bson:"next_attempt_at"`
+	LastError     string                 `bson:"last_error,omitempty"`
+	CreatedAt     time.Time              `bson:"created_at"`
+	SentAt        *time.Time             `bson:"sent_at,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *watchNotificationModel) toEntity() *entity.WatchNotification {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	return &entity.WatchNotification{
+		ID:            id,
+		EventID:       m.EventID,
+		UserID:        m.UserID,
+		CourseID:      m.CourseID,
+		Change:        *m.Change.toEntity(),
+		Status:        entity.WatchNotificationStatus(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+		SentAt:        m.SentAt,
+	}
+}
+
+type watchNotificationRepository struct {
+	db *mongo.Database
+}
+
+// NewWatchNotificationRepository creates a new instance of WatchNotificationRepository.
+func NewWatchNotificationRepository(db *mongo.Database) repository.WatchNotificationRepository {
+	return &watchNotificationRepository{db: db}
+}
+
+// Create relies on the unique (event_id, user_id) index to keep a
+// notification from being queued twice.
+func (r *watchNotificationRepository) Create(ctx context.Context, n *entity.WatchNotification) error {
+	n.CreatedAt = time.Now()
+	result, err := r.db.Collection(watchNotificationCollection).InsertOne(ctx, &watchNotificationModel{
+		EventID:       n.EventID,
+		UserID:        n.UserID,
+		CourseID:      n.CourseID,
+		Change:        *toCourseChangeEventModel(&n.Change),
+		Status:        string(n.Status),
+		Attempts:      n.Attempts,
+		NextAttemptAt: n.NextAttemptAt,
+		LastError:     n.LastError,
+		CreatedAt:     n.CreatedAt,
+		SentAt:        n.SentAt,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		n.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *watchNotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*entity.WatchNotification, error) {
+	filter := bson.M{
+		"status":          string(entity.WatchNotificationPending),
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var model watchNotificationModel
+	err := r.db.Collection(watchNotificationCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *watchNotificationRepository) Update(ctx context.Context, n *entity.WatchNotification) error {
+	oid, err := bson.ObjectIDFromHex(n.ID)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+	set := bson.M{
+		"status":          string(n.Status),
+		"attempts":        n.Attempts,
+		"next_attempt_at": n.NextAttemptAt,
+		"last_error":      n.LastError,
+	}
+	if n.SentAt != nil {
+		set["sent_at"] = n.SentAt
+	}
+	_, err = r.db.Collection(watchNotificationCollection).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": set})
+	return err
+}
@@ -0,0 +1,304 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	webhookCollection         = "webhooks"
+	webhookDeliveryCollection = "webhook_deliveries"
+)
+
+// webhookModel is the MongoDB-specific representation of a webhook.
+type webhookModel struct {
+	ID          *bson.ObjectID `bson:"_id,omitempty"`
+	URL         string         `bson:"url"`
+	Events      []string       `bson:"events"`
+	Secret      string         `bson:"secret"`
+	Description string         `bson:"description,omitempty"`
+	CreatedBy   string         `bson:"created_by"`
+	CreatedAt   time.Time      `bson:"created_at"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *webhookModel) toEntity() *entity.Webhook {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	return &entity.Webhook{
+		ID:          id,
+		URL:         m.URL,
+		Events:      m.Events,
+		Secret:      m.Secret,
+		Description: m.Description,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+type webhookRepository struct {
+	db *mongo.Database
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository.
+func NewWebhookRepository(db *mongo.Database) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	webhook.CreatedAt = time.Now()
+	result, err := r.db.Collection(webhookCollection).InsertOne(ctx, &webhookModel{
+		URL:         webhook.URL,
+		Events:      webhook.Events,
+		Secret:      webhook.Secret,
+		Description: webhook.Description,
+		CreatedBy:   webhook.CreatedBy,
+		CreatedAt:   webhook.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		webhook.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context) ([]*entity.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *webhookRepository) GetByEvent(ctx context.Context, event string) ([]*entity.Webhook, error) {
+	return r.find(ctx, bson.M{"events": event})
+}
+
+func (r *webhookRepository) find(ctx context.Context, filter bson.M) ([]*entity.Webhook, error) {
+	cursor, err := r.db.Collection(webhookCollection).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*webhookModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+	webhooks := make([]*entity.Webhook, len(models))
+	for i, m := range models {
+		webhooks[i] = m.toEntity()
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var model webhookModel
+	err = r.db.Collection(webhookCollection).FindOne(ctx, bson.M{"_id": oid}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) (bool, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	result, err := r.db.Collection(webhookCollection).DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// webhookDeliveryModel is the MongoDB-specific representation of a webhook delivery.
+type webhookDeliveryModel struct {
+	ID             *bson.ObjectID `bson:"_id,omitempty"`
+	WebhookID      string         `bson:"webhook_id"`
+	Event          string         `bson:"event"`
+	Payload        []byte         `bson:"payload"`
+	Status         string         `bson:"status"`
+	Attempts       int            `bson:"attempts"`
+	NextAttemptAt  time.Time      `bson:"next_attempt_at"`
+	LastStatusCode int            `bson:"last_status_code,omitempty"`
+	LastError      string         `bson:"last_error,omitempty"`
+	ReplayOf       string         `bson:"replay_of,omitempty"`
+	CreatedAt      time.Time      `bson:"created_at"`
+	DeliveredAt    *time.Time     `bson:"delivered_at,omitempty"`
+}
+
+// toEntity converts a MongoDB model to a domain entity.
+func (m *webhookDeliveryModel) toEntity() *entity.WebhookDelivery {
+	var id string
+	if m.ID != nil {
+		id = m.ID.Hex()
+	}
+	return &entity.WebhookDelivery{
+		ID:             id,
+		WebhookID:      m.WebhookID,
+		Event:          m.Event,
+		Payload:        m.Payload,
+		Status:         entity.WebhookDeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		ReplayOf:       m.ReplayOf,
+		CreatedAt:      m.CreatedAt,
+		DeliveredAt:    m.DeliveredAt,
+	}
+}
+
+type webhookDeliveryRepository struct {
+	db *mongo.Database
+}
+
+// NewWebhookDeliveryRepository creates a new instance of WebhookDeliveryRepository.
+func NewWebhookDeliveryRepository(db *mongo.Database) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	delivery.CreatedAt = time.Now()
+	result, err := r.db.Collection(webhookDeliveryCollection).InsertOne(ctx, &webhookDeliveryModel{
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(bson.ObjectID); ok {
+		delivery.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var model webhookDeliveryModel
+	err = r.db.Collection(webhookDeliveryCollection).FindOne(ctx, bson.M{"_id": oid}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *webhookDeliveryRepository) GetPaginated(ctx context.Context, filter repository.WebhookDeliveryFilter, page, limit int) ([]*entity.WebhookDelivery, int64, error) {
+	col := r.db.Collection(webhookDeliveryCollection)
+	query := webhookDeliveryQuery(filter)
+
+	total, err := col.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetSkip(int64((page - 1) * limit))
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := col.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*webhookDeliveryModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, 0, err
+	}
+	deliveries := make([]*entity.WebhookDelivery, len(models))
+	for i, m := range models {
+		deliveries[i] = m.toEntity()
+	}
+	return deliveries, total, nil
+}
+
+// webhookDeliveryQuery builds the MongoDB filter for a WebhookDeliveryFilter.
+func webhookDeliveryQuery(f repository.WebhookDeliveryFilter) bson.M {
+	q := bson.M{}
+	for field, val := range map[string]string{
+		"webhook_id": f.WebhookID,
+		"event":      f.Event,
+		"status":     string(f.Status),
+	} {
+		if val != "" {
+			q[field] = val
+		}
+	}
+	return q
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*entity.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          string(entity.WebhookDeliveryPending),
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var model webhookDeliveryModel
+	err := r.db.Collection(webhookDeliveryCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.toEntity(), nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	oid, err := bson.ObjectIDFromHex(delivery.ID)
+	if err != nil {
+		return errors.New("invalid id format")
+	}
+	set := bson.M{
+		"status":           string(delivery.Status),
+		"attempts":         delivery.Attempts,
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"last_status_code": delivery.LastStatusCode,
+		"last_error":       delivery.LastError,
+	}
+	if delivery.DeliveredAt != nil {
+		set["delivered_at"] = delivery.DeliveredAt
+	}
+	_, err = r.db.Collection(webhookDeliveryCollection).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": set})
+	return err
+}
@@ -0,0 +1,39 @@
+package constants
+
+// Audit actions, in "target.verb" form.
+const (
+	AuditCourseCreate = "course.create"
+	AuditCourseDelete = "course.delete"
+
+	AuditCronJobCreate  = "cronjob.create"
+	AuditCronJobUpdate  = "cronjob.update"
+	AuditCronJobDelete  = "cronjob.delete"
+	AuditCronJobTrigger = "cronjob.trigger"
+
+	AuditUserCreate        = "user.create"
+	AuditUserRoleChange    = "user.role_change"
+	AuditUserDisable       = "user.disable"
+	AuditUserEnable        = "user.enable"
+	AuditUserDelete        = "user.delete"
+	AuditUserPasswordReset = "user.password_reset"
+
+	AuditRolePermissionsUpdate = "role_permissions.update"
+
+	AuditAPIKeyCreate = "apikey.create"
+	AuditAPIKeyRevoke = "apikey.revoke"
+
+	AuditWebhookCreate         = "webhook.create"
+	AuditWebhookDelete         = "webhook.delete"
+	AuditWebhookDeliveryReplay = "webhook_delivery.replay"
+)
+
+// Audit target types.
+const (
+	AuditTargetCourse          = "course"
+	AuditTargetCronJob         = "cronjob"
+	AuditTargetUser            = "user"
+	AuditTargetRolePermissions = "role_permissions"
+	AuditTargetAPIKey          = "apikey"
+	AuditTargetWebhook         = "webhook"
+	AuditTargetWebhookDelivery = "webhook_delivery"
+)
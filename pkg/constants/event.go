@@ -0,0 +1,13 @@
+package constants
+
+// Domain event types recorded in the outbox that webhooks cannot subscribe
+// to. The rest are listed in WebhookEvents.
+const (
+	EventCourseChanged  = "course.changed" // a refresh found changes; carries the CourseChangeEvent, for watchers
+	EventCronJobCreated = "cronjob.created"
+	EventCronJobUpdated = "cronjob.updated"
+	EventCronJobDeleted = "cronjob.deleted"
+	EventUserCreated    = "user.created"
+	EventUserUpdated    = "user.updated" // profile, role or disabled flag changed
+	EventUserDeleted    = "user.deleted"
+)
@@ -0,0 +1,58 @@
+package constants
+
+// Permission names checked by RequirePermission, in "resource:action" form.
+const (
+	PermCourseWrite       = "course:write"
+	PermNormalizationRead = "normalization:read"
+	PermQueueRead         = "queue:read"
+	PermCronJobRead       = "cronjob:read"
+	PermCronJobWrite      = "cronjob:write"
+	PermCronJobTrigger    = "cronjob:trigger"
+	PermUserManage        = "user:manage"
+	PermRoleManage        = "role:manage"
+	PermAPIKeyManage      = "apikey:manage"
+	PermAuditRead         = "audit:read"
+	PermWebhookManage     = "webhook:manage"
+)
+
+// AllPermissions lists every permission in display order.
+var AllPermissions = []string{
+	PermCourseWrite,
+	PermNormalizationRead,
+	PermQueueRead,
+	PermCronJobRead,
+	PermCronJobWrite,
+	PermCronJobTrigger,
+	PermUserManage,
+	PermRoleManage,
+	PermAPIKeyManage,
+	PermAuditRead,
+	PermWebhookManage,
+}
+
+// ValidPermissions contains all valid permissions for validation.
+var ValidPermissions = func() map[string]bool {
+	m := make(map[string]bool, len(AllPermissions))
+	for _, p := range AllPermissions {
+		m[p] = true
+	}
+	return m
+}()
+
+// DefaultRolePermissions are the bindings seeded for roles that have none
+// stored. Superadmin always holds every permission regardless of storage.
+var DefaultRolePermissions = map[string][]string{
+	RoleSuperAdmin: AllPermissions,
+	RoleAdmin: {
+		PermCourseWrite,
+		PermNormalizationRead,
+		PermQueueRead,
+		PermCronJobRead,
+		PermCronJobWrite,
+		PermCronJobTrigger,
+		PermUserManage,
+		PermAPIKeyManage,
+		PermWebhookManage,
+	},
+	RoleStudent: {},
+}
@@ -0,0 +1,41 @@
+package constants
+
+import "testing"
+
+func TestDefaultRolePermissions_CoverEveryRole(t *testing.T) {
+	for _, role := range Roles {
+		if _, ok := DefaultRolePermissions[role]; !ok {
+			t.Errorf("expected default permissions for %q", role)
+		}
+	}
+}
+
+func TestDefaultRolePermissions_AreValid(t *testing.T) {
+	for role, perms := range DefaultRolePermissions {
+		for _, p := range perms {
+			if !ValidPermissions[p] {
+				t.Errorf("role %q has unknown permission %q", role, p)
+			}
+		}
+	}
+}
+
+func TestDefaultRolePermissions_SuperAdminOnly(t *testing.T) {
+	for _, perm := range []string{PermRoleManage, PermAuditRead} {
+		for role, perms := range DefaultRolePermissions {
+			has := false
+			for _, p := range perms {
+				has = has || p == perm
+			}
+			if has != (role == RoleSuperAdmin) {
+				t.Errorf("role %q: %s = %v", role, perm, has)
+			}
+		}
+	}
+}
+
+func TestDefaultRolePermissions_StudentHasNone(t *testing.T) {
+	if len(DefaultRolePermissions[RoleStudent]) != 0 {
+		t.Errorf("expected no student permissions, got %v", DefaultRolePermissions[RoleStudent])
+	}
+}
@@ -14,8 +14,5 @@ var ValidRoles = map[string]bool{
 	RoleStudent:    true,
 }
 
-// PrivilegedRoles are roles that can manage users (e.g. create admin accounts).
-var PrivilegedRoles = map[string]bool{
-	RoleSuperAdmin: true,
-	RoleAdmin:      true,
-}
+// Roles lists every role from most to least privileged.
+var Roles = []string{RoleSuperAdmin, RoleAdmin, RoleStudent}
@@ -20,21 +20,6 @@ func TestValidRoles_RejectsInvalid(t *testing.T) {
 	}
 }
 
-func TestPrivilegedRoles_ContainsSuperAdminAndAdmin(t *testing.T) {
-	if !PrivilegedRoles[RoleSuperAdmin] {
-		t.Error("expected PrivilegedRoles to contain superadmin")
-	}
-	if !PrivilegedRoles[RoleAdmin] {
-		t.Error("expected PrivilegedRoles to contain admin")
-	}
-}
-
-func TestPrivilegedRoles_ExcludesStudent(t *testing.T) {
-	if PrivilegedRoles[RoleStudent] {
-		t.Error("expected PrivilegedRoles to NOT contain student")
-	}
-}
-
 func TestRoleConstants_Values(t *testing.T) {
 	if RoleSuperAdmin != "superadmin" {
 		t.Errorf("expected RoleSuperAdmin='superadmin', got %q", RoleSuperAdmin)
@@ -0,0 +1,26 @@
+package constants
+
+// Webhook event types, in "resource.verb" form.
+const (
+	WebhookCourseCreated       = "course.created"
+	WebhookCourseUpdated       = "course.updated" // a refresh changed the course
+	WebhookCourseDeleted       = "course.deleted"
+	WebhookCronJobRunCompleted = "cronjob.run.completed"
+)
+
+// WebhookEvents lists every event type a webhook can subscribe to.
+var WebhookEvents = []string{
+	WebhookCourseCreated,
+	WebhookCourseUpdated,
+	WebhookCourseDeleted,
+	WebhookCronJobRunCompleted,
+}
+
+// ValidWebhookEvents contains all valid webhook event types for validation.
+var ValidWebhookEvents = func() map[string]bool {
+	m := make(map[string]bool, len(WebhookEvents))
+	for _, e := range WebhookEvents {
+		m[e] = true
+	}
+	return m
+}()
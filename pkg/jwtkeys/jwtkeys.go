@@ -0,0 +1,265 @@
+// Package jwtkeys manages the keys used to sign and verify access tokens.
+//
+// A KeySet has one signing key and any number of verification keys, each
+// identified by a "kid" header. Asymmetric keys (RS256, EdDSA) are loaded
+// from PEM files and published as a JWKS so other services can verify
+// tokens without being able to mint them. An HMAC key set is kept for local
+// development only.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA modulus accepted for signing or verification.
+const minRSABits = 2048
+
+// Key is a single signing or verification key.
+type Key struct {
+	ID     string            // kid; RFC 7638 thumbprint for asymmetric keys
+	Method jwt.SigningMethod // RS256, EdDSA or HS256
+	public crypto.PublicKey  // nil for HMAC
+	signer interface{}       // private key or HMAC secret; nil for verify-only keys
+	verify interface{}       // key handed to jwt for verification
+}
+
+// KeySet holds the active signing key and every key accepted for verification.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	order   []string // kids in load order, signing key first
+}
+
+// ErrUnknownKey is returned when a token's kid is not in the set.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// NewHMAC returns a key set that signs and verifies with a shared HS256
+// secret. Tokens carry kid "hs256". The JWKS of an HMAC set is empty.
+func NewHMAC(secret string) *KeySet {
+	k := &Key{ID: "hs256", Method: jwt.SigningMethodHS256, signer: []byte(secret), verify: []byte(secret)}
+	return &KeySet{signing: k, keys: map[string]*Key{k.ID: k}, order: []string{k.ID}}
+}
+
+// LoadFiles builds a key set from a PEM private key used for signing and any
+// number of additional PEM files (public or private keys) that remain valid
+// for verification, e.g. the previous signing key during rotation.
+func LoadFiles(signingKeyFile string, verifyKeyFiles ...string) (*KeySet, error) {
+	signing, err := loadFile(signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if signing.signer == nil {
+		return nil, fmt.Errorf("%s: signing key must be a private key", signingKeyFile)
+	}
+
+	ks := &KeySet{signing: signing, keys: map[string]*Key{signing.ID: signing}, order: []string{signing.ID}}
+	for _, f := range verifyKeyFiles {
+		k, err := loadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := ks.keys[k.ID]; dup {
+			continue
+		}
+		k.signer = nil // verification only
+		ks.keys[k.ID] = k
+		ks.order = append(ks.order, k.ID)
+	}
+	return ks, nil
+}
+
+// SigningKeyID returns the kid placed on newly signed tokens.
+func (ks *KeySet) SigningKeyID() string {
+	return ks.signing.ID
+}
+
+// Sign signs claims with the active key and sets the kid header.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.signer)
+}
+
+// Keyfunc resolves the verification key for a token by kid, rejecting
+// tokens whose alg does not match the key. Use it with jwt.Parse.
+func (ks *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	k, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if t.Method.Alg() != k.Method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	return k.verify, nil
+}
+
+// Methods lists the algorithms of every key in the set, for jwt.WithValidMethods.
+func (ks *KeySet) Methods() []string {
+	seen := map[string]bool{}
+	var methods []string
+	for _, kid := range ks.order {
+		alg := ks.keys[kid].Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// Parse verifies tokenStr against the set and returns its claims.
+func (ks *KeySet) Parse(tokenStr string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenStr, ks.Keyfunc, jwt.WithValidMethods(ks.Methods()))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// ---------- JWKS ----------
+
+// JWK is a public key in JSON Web Key form (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKS is a JSON Web Key Set document.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public half of every asymmetric key in the set.
+func (ks *KeySet) JWKS() JWKS {
+	doc := JWKS{Keys: []JWK{}}
+	for _, kid := range ks.order {
+		k := ks.keys[kid]
+		if k.public == nil {
+			continue
+		}
+		jwk := publicJWK(k.public)
+		jwk.Kid, jwk.Use, jwk.Alg = k.ID, "sig", k.Method.Alg()
+		doc.Keys = append(doc.Keys, jwk)
+	}
+	return doc
+}
+
+func publicJWK(pub crypto.PublicKey) JWK {
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   b64(p.N.Bytes()),
+			E:   b64(big.NewInt(int64(p.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: b64(p)}
+	}
+	return JWK{}
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint, used as the kid.
+func thumbprint(pub crypto.PublicKey) string {
+	jwk := publicJWK(pub)
+	var members map[string]string
+	if jwk.Kty == "RSA" {
+		members = map[string]string{"e": jwk.E, "kty": jwk.Kty, "n": jwk.N}
+	} else {
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X}
+	}
+	// encoding/json sorts map keys, giving the canonical member order.
+	b, _ := json.Marshal(members)
+	sum := sha256.Sum256(b)
+	return b64(sum[:])
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ---------- PEM loading ----------
+
+func loadFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key %s: %w", path, err)
+	}
+	k, err := ParsePEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", path, err)
+	}
+	return k, nil
+}
+
+// ParsePEM parses an RSA or Ed25519 key from PEM. Private keys may be PKCS#8
+// or PKCS#1; public keys may be PKIX or PKCS#1.
+func ParsePEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Key{}
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		k.Method, k.signer, k.public = jwt.SigningMethodRS256, key, &key.PublicKey
+	case *rsa.PublicKey:
+		k.Method, k.public = jwt.SigningMethodRS256, key
+	case ed25519.PrivateKey:
+		k.Method, k.signer, k.public = jwt.SigningMethodEdDSA, key, key.Public()
+	case ed25519.PublicKey:
+		k.Method, k.public = jwt.SigningMethodEdDSA, key
+	default:
+		return nil, fmt.Errorf("unsupported key type %T: use RSA or Ed25519", parsed)
+	}
+
+	if rsaPub, ok := k.public.(*rsa.PublicKey); ok && rsaPub.N.BitLen() < minRSABits {
+		return nil, fmt.Errorf("RSA key is %d bits; at least %d required", rsaPub.N.BitLen(), minRSABits)
+	}
+
+	k.verify = k.public
+	k.ID = thumbprint(k.public)
+	return k, nil
+}
@@ -0,0 +1,184 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writePEM(t *testing.T, name, typ string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func rsaKeyFile(t *testing.T) (string, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)), key
+}
+
+func ed25519KeyFile(t *testing.T) (string, ed25519.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "ed.pem", "PRIVATE KEY", der), pub
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"sub": "u1", "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func TestHMAC_SignAndParse(t *testing.T) {
+	ks := NewHMAC("secret")
+	tok, err := ks.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := ks.Parse(tok)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if claims["sub"] != "u1" {
+		t.Errorf("unexpected sub %v", claims["sub"])
+	}
+	if len(ks.JWKS().Keys) != 0 {
+		t.Error("HMAC keys must not be published")
+	}
+}
+
+func TestRSA_SignAndParse(t *testing.T) {
+	path, _ := rsaKeyFile(t)
+	ks, err := LoadFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := ks.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, _ := jwt.NewParser().ParseUnverified(tok, jwt.MapClaims{})
+	if parsed.Header["kid"] != ks.SigningKeyID() || parsed.Header["alg"] != "RS256" {
+		t.Errorf("unexpected header %v", parsed.Header)
+	}
+	if _, err := ks.Parse(tok); err != nil {
+		t.Errorf("parse: %v", err)
+	}
+
+	jwks := ks.JWKS()
+	if len(jwks.Keys) != 1 || jwks.Keys[0].Kty != "RSA" || jwks.Keys[0].Kid != ks.SigningKeyID() || jwks.Keys[0].N == "" {
+		t.Errorf("unexpected JWKS %+v", jwks)
+	}
+}
+
+func TestEdDSA_Rotation(t *testing.T) {
+	oldPath, _ := rsaKeyFile(t)
+	newPath, pub := ed25519KeyFile(t)
+
+	oldSet, err := LoadFiles(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, _ := oldSet.Sign(testClaims())
+
+	// New signing key, old key kept for verification only.
+	ks, err := LoadFiles(newPath, oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newToken, err := ks.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tok := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := ks.Parse(tok); err != nil {
+			t.Errorf("%s token rejected: %v", name, err)
+		}
+	}
+
+	jwks := ks.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("expected 2 published keys, got %d", len(jwks.Keys))
+	}
+	if jwks.Keys[0].Kty != "OKP" || jwks.Keys[0].Alg != "EdDSA" || jwks.Keys[0].X != b64(pub) {
+		t.Errorf("unexpected EdDSA JWK %+v", jwks.Keys[0])
+	}
+
+	// A set without the old key rejects its tokens.
+	newOnly, _ := LoadFiles(newPath)
+	if _, err := newOnly.Parse(oldToken); err == nil {
+		t.Error("expected token from a retired key to be rejected")
+	}
+
+	// Same algorithm, unknown kid.
+	otherPath, _ := ed25519KeyFile(t)
+	other, _ := LoadFiles(otherPath)
+	otherToken, _ := other.Sign(testClaims())
+	if _, err := newOnly.Parse(otherToken); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestParse_RejectsForeignAndAlgSwap(t *testing.T) {
+	path, key := rsaKeyFile(t)
+	ks, _ := LoadFiles(path)
+
+	// HS256 token signed with the RSA public modulus under the RSA kid.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	forged.Header["kid"] = ks.SigningKeyID()
+	forgedStr, _ := forged.SignedString(key.PublicKey.N.Bytes())
+	if _, err := ks.Parse(forgedStr); err == nil {
+		t.Error("expected alg-swapped token to be rejected")
+	}
+
+	hmacTok, _ := NewHMAC("secret").Sign(testClaims())
+	if _, err := ks.Parse(hmacTok); err == nil {
+		t.Error("expected HMAC token to be rejected by RSA set")
+	}
+}
+
+func TestLoadFiles_Errors(t *testing.T) {
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	smallPath := writePEM(t, "small.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(small))
+	if _, err := LoadFiles(smallPath); err == nil {
+		t.Error("expected error for 1024-bit RSA key")
+	}
+
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	pubDER, _ := x509.MarshalPKIXPublicKey(priv.Public())
+	pubPath := writePEM(t, "pub.pem", "PUBLIC KEY", pubDER)
+	if _, err := LoadFiles(pubPath); err == nil {
+		t.Error("expected error when signing key is public only")
+	}
+
+	if _, err := LoadFiles(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	if _, err := ParsePEM([]byte("not pem")); err == nil {
+		t.Error("expected error for non-PEM data")
+	}
+}
@@ -23,6 +23,12 @@ func (j RefreshJob) Key() string {
 	return fmt.Sprintf("%s:%d:%d", j.Code, j.Acadyear, j.Semester)
 }
 
+// term identifies the academic year and semester a job belongs to; only
+// jobs of the same term can share a batch call.
+type term struct {
+	acadyear, semester int
+}
+
 // JobResult holds the outcome of a processed refresh job.
 type JobResult struct {
 	Data interface{} // the refreshed entity (caller must type-assert)
@@ -113,6 +119,64 @@ func (q *RefreshQueue) Start(handler func(RefreshJob)) {
 	log.Printf("[queue] started %d workers (buffer=%d)", q.workers, cap(q.jobs))
 }
 
+// StartBatched is like Start, but each worker also takes up to maxBatch-1
+// jobs that are already waiting and hands them to handler grouped by
+// acadyear/semester, so one upstream call can serve the whole group. Jobs
+// are never held back to fill a batch.
+func (q *RefreshQueue) StartBatched(maxBatch int, handler func([]RefreshJob)) {
+	if maxBatch < 1 {
+		maxBatch = 1
+	}
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go func(id int) {
+			defer q.wg.Done()
+			log.Printf("[queue] worker %d started", id)
+			for job := range q.jobs {
+				for _, batch := range groupByTerm(q.drain(job, maxBatch)) {
+					handler(batch)
+				}
+			}
+			log.Printf("[queue] worker %d stopped", id)
+		}(i)
+	}
+	log.Printf("[queue] started %d batching workers (buffer=%d, batch=%d)", q.workers, cap(q.jobs), maxBatch)
+}
+
+// drain returns first plus up to max-1 jobs already in the queue.
+func (q *RefreshQueue) drain(first RefreshJob, max int) []RefreshJob {
+	jobs := []RefreshJob{first}
+	for len(jobs) < max {
+		select {
+		case job, ok := <-q.jobs:
+			if !ok {
+				return jobs
+			}
+			jobs = append(jobs, job)
+		default:
+			return jobs
+		}
+	}
+	return jobs
+}
+
+// groupByTerm splits jobs by acadyear/semester, keeping first-seen order.
+func groupByTerm(jobs []RefreshJob) [][]RefreshJob {
+	index := make(map[term]int)
+	var groups [][]RefreshJob
+	for _, job := range jobs {
+		t := term{job.Acadyear, job.Semester}
+		i, ok := index[t]
+		if !ok {
+			i = len(groups)
+			index[t] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], job)
+	}
+	return groups
+}
+
 // Stop closes the jobs channel and waits for all workers to finish.
 func (q *RefreshQueue) Stop() {
 	log.Println("[queue] stopping — waiting for workers to drain...")
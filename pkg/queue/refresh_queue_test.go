@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -179,6 +180,49 @@ func TestStart_WorkerProcessesAllJobs(t *testing.T) {
 	}
 }
 
+func TestStartBatched_GroupsPendingJobsByTerm(t *testing.T) {
+	q := New(10, 1)
+	// Enqueued before the worker starts, so they are all pending at once.
+	for _, job := range []RefreshJob{
+		{Code: "A1", Acadyear: 2568, Semester: 1},
+		{Code: "B1", Acadyear: 2568, Semester: 2},
+		{Code: "A2", Acadyear: 2568, Semester: 1},
+		{Code: "A3", Acadyear: 2568, Semester: 1},
+		{Code: "A4", Acadyear: 2568, Semester: 1},
+	} {
+		q.Enqueue(job)
+	}
+
+	batches := make(chan []string, 10)
+	q.StartBatched(4, func(jobs []RefreshJob) {
+		var codes []string
+		for _, job := range jobs {
+			codes = append(codes, job.Code)
+			q.MarkDone(job.Key())
+		}
+		batches <- codes
+	})
+
+	var got [][]string
+	for len(got) < 3 {
+		select {
+		case b := <-batches:
+			got = append(got, b)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for batches, got %v", got)
+		}
+	}
+	q.Stop()
+
+	want := [][]string{{"A1", "A2", "A3"}, {"B1"}, {"A4"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected batches %v, got %v", want, got)
+	}
+	if q.processed.Load() != 5 {
+		t.Errorf("expected processed=5, got %d", q.processed.Load())
+	}
+}
+
 // ---- Status tests ----
 
 func TestStatus_Empty(t *testing.T) {
@@ -0,0 +1,161 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by CircuitBreaker.Allow while calls are being rejected.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a CircuitBreaker.
+type State int
+
+const (
+	// StateClosed lets every call through and counts consecutive failures.
+	StateClosed State = iota
+	// StateOpen rejects every call until OpenTimeout has passed.
+	StateOpen
+	// StateHalfOpen lets a limited number of trial calls through.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerConfig holds the thresholds of a CircuitBreaker. Zero values are
+// replaced by the defaults in NewCircuitBreaker.
+type BreakerConfig struct {
+	FailureThreshold int           // consecutive failures that open the circuit (default 5)
+	OpenTimeout      time.Duration // time spent open before trial calls (default 30s)
+	HalfOpenMaxCalls int           // trial calls while half-open; all must succeed to close (default 1)
+}
+
+// BreakerStatus is a snapshot of a CircuitBreaker.
+type BreakerStatus struct {
+	State               State
+	ConsecutiveFailures int
+	OpenUntil           time.Time // zero unless open
+}
+
+// CircuitBreaker stops calling a failing dependency for a while, then lets a
+// few trial calls decide whether it has recovered.
+//
+// Every call allowed by Allow must be finished with exactly one of Success,
+// Failure or Ignore.
+type CircuitBreaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openedAt  time.Time
+	trials    int // trial calls allowed in the current half-open period
+	succeeded int // trial calls that succeeded
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls < 1 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+	return &CircuitBreaker{cfg: cfg, now: time.Now}
+}
+
+// Allow reports whether a call may proceed, returning ErrOpen if not.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen {
+		if b.now().Before(b.openedAt.Add(b.cfg.OpenTimeout)) {
+			return ErrOpen
+		}
+		b.state, b.trials, b.succeeded = StateHalfOpen, 0, 0
+	}
+	if b.state == StateHalfOpen {
+		if b.trials >= b.cfg.HalfOpenMaxCalls {
+			return ErrOpen
+		}
+		b.trials++
+	}
+	return nil
+}
+
+// Success records a call that reached a healthy dependency.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateClosed:
+		b.failures = 0
+	case StateHalfOpen:
+		b.succeeded++
+		if b.succeeded >= b.cfg.HalfOpenMaxCalls {
+			b.state, b.failures = StateClosed, 0
+		}
+	}
+}
+
+// Failure records a call that failed because of the dependency.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateClosed:
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.open()
+		}
+	case StateHalfOpen:
+		b.failures++
+		b.open()
+	}
+}
+
+// Ignore finishes a call whose outcome says nothing about the dependency,
+// such as one cancelled by the caller. A half-open trial slot is returned.
+func (b *CircuitBreaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.trials > b.succeeded {
+		b.trials--
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = StateOpen
+	b.openedAt = b.now()
+}
+
+// Status returns a snapshot of the breaker. An open breaker whose timeout
+// has passed still reports open until the next call is allowed.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := BreakerStatus{State: b.state, ConsecutiveFailures: b.failures}
+	if b.state == StateOpen {
+		st.OpenUntil = b.openedAt.Add(b.cfg.OpenTimeout)
+	}
+	return st
+}
@@ -0,0 +1,106 @@
+package resilience
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ---- CircuitBreaker tests ----
+
+func newTestBreaker(cfg BreakerConfig) (*CircuitBreaker, *time.Time) {
+	b := NewCircuitBreaker(cfg)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestNewCircuitBreaker_Defaults(t *testing.T) {
+	b := NewCircuitBreaker(BreakerConfig{})
+	if b.cfg.FailureThreshold != 5 || b.cfg.OpenTimeout != 30*time.Second || b.cfg.HalfOpenMaxCalls != 1 {
+		t.Errorf("unexpected defaults: %+v", b.cfg)
+	}
+	if st := b.Status(); st.State != StateClosed || st.State.String() != "closed" {
+		t.Errorf("expected closed, got %v", st.State)
+	}
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("call %d: unexpected error %v", i, err)
+		}
+		b.Failure()
+	}
+	// A success resets the count.
+	_ = b.Allow()
+	b.Success()
+	for i := 0; i < 3; i++ {
+		_ = b.Allow()
+		b.Failure()
+	}
+
+	st := b.Status()
+	if st.State != StateOpen || st.ConsecutiveFailures != 3 || st.OpenUntil.IsZero() {
+		t.Errorf("expected open after 3 failures, got %+v", st)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected ErrOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenTrials(t *testing.T) {
+	b, now := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenMaxCalls: 2})
+	_ = b.Allow()
+	b.Failure()
+
+	*now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected first trial call, got %v", err)
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected second trial call, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected trial calls to be limited, got %v", err)
+	}
+	if st := b.Status(); st.State != StateHalfOpen || st.State.String() != "half-open" {
+		t.Errorf("expected half-open, got %v", st.State)
+	}
+
+	b.Success()
+	b.Success()
+	if st := b.Status(); st.State != StateClosed || st.ConsecutiveFailures != 0 {
+		t.Errorf("expected closed after successful trials, got %+v", st)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	b, now := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	_ = b.Allow()
+	b.Failure()
+
+	*now = now.Add(time.Minute)
+	_ = b.Allow()
+	b.Failure()
+
+	st := b.Status()
+	if st.State != StateOpen || !st.OpenUntil.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected reopened until %v, got %+v", now.Add(time.Minute), st)
+	}
+}
+
+func TestCircuitBreaker_IgnoreFreesTrialSlot(t *testing.T) {
+	b, now := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	_ = b.Allow()
+	b.Failure()
+
+	*now = now.Add(time.Minute)
+	_ = b.Allow()
+	b.Ignore()
+	if err := b.Allow(); err != nil {
+		t.Errorf("expected the trial slot back after Ignore, got %v", err)
+	}
+}
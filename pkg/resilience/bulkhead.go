@@ -0,0 +1,55 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBulkheadFull is returned by Bulkhead.Acquire when no slot frees up
+// before the context is done.
+var ErrBulkheadFull = errors.New("too many concurrent calls")
+
+// Bulkhead limits how many calls to a dependency run at once, so a slow
+// dependency cannot tie up every caller.
+type Bulkhead struct {
+	slots chan struct{}
+}
+
+// NewBulkhead creates a Bulkhead allowing max concurrent calls (at least 1).
+func NewBulkhead(max int) *Bulkhead {
+	if max < 1 {
+		max = 1
+	}
+	return &Bulkhead{slots: make(chan struct{}, max)}
+}
+
+// Acquire waits for a free slot. Every successful Acquire must be paired
+// with a Release.
+func (b *Bulkhead) Acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ErrBulkheadFull
+	}
+}
+
+// Release frees a slot taken by Acquire.
+func (b *Bulkhead) Release() {
+	<-b.slots
+}
+
+// InFlight returns the number of slots in use.
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+// Capacity returns the maximum number of concurrent calls.
+func (b *Bulkhead) Capacity() int {
+	return cap(b.slots)
+}
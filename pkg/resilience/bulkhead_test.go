@@ -0,0 +1,50 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ---- Bulkhead tests ----
+
+func TestBulkhead_LimitsConcurrency(t *testing.T) {
+	b := NewBulkhead(2)
+	ctx := context.Background()
+	if err := b.Acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if b.InFlight() != 2 || b.Capacity() != 2 {
+		t.Errorf("expected 2/2 in flight, got %d/%d", b.InFlight(), b.Capacity())
+	}
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := b.Acquire(short); !errors.Is(err, ErrBulkheadFull) {
+		t.Errorf("expected ErrBulkheadFull, got %v", err)
+	}
+
+	b.Release()
+	if err := b.Acquire(ctx); err != nil {
+		t.Errorf("expected a free slot after Release, got %v", err)
+	}
+}
+
+func TestBulkhead_WaitsForRelease(t *testing.T) {
+	b := NewBulkhead(0) // clamped to 1
+	_ = b.Acquire(context.Background())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.Release()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := b.Acquire(ctx); err != nil {
+		t.Errorf("expected to acquire after release, got %v", err)
+	}
+}
@@ -13,7 +13,10 @@ const (
 	StatusNotFound            = 404
 	StatusConflict            = 409
 	StatusUnprocessableEntity = 422
+	StatusLocked              = 423
+	StatusTooManyRequests     = 429
 	StatusInternalServerError = 500
+	StatusServiceUnavailable  = 503
 )
 
 // Body is the standard API response envelope.
@@ -83,6 +86,19 @@ func Forbidden(r port.Responder, message string) error {
 	return errResponse(r, StatusForbidden, message)
 }
 
+// ForbiddenWithData sends a 403 error response carrying data the client
+// needs to resolve it.
+func ForbiddenWithData(r port.Responder, message string, data interface{}) error {
+	return r.Status(StatusForbidden).JSON(Body{
+		Success: false,
+		Data:    data,
+		Error: &ErrorBody{
+			Code:    StatusForbidden,
+			Message: message,
+		},
+	})
+}
+
 // NotFound sends a 404 error response.
 func NotFound(r port.Responder, message string) error {
 	return errResponse(r, StatusNotFound, message)
@@ -98,11 +114,34 @@ func UnprocessableEntity(r port.Responder, message string) error {
 	return errResponse(r, StatusUnprocessableEntity, message)
 }
 
+// Locked sends a 423 error response.
+func Locked(r port.Responder, message string) error {
+	return errResponse(r, StatusLocked, message)
+}
+
+// TooManyRequests sends a 429 error response.
+func TooManyRequests(r port.Responder, message string) error {
+	return errResponse(r, StatusTooManyRequests, message)
+}
+
 // InternalError sends a 500 error response.
 func InternalError(r port.Responder, message string) error {
 	return errResponse(r, StatusInternalServerError, message)
 }
 
+// ServiceUnavailableWithData sends a 503 error response carrying data that
+// explains the outage, e.g. a health report.
+func ServiceUnavailableWithData(r port.Responder, message string, data interface{}) error {
+	return r.Status(StatusServiceUnavailable).JSON(Body{
+		Success: false,
+		Data:    data,
+		Error: &ErrorBody{
+			Code:    StatusServiceUnavailable,
+			Message: message,
+		},
+	})
+}
+
 // ---------- validation helper ----------
 
 // ValidationError sends a 422 response with field-level validation errors.
@@ -212,6 +212,30 @@ func TestConflict(t *testing.T) {
 	}
 }
 
+func TestLocked(t *testing.T) {
+	m := newMock()
+	_ = Locked(m, "locked")
+	if m.statusCode != StatusLocked {
+		t.Errorf("expected status %d, got %d", StatusLocked, m.statusCode)
+	}
+	b := bodyAs(t, m)
+	if b.Error == nil || b.Error.Code != StatusLocked {
+		t.Errorf("expected error code %d", StatusLocked)
+	}
+}
+
+func TestTooManyRequests(t *testing.T) {
+	m := newMock()
+	_ = TooManyRequests(m, "slow down")
+	if m.statusCode != StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", StatusTooManyRequests, m.statusCode)
+	}
+	b := bodyAs(t, m)
+	if b.Error == nil || b.Error.Code != StatusTooManyRequests {
+		t.Errorf("expected error code %d", StatusTooManyRequests)
+	}
+}
+
 func TestUnprocessableEntity(t *testing.T) {
 	m := newMock()
 	_ = UnprocessableEntity(m, "bad entity")
@@ -258,6 +282,41 @@ func TestValidationError(t *testing.T) {
 	}
 }
 
+func TestForbiddenWithData(t *testing.T) {
+	m := newMock()
+	_ = ForbiddenWithData(m, "password change required", map[string]string{"token": "abc"})
+	if m.statusCode != StatusForbidden {
+		t.Errorf("expected status %d, got %d", StatusForbidden, m.statusCode)
+	}
+	b := bodyAs(t, m)
+	if b.Success {
+		t.Error("expected success=false")
+	}
+	if b.Error == nil || b.Error.Code != StatusForbidden || b.Error.Message != "password change required" {
+		t.Errorf("unexpected error body: %+v", b.Error)
+	}
+	d, ok := b.Data.(map[string]interface{})
+	if !ok || d["token"] != "abc" {
+		t.Errorf("expected data to contain token=abc, got %v", b.Data)
+	}
+}
+
+func TestServiceUnavailableWithData(t *testing.T) {
+	m := newMock()
+	_ = ServiceUnavailableWithData(m, "not ready", map[string]string{"status": "degraded"})
+	if m.statusCode != StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", StatusServiceUnavailable, m.statusCode)
+	}
+	b := bodyAs(t, m)
+	if b.Success || b.Error == nil || b.Error.Code != StatusServiceUnavailable || b.Error.Message != "not ready" {
+		t.Errorf("unexpected body: %+v", b)
+	}
+	d, ok := b.Data.(map[string]interface{})
+	if !ok || d["status"] != "degraded" {
+		t.Errorf("expected data to contain status=degraded, got %v", b.Data)
+	}
+}
+
 // ---------- Success body structure ----------
 
 func TestOK_DataIsPresent(t *testing.T) {
@@ -3,18 +3,25 @@ package scheduler
 import (
 	"log"
 	"sync"
+	"time"
 
 	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/entity"
 	"github.com/CPNext-hub/calendar-reg-main-api/pkg/queue"
 	"github.com/robfig/cron/v3"
 )
 
+// defaultRunTimeout bounds how long a run waits for its courses to be
+// refreshed; the ones still outstanding then count as failed.
+const defaultRunTimeout = 10 * time.Minute
+
 // Scheduler wraps robfig/cron and manages dynamic cron job registration.
 type Scheduler struct {
 	c            *cron.Cron
 	mu           sync.Mutex
 	entries      map[string]cron.EntryID // jobID → cron entryID
 	refreshQueue *queue.RefreshQueue
+	onRun        func(entity.CronJobRun)
+	runTimeout   time.Duration
 }
 
 // New creates a new Scheduler.
@@ -23,9 +30,18 @@ func New(refreshQueue *queue.RefreshQueue) *Scheduler {
 		c:            cron.New(),
 		entries:      make(map[string]cron.EntryID),
 		refreshQueue: refreshQueue,
+		runTimeout:   defaultRunTimeout,
 	}
 }
 
+// OnRunCompleted registers fn to be called, from its own goroutine, once
+// every course of a run has been refreshed or has failed.
+func (s *Scheduler) OnRunCompleted(fn func(entity.CronJobRun)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRun = fn
+}
+
 // Start begins the cron scheduler.
 func (s *Scheduler) Start() {
 	s.c.Start()
@@ -93,44 +109,93 @@ func (s *Scheduler) LoadJobs(jobs []*entity.CronJob) {
 // TriggerJob immediately enqueues all course codes for the given job.
 func (s *Scheduler) TriggerJob(job *entity.CronJob) {
 	log.Printf("[scheduler] manually triggering job %s (%s)", job.ID, job.Name)
-	s.enqueueCourseCodes(job)
+	s.enqueueCourseCodes(job, true)
 }
 
 // makeHandler creates the function called by cron for a specific job.
 func (s *Scheduler) makeHandler(job *entity.CronJob) func() {
 	// Capture job data at registration time.
-	jobID := job.ID
-	jobName := job.Name
-	codes := make([]string, len(job.CourseCodes))
-	copy(codes, job.CourseCodes)
-	acadyear := job.Acadyear
-	semester := job.Semester
+	snapshot := *job
+	snapshot.CourseCodes = make([]string, len(job.CourseCodes))
+	copy(snapshot.CourseCodes, job.CourseCodes)
 
 	return func() {
-		log.Printf("[scheduler] executing job %s (%s): refreshing %d courses", jobID, jobName, len(codes))
-		for _, code := range codes {
-			s.refreshQueue.Enqueue(queue.RefreshJob{
-				Code:     code,
-				Acadyear: acadyear,
-				Semester: semester,
-				IsNew:    false,
-			})
-		}
+		log.Printf("[scheduler] executing job %s (%s): refreshing %d courses", snapshot.ID, snapshot.Name, len(snapshot.CourseCodes))
+		s.enqueueCourseCodes(&snapshot, false)
 	}
 }
 
-// enqueueCourseCodes enqueues all course codes for a job.
-func (s *Scheduler) enqueueCourseCodes(job *entity.CronJob) {
+// enqueueCourseCodes enqueues all course codes for a job and, when a run
+// hook is registered, reports the run once the refreshes finish.
+func (s *Scheduler) enqueueCourseCodes(job *entity.CronJob, manual bool) {
+	s.mu.Lock()
+	onRun := s.onRun
+	s.mu.Unlock()
+
+	run := entity.CronJobRun{
+		JobID:     job.ID,
+		JobName:   job.Name,
+		Manual:    manual,
+		Acadyear:  job.Acadyear,
+		Semester:  job.Semester,
+		StartedAt: time.Now(),
+	}
+	var results chan queue.JobResult
+	if onRun != nil {
+		// Buffered so workers never block on a run that stopped waiting.
+		results = make(chan queue.JobResult, len(job.CourseCodes))
+	}
+
+	enqueued := 0
 	for _, code := range job.CourseCodes {
-		s.refreshQueue.Enqueue(queue.RefreshJob{
+		rj := queue.RefreshJob{
 			Code:     code,
 			Acadyear: job.Acadyear,
 			Semester: job.Semester,
 			IsNew:    false,
-		})
+		}
+		if results != nil {
+			rj.Result = results
+		}
+		if s.refreshQueue.Enqueue(rj) {
+			enqueued++
+		} else {
+			run.Skipped++
+		}
+	}
+
+	if onRun != nil {
+		go s.awaitRun(run, enqueued, results, onRun)
 	}
 }
 
+// awaitRun collects the results of a run's enqueued refreshes and hands the
+// summary to onRun.
+func (s *Scheduler) awaitRun(run entity.CronJobRun, enqueued int, results <-chan queue.JobResult, onRun func(entity.CronJobRun)) {
+	timeout := time.NewTimer(s.runTimeout)
+	defer timeout.Stop()
+
+	for pending := enqueued; pending > 0; {
+		select {
+		case res := <-results:
+			pending--
+			if res.Err != nil {
+				run.Failed++
+			} else {
+				run.Refreshed++
+			}
+		case <-timeout.C:
+			log.Printf("[scheduler] job %s (%s): %d refreshes still running after %s", run.JobID, run.JobName, pending, s.runTimeout)
+			run.Failed += pending
+			pending = 0
+		}
+	}
+
+	run.CompletedAt = time.Now()
+	log.Printf("[scheduler] job %s (%s) run completed: %d refreshed, %d failed, %d skipped", run.JobID, run.JobName, run.Refreshed, run.Failed, run.Skipped)
+	onRun(run)
+}
+
 // ValidateCronExpr validates a cron expression.
 func ValidateCronExpr(expr string) error {
 	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
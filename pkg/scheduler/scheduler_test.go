@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -300,6 +301,74 @@ func TestMakeHandler(t *testing.T) {
 	}
 }
 
+// ---- OnRunCompleted ----
+
+func TestOnRunCompleted_SummarisesRun(t *testing.T) {
+	q := queue.New(100, 2)
+	s := New(q)
+	runs := make(chan entity.CronJobRun, 1)
+	s.OnRunCompleted(func(run entity.CronJobRun) { runs <- run })
+
+	// "CS101" stays in flight until the run is over, so the run skips it.
+	release := make(chan struct{})
+	q.Enqueue(queue.RefreshJob{Code: "CS101", Acadyear: 2568, Semester: 1})
+	q.Start(func(job queue.RefreshJob) {
+		if job.Result == nil {
+			<-release
+		} else {
+			var err error
+			if job.Code == "CS103" {
+				err = errors.New("upstream down")
+			}
+			job.Result <- queue.JobResult{Err: err}
+		}
+		q.MarkDone(job.Key())
+	})
+	defer q.Stop()
+
+	s.TriggerJob(&entity.CronJob{
+		BaseEntity:  entity.BaseEntity{ID: "job1"},
+		Name:        "Run Me",
+		CourseCodes: []string{"CS101", "CS102", "CS103"},
+		Acadyear:    2568,
+		Semester:    1,
+	})
+
+	defer close(release)
+	select {
+	case run := <-runs:
+		if run.JobID != "job1" || !run.Manual || run.Refreshed != 1 || run.Failed != 1 || run.Skipped != 1 {
+			t.Errorf("unexpected run: %+v", run)
+		}
+		if run.CompletedAt.Before(run.StartedAt) {
+			t.Errorf("completed %v before started %v", run.CompletedAt, run.StartedAt)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the run hook")
+	}
+}
+
+func TestOnRunCompleted_TimesOut(t *testing.T) {
+	s, _ := newTestScheduler() // no workers: nothing is ever refreshed
+	s.runTimeout = 20 * time.Millisecond
+	runs := make(chan entity.CronJobRun, 1)
+	s.OnRunCompleted(func(run entity.CronJobRun) { runs <- run })
+
+	s.makeHandler(&entity.CronJob{
+		BaseEntity:  entity.BaseEntity{ID: "job1"},
+		CourseCodes: []string{"A", "B"},
+	})()
+
+	select {
+	case run := <-runs:
+		if run.Manual || run.Refreshed != 0 || run.Failed != 2 {
+			t.Errorf("unexpected run: %+v", run)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the run hook")
+	}
+}
+
 // ---- ValidateCronExpr ----
 
 func TestValidateCronExpr_Valid(t *testing.T) {
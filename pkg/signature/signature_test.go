@@ -0,0 +1,21 @@
+package signature
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	// echo -n 'hello' | openssl dgst -sha256 -hmac 'key'
+	want := "sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b"
+	if got := Sign("key", []byte("hello")); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	sig := Sign("key", []byte("hello"))
+	if !Verify("key", []byte("hello"), sig) {
+		t.Error("expected a valid signature to verify")
+	}
+	if Verify("other", []byte("hello"), sig) || Verify("key", []byte("hello!"), sig) || Verify("key", []byte("hello"), "") {
+		t.Error("expected a wrong secret, body or signature to fail")
+	}
+}
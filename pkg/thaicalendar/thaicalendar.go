@@ -0,0 +1,206 @@
+// Package thaicalendar converts between the Thai Buddhist Era (พ.ศ.) and the
+// Common Era (ค.ศ.), and parses/formats the Thai date strings emitted by the
+// university registrar.
+package thaicalendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EraOffset is the number of years between the Buddhist Era and the Common Era.
+const EraOffset = 543
+
+// beThreshold separates BE years from CE years when the era is ambiguous.
+// Any year at or above this value is assumed to already be BE.
+const beThreshold = 2400
+
+// DateTimeLayout is the CE layout used for stored exam timestamps ("2026-03-31 13:00:00").
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+// Location is the time zone registrar dates are expressed in (Asia/Bangkok, UTC+7, no DST).
+var Location = loadLocation()
+
+func loadLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		return time.FixedZone("ICT", 7*60*60)
+	}
+	return loc
+}
+
+// ---------- Year conversion ----------
+
+// ToCE converts a Buddhist Era year to a Common Era year (2569 → 2026).
+func ToCE(yearBE int) int {
+	return yearBE - EraOffset
+}
+
+// ToBE converts a Common Era year to a Buddhist Era year (2026 → 2569).
+func ToBE(yearCE int) int {
+	return yearCE + EraOffset
+}
+
+// NormalizeToBE accepts a year in either era and returns it in BE.
+// Years below 2400 are treated as CE; zero is returned unchanged.
+func NormalizeToBE(year int) int {
+	if year <= 0 || year >= beThreshold {
+		return year
+	}
+	return ToBE(year)
+}
+
+// ---------- Era ----------
+
+// Era selects which calendar years are expressed in.
+type Era string
+
+const (
+	EraBE Era = "be" // พุทธศักราช (default — matches stored data)
+	EraCE Era = "ce" // คริสต์ศักราช
+)
+
+// ParseEra parses an era name ("be"/"ce", case-insensitive). Empty means EraBE.
+func ParseEra(s string) (Era, error) {
+	switch Era(strings.ToLower(strings.TrimSpace(s))) {
+	case "", EraBE:
+		return EraBE, nil
+	case EraCE:
+		return EraCE, nil
+	default:
+		return "", fmt.Errorf("invalid era %q: must be 'be' or 'ce'", s)
+	}
+}
+
+// FromBE converts a BE year into this era.
+func (e Era) FromBE(yearBE int) int {
+	if e == EraCE && yearBE != 0 {
+		return ToCE(yearBE)
+	}
+	return yearBE
+}
+
+// ---------- Month names ----------
+
+var shortMonths = [...]string{
+	"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.",
+	"ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค.",
+}
+
+var longMonths = [...]string{
+	"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน",
+	"กรกฎาคม", "สิงหาคม", "กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม",
+}
+
+// ShortMonth returns the abbreviated Thai month name, e.g. "มี.ค.".
+func ShortMonth(m time.Month) string {
+	if m < time.January || m > time.December {
+		return ""
+	}
+	return shortMonths[m-1]
+}
+
+// LongMonth returns the full Thai month name, e.g. "มีนาคม".
+func LongMonth(m time.Month) string {
+	if m < time.January || m > time.December {
+		return ""
+	}
+	return longMonths[m-1]
+}
+
+// ParseMonth parses a Thai month name in either abbreviated or full form.
+// Returns 0 when the name is not recognised.
+func ParseMonth(name string) time.Month {
+	name = strings.TrimSpace(name)
+	for i := range shortMonths {
+		if name == shortMonths[i] || name == longMonths[i] {
+			return time.Month(i + 1)
+		}
+	}
+	return 0
+}
+
+// ---------- Formatting ----------
+
+// FormatShort formats t as "31 มี.ค. 2569" (BE year, Bangkok time).
+func FormatShort(t time.Time) string {
+	t = t.In(Location)
+	return fmt.Sprintf("%d %s %d", t.Day(), ShortMonth(t.Month()), ToBE(t.Year()))
+}
+
+// FormatLong formats t as "31 มีนาคม 2569" (BE year, Bangkok time).
+func FormatLong(t time.Time) string {
+	t = t.In(Location)
+	return fmt.Sprintf("%d %s %d", t.Day(), LongMonth(t.Month()), ToBE(t.Year()))
+}
+
+// ---------- Parsing ----------
+
+// ParseDate parses a Thai date such as "31 มี.ค. 2569" or "31 มีนาคม 2569"
+// and returns midnight of that day in Location.
+func ParseDate(s string) (time.Time, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date format")
+	}
+
+	day, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day: %v", err)
+	}
+
+	month := ParseMonth(fields[1])
+	if month == 0 {
+		return time.Time{}, fmt.Errorf("invalid month: %s", fields[1])
+	}
+
+	year, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid year: %v", err)
+	}
+
+	date := time.Date(ToCE(NormalizeToBE(year)), month, day, 0, 0, 0, 0, Location)
+	if day < 1 || date.Day() != day || date.Month() != month {
+		return time.Time{}, fmt.Errorf("invalid day: %d %s", day, fields[1])
+	}
+	return date, nil
+}
+
+// ParseExamDate parses registrar exam strings like "31 มี.ค. 2569 เวลา 13:00 - 16:00"
+// and returns the start and end instants in Location.
+func ParseExamDate(s string) (time.Time, time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	parts := strings.Split(s, " เวลา ")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid format: missing ' เวลา ' separator")
+	}
+
+	date, err := ParseDate(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	times := strings.Split(strings.TrimSpace(parts[1]), "-")
+	if len(times) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time range format")
+	}
+
+	startT, err := time.Parse("15:04", strings.TrimSpace(times[0]))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time: %v", err)
+	}
+	endT, err := time.Parse("15:04", strings.TrimSpace(times[1]))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end time: %v", err)
+	}
+
+	start := date.Add(time.Duration(startT.Hour())*time.Hour + time.Duration(startT.Minute())*time.Minute)
+	end := date.Add(time.Duration(endT.Hour())*time.Hour + time.Duration(endT.Minute())*time.Minute)
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time range: ends before it starts")
+	}
+	return start, end, nil
+}
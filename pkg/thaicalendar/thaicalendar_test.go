@@ -0,0 +1,224 @@
+package thaicalendar
+
+import (
+	"testing"
+	"time"
+)
+
+// ---- Year conversion ----
+
+func TestToCEAndToBE(t *testing.T) {
+	if got := ToCE(2569); got != 2026 {
+		t.Errorf("ToCE(2569) = %d, want 2026", got)
+	}
+	if got := ToBE(2026); got != 2569 {
+		t.Errorf("ToBE(2026) = %d, want 2569", got)
+	}
+}
+
+func TestNormalizeToBE(t *testing.T) {
+	tests := []struct {
+		input    int
+		expected int
+	}{
+		{2568, 2568}, // already BE
+		{2025, 2568}, // CE → BE
+		{0, 0},       // unset stays unset
+		{-1, -1},
+	}
+	for _, tc := range tests {
+		if got := NormalizeToBE(tc.input); got != tc.expected {
+			t.Errorf("NormalizeToBE(%d) = %d, want %d", tc.input, got, tc.expected)
+		}
+	}
+}
+
+// ---- Era ----
+
+func TestParseEra(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Era
+		wantErr  bool
+	}{
+		{"", EraBE, false},
+		{"be", EraBE, false},
+		{"CE", EraCE, false},
+		{" ce ", EraCE, false},
+		{"ad", "", true},
+	}
+	for _, tc := range tests {
+		got, err := ParseEra(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseEra(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+		}
+		if got != tc.expected {
+			t.Errorf("ParseEra(%q) = %q, want %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestEra_FromBE(t *testing.T) {
+	if got := EraBE.FromBE(2568); got != 2568 {
+		t.Errorf("EraBE.FromBE(2568) = %d, want 2568", got)
+	}
+	if got := EraCE.FromBE(2568); got != 2025 {
+		t.Errorf("EraCE.FromBE(2568) = %d, want 2025", got)
+	}
+	if got := EraCE.FromBE(0); got != 0 {
+		t.Errorf("EraCE.FromBE(0) = %d, want 0", got)
+	}
+}
+
+// ---- Month names ----
+
+func TestParseMonth_AllMonths(t *testing.T) {
+	tests := []struct {
+		short    string
+		long     string
+		expected time.Month
+	}{
+		{"ม.ค.", "มกราคม", time.January},
+		{"ก.พ.", "กุมภาพันธ์", time.February},
+		{"มี.ค.", "มีนาคม", time.March},
+		{"เม.ย.", "เมษายน", time.April},
+		{"พ.ค.", "พฤษภาคม", time.May},
+		{"มิ.ย.", "มิถุนายน", time.June},
+		{"ก.ค.", "กรกฎาคม", time.July},
+		{"ส.ค.", "สิงหาคม", time.August},
+		{"ก.ย.", "กันยายน", time.September},
+		{"ต.ค.", "ตุลาคม", time.October},
+		{"พ.ย.", "พฤศจิกายน", time.November},
+		{"ธ.ค.", "ธันวาคม", time.December},
+	}
+
+	for _, tc := range tests {
+		if got := ParseMonth(tc.short); got != tc.expected {
+			t.Errorf("ParseMonth(%q) = %v, want %v", tc.short, got, tc.expected)
+		}
+		if got := ParseMonth(tc.long); got != tc.expected {
+			t.Errorf("ParseMonth(%q) = %v, want %v", tc.long, got, tc.expected)
+		}
+		if got := ShortMonth(tc.expected); got != tc.short {
+			t.Errorf("ShortMonth(%v) = %q, want %q", tc.expected, got, tc.short)
+		}
+		if got := LongMonth(tc.expected); got != tc.long {
+			t.Errorf("LongMonth(%v) = %q, want %q", tc.expected, got, tc.long)
+		}
+	}
+}
+
+func TestParseMonth_Unknown(t *testing.T) {
+	if got := ParseMonth("unknown"); got != 0 {
+		t.Errorf("expected 0 for unknown month, got %v", got)
+	}
+}
+
+func TestMonthNames_OutOfRange(t *testing.T) {
+	if ShortMonth(0) != "" || LongMonth(13) != "" {
+		t.Error("expected empty names for out-of-range months")
+	}
+}
+
+// ---- Formatting ----
+
+func TestFormatShortAndLong(t *testing.T) {
+	ts := time.Date(2026, time.March, 31, 13, 0, 0, 0, Location)
+	if got := FormatShort(ts); got != "31 มี.ค. 2569" {
+		t.Errorf("FormatShort = %q", got)
+	}
+	if got := FormatLong(ts); got != "31 มีนาคม 2569" {
+		t.Errorf("FormatLong = %q", got)
+	}
+}
+
+func TestFormatShort_ConvertsToBangkok(t *testing.T) {
+	// 2026-03-30 20:00 UTC is already 31 March in Bangkok.
+	ts := time.Date(2026, time.March, 30, 20, 0, 0, 0, time.UTC)
+	if got := FormatShort(ts); got != "31 มี.ค. 2569" {
+		t.Errorf("FormatShort = %q", got)
+	}
+}
+
+// ---- ParseDate ----
+
+func TestParseDate_LeapDay(t *testing.T) {
+	// 2567 BE is 2024 CE, a leap year; 2569 BE is 2026 CE, which is not.
+	got, err := ParseDate("29 ก.พ. 2567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Format(DateTimeLayout) != "2024-02-29 00:00:00" {
+		t.Errorf("expected 2024-02-29 00:00:00, got %s", got.Format(DateTimeLayout))
+	}
+	if _, err := ParseDate("29 ก.พ. 2569"); err == nil {
+		t.Error("expected error for 29 February in a non-leap year")
+	}
+}
+
+// ---- ParseExamDate ----
+
+func TestParseExamDate_ValidDate(t *testing.T) {
+	start, end, err := ParseExamDate("31 มี.ค. 2569 เวลา 13:00 - 16:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := start.Format(DateTimeLayout); got != "2026-03-31 13:00:00" {
+		t.Errorf("expected start 2026-03-31 13:00:00, got %s", got)
+	}
+	if got := end.Format(DateTimeLayout); got != "2026-03-31 16:00:00" {
+		t.Errorf("expected end 2026-03-31 16:00:00, got %s", got)
+	}
+	if start.Location() != Location {
+		t.Errorf("expected start in %v, got %v", Location, start.Location())
+	}
+}
+
+func TestParseExamDate_LongMonthAndCEYear(t *testing.T) {
+	start, _, err := ParseExamDate("15 กุมภาพันธ์ 2027 เวลา 09:00 - 12:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := start.Format(DateTimeLayout); got != "2027-02-15 09:00:00" {
+		t.Errorf("expected 2027-02-15 09:00:00, got %s", got)
+	}
+}
+
+func TestParseExamDate_WithWhitespace(t *testing.T) {
+	start, end, err := ParseExamDate("  15 ก.พ. 2570 เวลา 09:00 - 12:00  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := start.Format(DateTimeLayout); got != "2027-02-15 09:00:00" {
+		t.Errorf("expected start 2027-02-15 09:00:00, got %s", got)
+	}
+	if got := end.Format(DateTimeLayout); got != "2027-02-15 12:00:00" {
+		t.Errorf("expected end 2027-02-15 12:00:00, got %s", got)
+	}
+}
+
+func TestParseExamDate_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing separator", "31 มี.ค. 2569 13:00 - 16:00"},
+		{"too few date fields", "31 2569 เวลา 13:00 - 16:00"},
+		{"too many date fields", "31 มี.ค. 2569 Extra เวลา 13:00 - 16:00"},
+		{"invalid day", "XX มี.ค. 2569 เวลา 13:00 - 16:00"},
+		{"invalid month", "31 zzz. 2569 เวลา 13:00 - 16:00"},
+		{"invalid year", "31 มี.ค. YYYY เวลา 13:00 - 16:00"},
+		{"missing time range", "31 มี.ค. 2569 เวลา 13:00"},
+		{"invalid start time", "31 มี.ค. 2569 เวลา XX:XX - 16:00"},
+		{"invalid end time", "31 มี.ค. 2569 เวลา 13:00 - XX:XX"},
+		{"day past end of month", "31 ก.พ. 2569 เวลา 13:00 - 16:00"},
+		{"day zero", "0 มี.ค. 2569 เวลา 13:00 - 16:00"},
+		{"end before start", "31 มี.ค. 2569 เวลา 16:00 - 13:00"},
+	}
+	for _, tc := range tests {
+		if _, _, err := ParseExamDate(tc.input); err == nil {
+			t.Errorf("%s: expected error for %q", tc.name, tc.input)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Rule checks a single value against an optional tag parameter and returns
+// an error message, or "" when the value is valid.
+type Rule func(value interface{}, param string) string
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]Rule{}
+)
+
+// Register adds a named rule usable in `validate` tags. Registering an
+// existing name replaces it.
+func Register(name string, rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = rule
+}
+
+func lookup(name string) (Rule, bool) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	r, ok := rules[name]
+	return r, ok
+}
+
+// Struct validates v (a struct or pointer to struct) using `validate` tags
+// and returns every failure. Field paths use json names, e.g.
+// "sections[0].number". Nested structs and slices of structs are walked.
+//
+// Built-in rules:
+//
+//	required   value must be non-zero (non-empty for strings/slices)
+//	omitempty  skip remaining rules when the value is zero
+//	min=N      numbers ≥ N; strings/slices have at least N characters/items
+//	max=N      numbers ≤ N; strings/slices have at most N characters/items
+//	oneof=a b  value must equal one of the space-separated options
+//	dive       apply the remaining rules to every slice element
+//
+// Other names are looked up in the rules added with Register.
+func Struct(v interface{}) Errors {
+	var errs Errors
+	walkStruct(reflect.ValueOf(v), "", &errs)
+	return errs
+}
+
+func walkStruct(v reflect.Value, prefix string, errs *Errors) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		path := joinPath(prefix, fieldName(f))
+		fv := v.Field(i)
+
+		if tag := f.Tag.Get("validate"); tag != "" && tag != "-" {
+			applyRules(fv, path, strings.Split(tag, ","), errs)
+		}
+		walkNested(fv, path, errs)
+	}
+}
+
+// walkNested descends into struct fields and slices of structs.
+func walkNested(v reflect.Value, path string, errs *Errors) {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Ptr:
+		walkStruct(v, path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkStruct(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+func applyRules(v reflect.Value, path string, tags []string, errs *Errors) {
+	for i, tag := range tags {
+		name, param, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		switch name {
+		case "":
+			continue
+		case "omitempty":
+			if v.IsZero() || isEmpty(v) {
+				return
+			}
+			continue
+		case "dive":
+			if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+				for j := 0; j < v.Len(); j++ {
+					applyRules(v.Index(j), fmt.Sprintf("%s[%d]", path, j), tags[i+1:], errs)
+				}
+			}
+			return
+		}
+
+		if msg := check(v, name, param); msg != "" {
+			errs.Add(path, msg)
+			return // one message per field
+		}
+	}
+}
+
+func check(v reflect.Value, name, param string) string {
+	switch name {
+	case "required":
+		if isEmpty(v) {
+			return "is required"
+		}
+	case "min":
+		return checkBound(v, param, true)
+	case "max":
+		return checkBound(v, param, false)
+	case "oneof":
+		s := fmt.Sprint(v.Interface())
+		for _, opt := range strings.Fields(param) {
+			if s == opt {
+				return ""
+			}
+		}
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	default:
+		rule, ok := lookup(name)
+		if !ok {
+			panic(fmt.Sprintf("validation: unknown rule %q", name))
+		}
+		return rule(v.Interface(), param)
+	}
+	return ""
+}
+
+func checkBound(v reflect.Value, param string, isMin bool) string {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validation: invalid bound %q", param))
+	}
+
+	word, unit := "most", ""
+	if isMin {
+		word = "least"
+	}
+
+	var got float64
+	switch v.Kind() {
+	case reflect.String:
+		got, unit = float64(len([]rune(v.String()))), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		got, unit = float64(v.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		got = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		got = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		got = v.Float()
+	default:
+		return ""
+	}
+
+	if (isMin && got < n) || (!isMin && got > n) {
+		if unit != "" {
+			return fmt.Sprintf("must have at %s %s%s", word, param, unit)
+		}
+		return fmt.Sprintf("must be at %s %s", word, param)
+	}
+	return ""
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// fieldName returns the json name of f, falling back to the Go name.
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,45 @@
+// Package validation collects field-level validation errors so they can be
+// returned together (e.g. through response.ValidationError).
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a single invalid field. Field is a JSON path such as
+// "sections[0].schedules[1].time".
+type FieldError struct {
+	Field   string `json:"field" example:"sections[0].number"`
+	Message string `json:"message" example:"is required"`
+}
+
+// Errors is a list of field errors. A nil or empty Errors means valid.
+type Errors []FieldError
+
+// Add appends an error for field.
+func (e *Errors) Add(field, message string) {
+	*e = append(*e, FieldError{Field: field, Message: message})
+}
+
+// Addf appends an error for field with a formatted message.
+func (e *Errors) Addf(field, format string, args ...interface{}) {
+	e.Add(field, fmt.Sprintf(format, args...))
+}
+
+// Err returns e as an error, or nil when there are no errors.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
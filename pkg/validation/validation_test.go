@@ -0,0 +1,126 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrors_EmptyIsNil(t *testing.T) {
+	var errs Errors
+	if err := errs.Err(); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+}
+
+func TestErrors_AddAndAs(t *testing.T) {
+	var errs Errors
+	errs.Add("code", "is required")
+	errs.Addf("sections[0].schedules[1].time", "invalid time range %q", "xx")
+
+	err := errs.Err()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var got Errors
+	if !errors.As(err, &got) {
+		t.Fatal("expected errors.As to find Errors")
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 field errors, got %d", len(got))
+	}
+	if got[1].Field != "sections[0].schedules[1].time" {
+		t.Errorf("unexpected field %q", got[1].Field)
+	}
+
+	want := `code: is required; sections[0].schedules[1].time: invalid time range "xx"`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+type testItem struct {
+	Number string `json:"number" validate:"required"`
+}
+
+type testRequest struct {
+	Code     string     `json:"code" validate:"required,upper"`
+	Semester int        `json:"semester" validate:"min=1,max=3"`
+	Password string     `json:"password" validate:"required,min=6"`
+	Role     string     `json:"role" validate:"omitempty,oneof=admin student"`
+	Tags     []string   `json:"tags" validate:"dive,upper"`
+	Items    []testItem `json:"items" validate:"required"`
+	Nested   *testItem  `json:"nested"`
+}
+
+func init() {
+	Register("upper", func(v interface{}, _ string) string {
+		if s, _ := v.(string); s != strings.ToUpper(s) {
+			return "must be upper case"
+		}
+		return ""
+	})
+}
+
+func TestStruct_Valid(t *testing.T) {
+	req := testRequest{
+		Code: "CP", Semester: 2, Password: "secret1",
+		Tags:  []string{"A"},
+		Items: []testItem{{Number: "01"}},
+	}
+	if errs := Struct(&req); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestStruct_FieldErrors(t *testing.T) {
+	req := testRequest{
+		Code:     "cp",
+		Semester: 4,
+		Password: "abc",
+		Role:     "root",
+		Tags:     []string{"A", "b"},
+		Items:    []testItem{{Number: "01"}, {}},
+		Nested:   &testItem{},
+	}
+
+	got := map[string]string{}
+	for _, fe := range Struct(req) {
+		got[fe.Field] = fe.Message
+	}
+
+	want := map[string]string{
+		"code":            "must be upper case",
+		"semester":        "must be at most 3",
+		"password":        "must have at least 6 characters",
+		"role":            "must be one of: admin, student",
+		"tags[1]":         "must be upper case",
+		"items[1].number": "is required",
+		"nested.number":   "is required",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), got)
+	}
+	for field, msg := range want {
+		if got[field] != msg {
+			t.Errorf("%s: got %q, want %q", field, got[field], msg)
+		}
+	}
+}
+
+func TestStruct_Required(t *testing.T) {
+	errs := Struct(testRequest{Semester: 1})
+	fields := map[string]bool{}
+	for _, fe := range errs {
+		fields[fe.Field] = true
+	}
+	for _, f := range []string{"code", "password", "items"} {
+		if !fields[f] {
+			t.Errorf("expected required error for %s, got %v", f, errs)
+		}
+	}
+	if fields["role"] {
+		t.Error("omitempty field should not be validated when empty")
+	}
+}
@@ -197,6 +197,170 @@ func (x *FetchByCodeResponse) GetDepartment() string {
 	return ""
 }
 
+type FetchByCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []string               `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	Acadyear      int32                  `protobuf:"varint,2,opt,name=acadyear,proto3" json:"acadyear,omitempty"` // e.g. 2568
+	Semester      int32                  `protobuf:"varint,3,opt,name=semester,proto3" json:"semester,omitempty"` // e.g. 1, 2, 3
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchByCodesRequest) Reset() {
+	*x = FetchByCodesRequest{}
+	mi := &file_course_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchByCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchByCodesRequest) ProtoMessage() {}
+
+func (x *FetchByCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_course_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchByCodesRequest.ProtoReflect.Descriptor instead.
+func (*FetchByCodesRequest) Descriptor() ([]byte, []int) {
+	return file_course_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FetchByCodesRequest) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *FetchByCodesRequest) GetAcadyear() int32 {
+	if x != nil {
+		return x.Acadyear
+	}
+	return 0
+}
+
+func (x *FetchByCodesRequest) GetSemester() int32 {
+	if x != nil {
+		return x.Semester
+	}
+	return 0
+}
+
+type FetchByCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Courses       []*FetchByCodeResponse `protobuf:"bytes,1,rep,name=courses,proto3" json:"courses,omitempty"`
+	NotFound      []string               `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchByCodesResponse) Reset() {
+	*x = FetchByCodesResponse{}
+	mi := &file_course_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchByCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchByCodesResponse) ProtoMessage() {}
+
+func (x *FetchByCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_course_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchByCodesResponse.ProtoReflect.Descriptor instead.
+func (*FetchByCodesResponse) Descriptor() ([]byte, []int) {
+	return file_course_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FetchByCodesResponse) GetCourses() []*FetchByCodeResponse {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+func (x *FetchByCodesResponse) GetNotFound() []string {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+type FetchByCodesItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Course        *FetchByCodeResponse   `protobuf:"bytes,2,opt,name=course,proto3" json:"course,omitempty"` // unset when the course does not exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchByCodesItem) Reset() {
+	*x = FetchByCodesItem{}
+	mi := &file_course_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchByCodesItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchByCodesItem) ProtoMessage() {}
+
+func (x *FetchByCodesItem) ProtoReflect() protoreflect.Message {
+	mi := &file_course_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchByCodesItem.ProtoReflect.Descriptor instead.
+func (*FetchByCodesItem) Descriptor() ([]byte, []int) {
+	return file_course_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FetchByCodesItem) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *FetchByCodesItem) GetCourse() *FetchByCodeResponse {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
 type Section struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
@@ -215,7 +379,7 @@ type Section struct {
 
 func (x *Section) Reset() {
 	*x = Section{}
-	mi := &file_course_service_proto_msgTypes[2]
+	mi := &file_course_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,7 +391,7 @@ func (x *Section) String() string {
 func (*Section) ProtoMessage() {}
 
 func (x *Section) ProtoReflect() protoreflect.Message {
-	mi := &file_course_service_proto_msgTypes[2]
+	mi := &file_course_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +404,7 @@ func (x *Section) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Section.ProtoReflect.Descriptor instead.
 func (*Section) Descriptor() ([]byte, []int) {
-	return file_course_service_proto_rawDescGZIP(), []int{2}
+	return file_course_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Section) GetNumber() string {
@@ -325,7 +489,7 @@ type Schedule struct {
 
 func (x *Schedule) Reset() {
 	*x = Schedule{}
-	mi := &file_course_service_proto_msgTypes[3]
+	mi := &file_course_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +501,7 @@ func (x *Schedule) String() string {
 func (*Schedule) ProtoMessage() {}
 
 func (x *Schedule) ProtoReflect() protoreflect.Message {
-	mi := &file_course_service_proto_msgTypes[3]
+	mi := &file_course_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +514,7 @@ func (x *Schedule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
 func (*Schedule) Descriptor() ([]byte, []int) {
-	return file_course_service_proto_rawDescGZIP(), []int{3}
+	return file_course_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Schedule) GetDay() string {
@@ -403,7 +567,17 @@ const file_course_service_proto_rawDesc = "" +
 	"\n" +
 	"department\x18\n" +
 	" \x01(\tR\n" +
-	"department\"\xb2\x02\n" +
+	"department\"c\n" +
+	"\x13FetchByCodesRequest\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\x12\x1a\n" +
+	"\bacadyear\x18\x02 \x01(\x05R\bacadyear\x12\x1a\n" +
+	"\bsemester\x18\x03 \x01(\x05R\bsemester\"l\n" +
+	"\x14FetchByCodesResponse\x127\n" +
+	"\acourses\x18\x01 \x03(\v2\x1d.coursepb.FetchByCodeResponseR\acourses\x12\x1b\n" +
+	"\tnot_found\x18\x02 \x03(\tR\bnotFound\"]\n" +
+	"\x10FetchByCodesItem\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x125\n" +
+	"\x06course\x18\x02 \x01(\v2\x1d.coursepb.FetchByCodeResponseR\x06course\"\xb2\x02\n" +
 	"\aSection\x12\x16\n" +
 	"\x06number\x18\x01 \x01(\tR\x06number\x120\n" +
 	"\tschedules\x18\x02 \x03(\v2\x12.coursepb.ScheduleR\tschedules\x12\x14\n" +
@@ -422,9 +596,11 @@ const file_course_service_proto_rawDesc = "" +
 	"\x03day\x18\x01 \x01(\tR\x03day\x12\x12\n" +
 	"\x04time\x18\x02 \x01(\tR\x04time\x12\x12\n" +
 	"\x04room\x18\x03 \x01(\tR\x04room\x12\x12\n" +
-	"\x04type\x18\x04 \x01(\tR\x04type2[\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type2\xf8\x01\n" +
 	"\rCourseService\x12J\n" +
-	"\vFetchByCode\x12\x1c.coursepb.FetchByCodeRequest\x1a\x1d.coursepb.FetchByCodeResponseB@Z>github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepbb\x06proto3"
+	"\vFetchByCode\x12\x1c.coursepb.FetchByCodeRequest\x1a\x1d.coursepb.FetchByCodeResponse\x12M\n" +
+	"\fFetchByCodes\x12\x1d.coursepb.FetchByCodesRequest\x1a\x1e.coursepb.FetchByCodesResponse\x12L\n" +
+	"\rStreamByCodes\x12\x1d.coursepb.FetchByCodesRequest\x1a\x1a.coursepb.FetchByCodesItem0\x01B@Z>github.com/CPNext-hub/calendar-reg-main-api/proto/gen/coursepbb\x06proto3"
 
 var (
 	file_course_service_proto_rawDescOnce sync.Once
@@ -438,23 +614,32 @@ func file_course_service_proto_rawDescGZIP() []byte {
 	return file_course_service_proto_rawDescData
 }
 
-var file_course_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_course_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_course_service_proto_goTypes = []any{
-	(*FetchByCodeRequest)(nil),  // 0: coursepb.FetchByCodeRequest
-	(*FetchByCodeResponse)(nil), // 1: coursepb.FetchByCodeResponse
-	(*Section)(nil),             // 2: coursepb.Section
-	(*Schedule)(nil),            // 3: coursepb.Schedule
+	(*FetchByCodeRequest)(nil),   // 0: coursepb.FetchByCodeRequest
+	(*FetchByCodeResponse)(nil),  // 1: coursepb.FetchByCodeResponse
+	(*FetchByCodesRequest)(nil),  // 2: coursepb.FetchByCodesRequest
+	(*FetchByCodesResponse)(nil), // 3: coursepb.FetchByCodesResponse
+	(*FetchByCodesItem)(nil),     // 4: coursepb.FetchByCodesItem
+	(*Section)(nil),              // 5: coursepb.Section
+	(*Schedule)(nil),             // 6: coursepb.Schedule
 }
 var file_course_service_proto_depIdxs = []int32{
-	2, // 0: coursepb.FetchByCodeResponse.sections:type_name -> coursepb.Section
-	3, // 1: coursepb.Section.schedules:type_name -> coursepb.Schedule
-	0, // 2: coursepb.CourseService.FetchByCode:input_type -> coursepb.FetchByCodeRequest
-	1, // 3: coursepb.CourseService.FetchByCode:output_type -> coursepb.FetchByCodeResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	5, // 0: coursepb.FetchByCodeResponse.sections:type_name -> coursepb.Section
+	1, // 1: coursepb.FetchByCodesResponse.courses:type_name -> coursepb.FetchByCodeResponse
+	1, // 2: coursepb.FetchByCodesItem.course:type_name -> coursepb.FetchByCodeResponse
+	6, // 3: coursepb.Section.schedules:type_name -> coursepb.Schedule
+	0, // 4: coursepb.CourseService.FetchByCode:input_type -> coursepb.FetchByCodeRequest
+	2, // 5: coursepb.CourseService.FetchByCodes:input_type -> coursepb.FetchByCodesRequest
+	2, // 6: coursepb.CourseService.StreamByCodes:input_type -> coursepb.FetchByCodesRequest
+	1, // 7: coursepb.CourseService.FetchByCode:output_type -> coursepb.FetchByCodeResponse
+	3, // 8: coursepb.CourseService.FetchByCodes:output_type -> coursepb.FetchByCodesResponse
+	4, // 9: coursepb.CourseService.StreamByCodes:output_type -> coursepb.FetchByCodesItem
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_course_service_proto_init() }
@@ -468,7 +653,7 @@ func file_course_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_course_service_proto_rawDesc), len(file_course_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CourseService_FetchByCode_FullMethodName = "/coursepb.CourseService/FetchByCode"
+	CourseService_FetchByCode_FullMethodName   = "/coursepb.CourseService/FetchByCode"
+	CourseService_FetchByCodes_FullMethodName  = "/coursepb.CourseService/FetchByCodes"
+	CourseService_StreamByCodes_FullMethodName = "/coursepb.CourseService/StreamByCodes"
 )
 
 // CourseServiceClient is the client API for CourseService service.
@@ -28,6 +30,14 @@ const (
 type CourseServiceClient interface {
 	// FetchByCode returns course data for the given course code.
 	FetchByCode(ctx context.Context, in *FetchByCodeRequest, opts ...grpc.CallOption) (*FetchByCodeResponse, error)
+	// FetchByCodes returns course data for several codes of one academic year
+	// and semester in a single call. Unknown codes are listed in not_found
+	// instead of failing the whole batch.
+	FetchByCodes(ctx context.Context, in *FetchByCodesRequest, opts ...grpc.CallOption) (*FetchByCodesResponse, error)
+	// StreamByCodes is the server-streaming form of FetchByCodes: one message
+	// per requested code, sent as soon as it is ready. Use it for batches whose
+	// combined response would be too large for a single message.
+	StreamByCodes(ctx context.Context, in *FetchByCodesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FetchByCodesItem], error)
 }
 
 type courseServiceClient struct {
@@ -48,12 +58,49 @@ func (c *courseServiceClient) FetchByCode(ctx context.Context, in *FetchByCodeRe
 	return out, nil
 }
 
+func (c *courseServiceClient) FetchByCodes(ctx context.Context, in *FetchByCodesRequest, opts ...grpc.CallOption) (*FetchByCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FetchByCodesResponse)
+	err := c.cc.Invoke(ctx, CourseService_FetchByCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) StreamByCodes(ctx context.Context, in *FetchByCodesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FetchByCodesItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CourseService_ServiceDesc.Streams[0], CourseService_StreamByCodes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchByCodesRequest, FetchByCodesItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CourseService_StreamByCodesClient = grpc.ServerStreamingClient[FetchByCodesItem]
+
 // CourseServiceServer is the server API for CourseService service.
 // All implementations must embed UnimplementedCourseServiceServer
 // for forward compatibility.
 type CourseServiceServer interface {
 	// FetchByCode returns course data for the given course code.
 	FetchByCode(context.Context, *FetchByCodeRequest) (*FetchByCodeResponse, error)
+	// FetchByCodes returns course data for several codes of one academic year
+	// and semester in a single call. Unknown codes are listed in not_found
+	// instead of failing the whole batch.
+	FetchByCodes(context.Context, *FetchByCodesRequest) (*FetchByCodesResponse, error)
+	// StreamByCodes is the server-streaming form of FetchByCodes: one message
+	// per requested code, sent as soon as it is ready. Use it for batches whose
+	// combined response would be too large for a single message.
+	StreamByCodes(*FetchByCodesRequest, grpc.ServerStreamingServer[FetchByCodesItem]) error
 	mustEmbedUnimplementedCourseServiceServer()
 }
 
@@ -67,6 +114,12 @@ type UnimplementedCourseServiceServer struct{}
 func (UnimplementedCourseServiceServer) FetchByCode(context.Context, *FetchByCodeRequest) (*FetchByCodeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method FetchByCode not implemented")
 }
+func (UnimplementedCourseServiceServer) FetchByCodes(context.Context, *FetchByCodesRequest) (*FetchByCodesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FetchByCodes not implemented")
+}
+func (UnimplementedCourseServiceServer) StreamByCodes(*FetchByCodesRequest, grpc.ServerStreamingServer[FetchByCodesItem]) error {
+	return status.Error(codes.Unimplemented, "method StreamByCodes not implemented")
+}
 func (UnimplementedCourseServiceServer) mustEmbedUnimplementedCourseServiceServer() {}
 func (UnimplementedCourseServiceServer) testEmbeddedByValue()                       {}
 
@@ -106,6 +159,35 @@ func _CourseService_FetchByCode_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CourseService_FetchByCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchByCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).FetchByCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_FetchByCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).FetchByCodes(ctx, req.(*FetchByCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_StreamByCodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchByCodesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CourseServiceServer).StreamByCodes(m, &grpc.GenericServerStream[FetchByCodesRequest, FetchByCodesItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CourseService_StreamByCodesServer = grpc.ServerStreamingServer[FetchByCodesItem]
+
 // CourseService_ServiceDesc is the grpc.ServiceDesc for CourseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,7 +199,17 @@ var CourseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FetchByCode",
 			Handler:    _CourseService_FetchByCode_Handler,
 		},
+		{
+			MethodName: "FetchByCodes",
+			Handler:    _CourseService_FetchByCodes_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamByCodes",
+			Handler:       _CourseService_StreamByCodes_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "course_service.proto",
 }
@@ -1,11 +1,16 @@
 """Mock Course API — returns sample course JSON for testing.
 
 Usage:
-  GET /<code>  → returns course data if found, 404 otherwise.
-  Example: GET /CP353004
+  GET /<code>?acadyear=<year>&semester=<n>
+      → returns course data if the course is offered in that term, 404 otherwise.
+        acadyear and semester are optional; without them any term matches.
+  Example: GET /CP353004?acadyear=2567&semester=1
+
+This is the request shape sent by the HTTP course source (COURSE_API=http).
 """
 
 from http.server import HTTPServer, BaseHTTPRequestHandler
+from urllib.parse import parse_qs, urlparse
 import json
 import time
 
@@ -86,8 +91,12 @@ class Handler(BaseHTTPRequestHandler):
     def do_GET(self):
         time.sleep(20)  # Simulate slow response
 
-        # Extract course code from path: /CP353004 → "CP353004"
-        code = self.path.strip("/")
+        # Extract course code from path: /CP353004?acadyear=2567&semester=1 → "CP353004"
+        url = urlparse(self.path)
+        code = url.path.strip("/")
+        query = parse_qs(url.query)
+        acadyear = query.get("acadyear", [None])[0]
+        semester = query.get("semester", [None])[0]
 
         if not code:
             # No code provided — list all available codes
@@ -102,12 +111,17 @@ class Handler(BaseHTTPRequestHandler):
             return
 
         course = COURSES.get(code)
+        if course is not None and (
+            (acadyear is not None and acadyear != str(course["year"]))
+            or (semester is not None and semester != str(course["semester"]))
+        ):
+            course = None  # not offered in the requested term
         if course is None:
             try:
                 self.send_response(404)
                 self.send_header("Content-Type", "application/json; charset=utf-8")
                 self.end_headers()
-                body = {"error": f"course '{code}' not found"}
+                body = {"error": f"course '{code}' not found in {acadyear}/{semester}"}
                 self.wfile.write(json.dumps(body, ensure_ascii=False, indent=2).encode("utf-8"))
             except BrokenPipeError:
                 print("⚠️  Client disconnected (timeout)")
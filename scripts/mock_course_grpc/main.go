@@ -125,6 +125,37 @@ func (s *server) FetchByCode(_ context.Context, req *pb.FetchByCodeRequest) (*pb
 	return course, nil
 }
 
+// FetchByCodes simulates one slow upstream lookup for the whole batch.
+func (s *server) FetchByCodes(_ context.Context, req *pb.FetchByCodesRequest) (*pb.FetchByCodesResponse, error) {
+	log.Printf("FetchByCodes request: %d codes acadyear=%d semester=%d", len(req.Codes), req.Acadyear, req.Semester)
+
+	time.Sleep(sleepDuration)
+
+	resp := &pb.FetchByCodesResponse{}
+	for _, code := range req.Codes {
+		if course, ok := courses[code]; ok {
+			resp.Courses = append(resp.Courses, course)
+		} else {
+			resp.NotFound = append(resp.NotFound, code)
+		}
+	}
+	return resp, nil
+}
+
+// StreamByCodes sends one item per requested code after a single slow lookup.
+func (s *server) StreamByCodes(req *pb.FetchByCodesRequest, stream grpc.ServerStreamingServer[pb.FetchByCodesItem]) error {
+	log.Printf("StreamByCodes request: %d codes acadyear=%d semester=%d", len(req.Codes), req.Acadyear, req.Semester)
+
+	time.Sleep(sleepDuration)
+
+	for _, code := range req.Codes {
+		if err := stream.Send(&pb.FetchByCodesItem{Code: code, Course: courses[code]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func run(ctx context.Context, addr string, ready chan<- string) error {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
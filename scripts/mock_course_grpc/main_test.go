@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -47,6 +48,20 @@ func TestFetchByCode_NotFound(t *testing.T) {
 	assert.Equal(t, codes.NotFound, st.Code())
 }
 
+func TestFetchByCodes(t *testing.T) {
+	originalSleep := sleepDuration
+	sleepDuration = 1 * time.Millisecond
+	defer func() { sleepDuration = originalSleep }()
+
+	s := &server{}
+	res, err := s.FetchByCodes(context.Background(), &pb.FetchByCodesRequest{Codes: []string{"CP353004", "INVALID", "CP353002"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Courses, 2)
+	assert.Equal(t, "CP353004", res.Courses[0].Code)
+	assert.Equal(t, []string{"INVALID"}, res.NotFound)
+}
+
 func TestRun(t *testing.T) {
 	// Test that run starts the server and stops on context cancel
 	ctx, cancel := context.WithCancel(context.Background())
@@ -84,6 +99,22 @@ func TestRun(t *testing.T) {
 	_, err = client.FetchByCode(context.Background(), &pb.FetchByCodeRequest{Code: "CP353004"})
 	assert.NoError(t, err)
 
+	stream, err := client.StreamByCodes(context.Background(), &pb.FetchByCodesRequest{Codes: []string{"CP353006", "INVALID"}})
+	assert.NoError(t, err)
+	var items []*pb.FetchByCodesItem
+	for {
+		item, err := stream.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+			break
+		}
+		items = append(items, item)
+	}
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, "CP353006", items[0].Course.GetCode())
+		assert.Nil(t, items[1].Course)
+	}
+
 	// Cancel context to stop server
 	cancel()
 
@@ -0,0 +1,314 @@
+// Command mock_oidc is a stand-in OpenID Connect provider for local
+// development and tests. It signs in any of a few fixed university users
+// without a password, enforcing the authorization-code flow with PKCE (S256)
+// and issuing RS256-signed ID tokens.
+//
+// Point the API at it with:
+//
+//	OIDC_ISSUER=http://localhost:9000
+//	OIDC_CLIENT_ID=calendar-reg
+//	OIDC_REDIRECT_URL=http://localhost:8080/api/v1/auth/oidc/callback
+//	OIDC_ROLE_CLAIM=groups
+//	OIDC_ROLE_MAP=staff=admin,students=student
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID    = "mock-oidc-1"
+	codeTTL  = time.Minute
+	tokenTTL = 5 * time.Minute
+)
+
+type user struct {
+	Subject string
+	Name    string
+	Groups  []string
+}
+
+// users are the accounts that can sign in, keyed by preferred_username.
+var users = map[string]user{
+	"somchai.s": {Subject: "6401234567", Name: "Somchai Sukjai", Groups: []string{"students"}},
+	"malee.k":   {Subject: "6407654321", Name: "Malee Kongdee", Groups: []string{"students"}},
+	"ajarn.p":   {Subject: "staff-0042", Name: "Prasert Wongsa", Groups: []string{"staff"}},
+}
+
+// authCode is an issued, not yet redeemed authorization code.
+type authCode struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	nonce       string
+	username    string
+	expiresAt   time.Time
+}
+
+type provider struct {
+	issuer string
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authCode
+}
+
+// injectable functions for testability
+var runFunc = run
+var logFatal = log.Fatalf
+
+func newProvider(issuer string) (*provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &provider{issuer: issuer, key: key, codes: map[string]authCode{}}, nil
+}
+
+func (p *provider) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("GET /authorize", p.authorize)
+	mux.HandleFunc("POST /token", p.token)
+	return mux
+}
+
+func (p *provider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *provider) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   b64(pub.N.Bytes()),
+			"e":   b64(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+var pickUser = template.Must(template.New("pick").Parse(`<!doctype html>
+<title>Mock university SSO</title>
+<h1>Sign in as</h1>
+<ul>{{range $name, $u := .Users}}<li><a href="{{$.Base}}&login_hint={{$name}}">{{$u.Name}} ({{$name}}, {{range $u.Groups}}{{.}} {{end}})</a></li>{{end}}</ul>
+`))
+
+// authorize issues a code for the user named by login_hint, or shows a
+// page to pick one.
+func (p *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("response_type") != "code" || q.Get("client_id") == "" || redirectURI == "" {
+		http.Error(w, "response_type=code, client_id and redirect_uri are required", http.StatusBadRequest)
+		return
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "PKCE with code_challenge_method=S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	username := q.Get("login_hint")
+	if username == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = pickUser.Execute(w, map[string]interface{}{"Users": users, "Base": template.URL(r.URL.String())})
+		return
+	}
+	if _, ok := users[username]; !ok {
+		http.Error(w, fmt.Sprintf("unknown user %q", username), http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = authCode{
+		clientID:    q.Get("client_id"),
+		redirectURI: redirectURI,
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		username:    username,
+		expiresAt:   time.Now().Add(codeTTL),
+	}
+	p.mu.Unlock()
+
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	back := target.Query()
+	back.Set("code", code)
+	back.Set("state", q.Get("state"))
+	target.RawQuery = back.Encode()
+	log.Printf("authorize: %s signed in for client %s", username, q.Get("client_id"))
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// token redeems an authorization code. Codes are single use.
+func (p *provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	p.mu.Lock()
+	code, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	clientID := r.PostForm.Get("client_id")
+	if basicID, _, hasBasic := r.BasicAuth(); hasBasic {
+		clientID, _ = url.QueryUnescape(basicID)
+	}
+	if err := code.check(clientID, r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier")); !ok || err != nil {
+		desc := "unknown or already used code"
+		if err != nil {
+			desc = err.Error()
+		}
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": desc})
+		return
+	}
+
+	idToken, err := p.idToken(code)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (c authCode) check(clientID, redirectURI, verifier string) error {
+	switch {
+	case time.Now().After(c.expiresAt):
+		return errors.New("code expired")
+	case clientID != c.clientID:
+		return errors.New("code was issued to another client")
+	case redirectURI != c.redirectURI:
+		return errors.New("redirect_uri does not match")
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	if b64(sum[:]) != c.challenge {
+		return errors.New("code_verifier does not match code_challenge")
+	}
+	return nil
+}
+
+func (p *provider) idToken(c authCode) (string, error) {
+	u := users[c.username]
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":                p.issuer,
+		"sub":                u.Subject,
+		"aud":                c.clientID,
+		"exp":                now.Add(tokenTTL).Unix(),
+		"iat":                now.Unix(),
+		"preferred_username": c.username,
+		"name":               u.Name,
+		"groups":             u.Groups,
+	}
+	if c.nonce != "" {
+		claims["nonce"] = c.nonce
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(p.key)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return b64(b)
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// run serves the provider on addr. An empty issuer is derived from the
+// listening address, which lets tests use ":0".
+func run(ctx context.Context, addr, issuer string, ready chan<- string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if issuer == "" {
+		issuer = "http://" + lis.Addr().String()
+	}
+
+	p, err := newProvider(issuer)
+	if err != nil {
+		lis.Close()
+		return err
+	}
+	srv := &http.Server{Handler: p.routes(), ReadHeaderTimeout: 5 * time.Second}
+
+	if ready != nil {
+		ready <- issuer
+	}
+
+	// Handle context cancellation to stop server
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	log.Printf("🚀 Mock OIDC provider running at %s", issuer)
+	log.Printf("👤 Available users: somchai.s, malee.k (students), ajarn.p (staff)")
+	if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func main() {
+	issuer := os.Getenv("MOCK_OIDC_ISSUER")
+	if issuer == "" {
+		issuer = "http://localhost:9000"
+	}
+	if err := runFunc(context.Background(), ":9000", issuer, nil); err != nil {
+		logFatal("failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/domain/repository"
+	"github.com/CPNext-hub/calendar-reg-main-api/internal/infrastructure/externalapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRedirect = "http://localhost:8080/api/v1/auth/oidc/callback"
+
+// startProvider runs the mock provider on a random port and returns its issuer.
+func startProvider(t *testing.T) string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ready := make(chan string, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- run(ctx, "127.0.0.1:0", "", ready)
+	}()
+
+	select {
+	case issuer := <-ready:
+		return issuer
+	case err := <-errCh:
+		t.Fatalf("run failed to start: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for provider to start")
+	}
+	return ""
+}
+
+// authorize follows the authorization URL as the given user and returns the
+// code and state sent back to the redirect URI.
+func authorize(t *testing.T, authURL, username string) (string, string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL + "&login_hint=" + username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected redirect, got %d", resp.StatusCode)
+	}
+
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, testRedirect, loc.Scheme+"://"+loc.Host+loc.Path)
+	return loc.Query().Get("code"), loc.Query().Get("state")
+}
+
+func challengeFor(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return b64(sum[:])
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	issuer := startProvider(t)
+	idp := externalapi.NewOIDCProvider(externalapi.OIDCProviderConfig{
+		Issuer:      issuer,
+		ClientID:    "calendar-reg",
+		RedirectURL: testRedirect,
+		Scopes:      []string{"profile"},
+	})
+	ctx := context.Background()
+	verifier := randomString() + randomString()
+
+	authURL, err := idp.AuthCodeURL(ctx, "st-1", "nonce-1", challengeFor(verifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := authorize(t, authURL, "ajarn.p")
+	assert.Equal(t, "st-1", state)
+
+	identity, err := idp.Exchange(ctx, code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, issuer, identity.Issuer)
+	assert.Equal(t, "staff-0042", identity.Subject)
+	assert.Equal(t, "ajarn.p", identity.Claims["preferred_username"])
+	assert.Equal(t, []interface{}{"staff"}, identity.Claims["groups"])
+
+	// Codes are single use.
+	_, err = idp.Exchange(ctx, code, verifier, "nonce-1")
+	assert.ErrorIs(t, err, repository.ErrIdentityRejected)
+}
+
+func TestAuthorizationCodeFlow_Rejections(t *testing.T) {
+	issuer := startProvider(t)
+	ctx := context.Background()
+	verifier := randomString() + randomString()
+	newIDP := func(clientID string) repository.IdentityProvider {
+		return externalapi.NewOIDCProvider(externalapi.OIDCProviderConfig{Issuer: issuer, ClientID: clientID, RedirectURL: testRedirect})
+	}
+	idp := newIDP("calendar-reg")
+
+	tests := []struct {
+		name     string
+		exchange func(code string) error
+	}{
+		{"wrong PKCE verifier", func(code string) error {
+			_, err := idp.Exchange(ctx, code, "not-the-verifier", "nonce-1")
+			return err
+		}},
+		{"nonce mismatch", func(code string) error {
+			_, err := idp.Exchange(ctx, code, verifier, "other-nonce")
+			return err
+		}},
+		{"code issued to another client", func(code string) error {
+			_, err := newIDP("someone-else").Exchange(ctx, code, verifier, "nonce-1")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authURL, err := idp.AuthCodeURL(ctx, "st", "nonce-1", challengeFor(verifier))
+			if err != nil {
+				t.Fatal(err)
+			}
+			code, _ := authorize(t, authURL, "somchai.s")
+			assert.ErrorIs(t, tt.exchange(code), repository.ErrIdentityRejected)
+		})
+	}
+}
+
+func TestAuthorize_RequiresPKCE(t *testing.T) {
+	issuer := startProvider(t)
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {"calendar-reg"},
+		"redirect_uri":  {testRedirect},
+		"login_hint":    {"somchai.s"},
+	}
+	resp, err := http.Get(issuer + "/authorize?" + q.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMain_Success(t *testing.T) {
+	origRun := runFunc
+	origFatal := logFatal
+	defer func() {
+		runFunc = origRun
+		logFatal = origFatal
+	}()
+
+	runFunc = func(ctx context.Context, addr, issuer string, ready chan<- string) error {
+		return nil
+	}
+
+	fatalCalled := false
+	logFatal = func(format string, args ...interface{}) {
+		fatalCalled = true
+	}
+
+	main()
+
+	assert.False(t, fatalCalled, "logFatal should not be called on success")
+}
+
+func TestMain_Error(t *testing.T) {
+	origRun := runFunc
+	origFatal := logFatal
+	defer func() {
+		runFunc = origRun
+		logFatal = origFatal
+	}()
+
+	runFunc = func(ctx context.Context, addr, issuer string, ready chan<- string) error {
+		return errors.New("bind error")
+	}
+
+	var fatalMsg string
+	logFatal = func(format string, args ...interface{}) {
+		fatalMsg = fmt.Sprintf(format, args...)
+	}
+
+	main()
+
+	assert.Contains(t, fatalMsg, "bind error")
+}